@@ -15,9 +15,10 @@ import (
 func main() {
 	// コマンドラインオプション
 	var (
-		host = flag.String("host", "", "サーバーのホスト (デフォルト: 0.0.0.0)")
-		port = flag.Int("port", 0, "サーバーのポート (デフォルト: 8009)")
-		help = flag.Bool("help", false, "ヘルプを表示")
+		configPath = flag.String("config", "", "設定ファイルのパス (.yaml/.yml/.toml)")
+		host       = flag.String("host", "", "サーバーのホスト (デフォルト: 0.0.0.0)")
+		port       = flag.Int("port", 0, "サーバーのポート (デフォルト: 8009)")
+		help       = flag.Bool("help", false, "ヘルプを表示")
 	)
 
 	flag.Parse()
@@ -35,7 +36,7 @@ func main() {
 	}
 
 	// 設定を読み込む
-	cfg, err := config.Load()
+	cfg, err := config.LoadFile(*configPath)
 	if err != nil {
 		log.Fatalf("設定の読み込みに失敗しました: %v", err)
 	}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tokenBytes は生成するAPIトークン・セッションIDのバイト数
+const tokenBytes = 32
+
+// HashPassword はパスワードをbcryptでハッシュ化する（設定ファイルの password_hash 用）
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", fmt.Errorf("パスワードが空です")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("パスワードのハッシュ化に失敗: %w", err)
+	}
+	return string(hash), nil
+}
+
+// GenerateToken は新しいAPIトークンと設定ファイルに記述するハッシュを生成する
+func GenerateToken() (token, hash string, err error) {
+	token, err = randomString()
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashToken(token), nil
+}
+
+// HashToken はAPIトークンのSHA-256を16進数で返す
+// トークンは十分に長い乱数のため、パスワードと異なり高速なハッシュで照合する
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomString は暗号論的乱数からURLで安全な文字列を生成する
+func randomString() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("乱数の生成に失敗: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// validatePasswordHash はbcryptのハッシュとして解釈できるか検証する
+func validatePasswordHash(hash string) error {
+	if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+		return fmt.Errorf("password_hash がbcryptのハッシュではありません: %w", err)
+	}
+	return nil
+}
+
+// decodeTokenHash はトークンのハッシュ（SHA-256の16進数）をバイト列に変換する
+func decodeTokenHash(hash string) ([]byte, error) {
+	decoded, err := hex.DecodeString(hash)
+	if err != nil || len(decoded) != sha256.Size {
+		return nil, fmt.Errorf("token_hash はSHA-256の16進数（64文字）で指定してください")
+	}
+	return decoded, nil
+}
+
+// userStore はパスワードでログインするユーザーを照合する
+type userStore struct {
+	users map[string]User
+}
+
+// newUserStore はユーザー一覧から userStore を作成する
+func newUserStore(users []User) *userStore {
+	store := &userStore{users: make(map[string]User, len(users))}
+	for _, user := range users {
+		store.users[user.Username] = user
+	}
+	return store
+}
+
+// dummyPasswordHash は存在しないユーザーの照合に使うハッシュ（初回のログイン時に生成する）
+// ユーザーの有無で応答時間が変わらないよう、常にbcryptの比較を行う
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("senrigan-dummy-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// verify はユーザー名とパスワードを照合する
+func (s *userStore) verify(username, password string) (User, error) {
+	user, exists := s.users[username]
+	hash := []byte(user.PasswordHash)
+	if !exists {
+		hash = dummyPasswordHash()
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || !exists {
+		return User{}, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// tokenStore はAPIトークンを照合する
+type tokenStore struct {
+	tokens []storedToken
+}
+
+// storedToken は照合用にハッシュをデコードしたトークン
+type storedToken struct {
+	hash  []byte
+	token Token
+}
+
+// newTokenStore はトークン一覧から tokenStore を作成する（不正なハッシュは無視する）
+func newTokenStore(tokens []Token) *tokenStore {
+	store := &tokenStore{}
+	for _, token := range tokens {
+		hash, err := decodeTokenHash(token.TokenHash)
+		if err != nil {
+			continue
+		}
+		store.tokens = append(store.tokens, storedToken{hash: hash, token: token})
+	}
+	return store
+}
+
+// verify はトークンを照合する（全てのトークンと定数時間で比較する）
+func (s *tokenStore) verify(token string) (Token, error) {
+	sum := sha256.Sum256([]byte(token))
+
+	var matched *Token
+	for i := range s.tokens {
+		if subtle.ConstantTimeCompare(sum[:], s.tokens[i].hash) == 1 {
+			matched = &s.tokens[i].token
+		}
+	}
+	if matched == nil {
+		return Token{}, ErrInvalidCredentials
+	}
+	return *matched, nil
+}
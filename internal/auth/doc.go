@@ -0,0 +1,20 @@
+// Package auth は利用者の認証と権限の管理を提供します。
+//
+// 主な機能:
+// - 設定ファイルに記述したユーザー（bcryptでハッシュ化したパスワード）によるログイン
+// - ログインセッション（SPA用のCookie）の発行・照合・破棄
+// - スクリプト用のAPIトークン（Authorization: Bearer）の照合
+// - viewer / operator / admin の権限による操作の可否判定
+// - ユーザー・トークン毎の閲覧できるカメラの制限
+//
+// 責務:
+// - Manager: 認証方式の管理とログイン・ログアウト
+// - Authenticator: リクエストから利用者を特定する認証方式（追加可能）
+//
+// 仕様:
+// - 権限は viewer < operator < admin の順で、上位の権限は下位の操作を全て行える
+// - セッションはメモリ上に保持し、有効期間（デフォルト24時間）を過ぎると無効になる
+// - APIトークンはSHA-256のハッシュのみを設定ファイルに記述する
+// - 閲覧できるカメラはIDのパターン（path.Match の形式）で指定し、未指定の場合は全てのカメラを閲覧できる
+// - 認証が無効の場合は全てのリクエストを admin 権限の Anonymous として扱う
+package auth
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// SessionCookieName はログインセッションのCookie名
+const SessionCookieName = "senrigan_session"
+
+// Authenticator はリクエストから利用者を特定する認証方式
+// 独自の方式（リバースプロキシが付与するヘッダー等）は Manager.AddAuthenticator で追加する
+type Authenticator interface {
+	// Authenticate はリクエストの認証情報を照合する
+	// 認証情報がない場合は ErrNoCredentials を返し、次の認証方式に任せる
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+// Manager は認証とログインセッションを管理するインターフェース
+type Manager interface {
+	// Enabled は認証が有効か返す（無効の場合は全てのリクエストを Anonymous として扱う）
+	Enabled() bool
+
+	// Login はユーザー名とパスワードを照合してセッションを作成する
+	Login(username, password string) (sessionID string, principal Principal, err error)
+	// Logout はセッションを破棄する
+	Logout(sessionID string)
+
+	// Authenticate は登録された認証方式を順に試してリクエストの利用者を特定する
+	Authenticate(r *http.Request) (Principal, error)
+	// AddAuthenticator は認証方式を追加する（組み込みの方式の後に試す）
+	AddAuthenticator(authenticator Authenticator)
+
+	// SessionCookie はセッションIDを保持するCookieを返す
+	SessionCookie(sessionID string, principal Principal) *http.Cookie
+	// ExpiredSessionCookie はセッションCookieを削除するCookieを返す
+	ExpiredSessionCookie() *http.Cookie
+}
+
+// DefaultManager はManagerのデフォルト実装
+// 設定ファイルのユーザー（bcrypt）・APIトークン（SHA-256）とメモリ上のセッションで認証する
+type DefaultManager struct {
+	config   Config
+	users    *userStore
+	sessions *sessionStore
+
+	mu             sync.RWMutex
+	authenticators []Authenticator
+}
+
+// NewDefaultManager は新しいDefaultManagerを作成する
+func NewDefaultManager(config Config) *DefaultManager {
+	if config.SessionTTL <= 0 {
+		config.SessionTTL = DefaultConfig().SessionTTL
+	}
+
+	m := &DefaultManager{
+		config:   config,
+		users:    newUserStore(config.Users),
+		sessions: newSessionStore(config.SessionTTL),
+	}
+	m.authenticators = []Authenticator{
+		sessionAuthenticator{sessions: m.sessions},
+		tokenAuthenticator{tokens: newTokenStore(config.Tokens)},
+	}
+	return m
+}
+
+// Enabled は認証が有効か返す
+func (m *DefaultManager) Enabled() bool {
+	return m.config.Enabled
+}
+
+// Login はユーザー名とパスワードを照合してセッションを作成する
+func (m *DefaultManager) Login(username, password string) (string, Principal, error) {
+	user, err := m.users.verify(username, password)
+	if err != nil {
+		log.Printf("ユーザー %q のログインに失敗しました", username)
+		return "", Principal{}, err
+	}
+
+	sessionID, principal, err := m.sessions.create(Principal{Name: user.Username, Role: user.Role, Cameras: user.Cameras})
+	if err != nil {
+		return "", Principal{}, fmt.Errorf("セッションの作成に失敗: %w", err)
+	}
+
+	log.Printf("ユーザー %s がログインしました（権限: %s）", user.Username, user.Role)
+	return sessionID, principal, nil
+}
+
+// Logout はセッションを破棄する
+func (m *DefaultManager) Logout(sessionID string) {
+	m.sessions.delete(sessionID)
+}
+
+// Authenticate は登録された認証方式を順に試してリクエストの利用者を特定する
+func (m *DefaultManager) Authenticate(r *http.Request) (Principal, error) {
+	if !m.config.Enabled {
+		return Anonymous, nil
+	}
+
+	m.mu.RLock()
+	authenticators := m.authenticators
+	m.mu.RUnlock()
+
+	for _, authenticator := range authenticators {
+		principal, err := authenticator.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+	return Principal{}, ErrNoCredentials
+}
+
+// AddAuthenticator は認証方式を追加する
+func (m *DefaultManager) AddAuthenticator(authenticator Authenticator) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.authenticators = append(m.authenticators[:len(m.authenticators):len(m.authenticators)], authenticator)
+}
+
+// SessionCookie はセッションIDを保持するCookieを返す
+// JavaScriptからは参照できず、他サイトからのPOSTには付与されない
+func (m *DefaultManager) SessionCookie(sessionID string, principal Principal) *http.Cookie {
+	return &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		Expires:  principal.ExpiresAt,
+		HttpOnly: true,
+		Secure:   m.config.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// ExpiredSessionCookie はセッションCookieを削除するCookieを返す
+func (m *DefaultManager) ExpiredSessionCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   m.config.CookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// sessionAuthenticator はセッションCookieで認証する
+type sessionAuthenticator struct {
+	sessions *sessionStore
+}
+
+// Authenticate はセッションCookieのセッションを照合する
+func (a sessionAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return Principal{}, ErrNoCredentials
+	}
+	return a.sessions.get(cookie.Value)
+}
+
+// tokenAuthenticator は Authorization: Bearer ヘッダーのAPIトークンで認証する
+type tokenAuthenticator struct {
+	tokens *tokenStore
+}
+
+// Authenticate はAPIトークンを照合する
+func (a tokenAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return Principal{}, ErrNoCredentials
+	}
+
+	matched, err := a.tokens.verify(strings.TrimSpace(token))
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{Name: matched.Name, Role: matched.Role, Method: MethodToken, Cameras: matched.Cameras}, nil
+}
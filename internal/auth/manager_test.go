@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestManager はユーザー1人・トークン1つを登録した DefaultManager を作成する
+func newTestManager(t *testing.T) (*DefaultManager, string) {
+	t.Helper()
+
+	passwordHash, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, tokenHash, err := GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Users = []User{{Username: "alice", PasswordHash: passwordHash, Role: RoleOperator}}
+	config.Tokens = []Token{{Name: "backup", TokenHash: tokenHash, Role: RoleViewer}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected valid config, got %v", err)
+	}
+	return NewDefaultManager(config), token
+}
+
+func TestRole_Allows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{RoleViewer, RoleViewer, true},
+		{RoleViewer, RoleOperator, false},
+		{RoleOperator, RoleViewer, true},
+		{RoleOperator, RoleAdmin, false},
+		{RoleAdmin, RoleOperator, true},
+		{Role("unknown"), RoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Allows(tt.required); got != tt.want {
+			t.Errorf("%s.Allows(%s) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultManager_Disabled(t *testing.T) {
+	manager := NewDefaultManager(DefaultConfig())
+
+	principal, err := manager.Authenticate(httptest.NewRequest("GET", "/api/cameras", nil))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if principal.Name != Anonymous.Name || principal.Role != RoleAdmin || !principal.ViewsAllCameras() {
+		t.Errorf("Expected anonymous principal, got %+v", principal)
+	}
+}
+
+func TestDefaultManager_LoginSession(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	if _, _, err := manager.Login("alice", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials for wrong password, got %v", err)
+	}
+	if _, _, err := manager.Login("bob", "secret"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials for unknown user, got %v", err)
+	}
+
+	sessionID, principal, err := manager.Login("alice", "secret")
+	if err != nil {
+		t.Fatalf("Expected login to succeed, got %v", err)
+	}
+	if principal.Name != "alice" || principal.Role != RoleOperator || principal.Method != MethodSession {
+		t.Errorf("Unexpected principal: %+v", principal)
+	}
+
+	cookie := manager.SessionCookie(sessionID, principal)
+	if !cookie.HttpOnly || cookie.SameSite != http.SameSiteLaxMode {
+		t.Errorf("Expected HttpOnly SameSite=Lax cookie, got %+v", cookie)
+	}
+
+	request := httptest.NewRequest("GET", "/api/auth/me", nil)
+	request.AddCookie(cookie)
+	authenticated, err := manager.Authenticate(request)
+	if err != nil {
+		t.Fatalf("Expected session to authenticate, got %v", err)
+	}
+	if authenticated.Name != "alice" {
+		t.Errorf("Expected alice, got %s", authenticated.Name)
+	}
+
+	manager.Logout(sessionID)
+	if _, err := manager.Authenticate(request); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Expected ErrSessionExpired after logout, got %v", err)
+	}
+}
+
+func TestDefaultManager_SessionExpiry(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	now := time.Now()
+	manager.sessions.now = func() time.Time { return now }
+
+	sessionID, principal, err := manager.Login("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !principal.ExpiresAt.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("Expected expiry after 24h, got %v", principal.ExpiresAt)
+	}
+
+	request := httptest.NewRequest("GET", "/api/cameras", nil)
+	request.AddCookie(&http.Cookie{Name: SessionCookieName, Value: sessionID})
+
+	now = now.Add(24 * time.Hour)
+	if _, err := manager.Authenticate(request); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Expected ErrSessionExpired, got %v", err)
+	}
+}
+
+func TestDefaultManager_Token(t *testing.T) {
+	manager, token := newTestManager(t)
+
+	request := httptest.NewRequest("GET", "/api/cameras", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	principal, err := manager.Authenticate(request)
+	if err != nil {
+		t.Fatalf("Expected token to authenticate, got %v", err)
+	}
+	if principal.Name != "backup" || principal.Role != RoleViewer || principal.Method != MethodToken {
+		t.Errorf("Unexpected principal: %+v", principal)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+strings.Repeat("x", len(token)))
+	if _, err := manager.Authenticate(request); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected ErrInvalidCredentials for unknown token, got %v", err)
+	}
+
+	if _, err := manager.Authenticate(httptest.NewRequest("GET", "/api/cameras", nil)); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Expected ErrNoCredentials without credentials, got %v", err)
+	}
+}
+
+// headerAuthenticator はテスト用の独自の認証方式
+type headerAuthenticator struct{}
+
+func (headerAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	user := r.Header.Get("X-Remote-User")
+	if user == "" {
+		return Principal{}, ErrNoCredentials
+	}
+	return Principal{Name: user, Role: RoleAdmin, Method: "proxy"}, nil
+}
+
+func TestDefaultManager_AddAuthenticator(t *testing.T) {
+	manager, _ := newTestManager(t)
+	manager.AddAuthenticator(headerAuthenticator{})
+
+	request := httptest.NewRequest("GET", "/api/cameras", nil)
+	request.Header.Set("X-Remote-User", "carol")
+	principal, err := manager.Authenticate(request)
+	if err != nil {
+		t.Fatalf("Expected custom authenticator to succeed, got %v", err)
+	}
+	if principal.Name != "carol" {
+		t.Errorf("Expected carol, got %s", principal.Name)
+	}
+}
+
+func TestPrincipal_CanViewCamera(t *testing.T) {
+	principal := Principal{Name: "ops", Role: RoleViewer, Cameras: []string{"office", "server_*"}}
+
+	tests := map[string]bool{
+		"office":      true,
+		"server_room": true,
+		"screen_0_0":  false,
+		"officeX":     false,
+	}
+	for cameraID, want := range tests {
+		if got := principal.CanViewCamera(cameraID); got != want {
+			t.Errorf("CanViewCamera(%q) = %v, want %v", cameraID, got, want)
+		}
+	}
+
+	if !Anonymous.CanViewCamera("screen_0_0") {
+		t.Error("Expected anonymous principal to view all cameras")
+	}
+}
+
+func TestDefaultManager_CameraRestriction(t *testing.T) {
+	passwordHash, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Users = []User{{Username: "ops", PasswordHash: passwordHash, Role: RoleViewer, Cameras: []string{"server_room"}}}
+	manager := NewDefaultManager(config)
+
+	_, principal, err := manager.Login("ops", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.ViewsAllCameras() || !principal.CanViewCamera("server_room") || principal.CanViewCamera("office") {
+		t.Errorf("Expected session to be restricted to server_room, got %+v", principal.Cameras)
+	}
+
+	config.Users[0].Cameras = []string{"[invalid"}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected invalid camera pattern to be rejected")
+	}
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// session はログインセッション
+type session struct {
+	principal Principal
+	expiresAt time.Time
+}
+
+// sessionStore はメモリ上でログインセッションを管理する
+// サーバーを再起動するとセッションは失われ、再ログインが必要になる
+type sessionStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]session
+}
+
+// newSessionStore は新しい sessionStore を作成する
+func newSessionStore(ttl time.Duration) *sessionStore {
+	return &sessionStore{
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: make(map[string]session),
+	}
+}
+
+// create は新しいセッションを作成し、セッションIDと有効期限を設定した利用者を返す
+func (s *sessionStore) create(principal Principal) (string, Principal, error) {
+	id, err := randomString()
+	if err != nil {
+		return "", Principal{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.removeExpired(now)
+
+	principal.Method = MethodSession
+	principal.ExpiresAt = now.Add(s.ttl)
+	s.sessions[id] = session{principal: principal, expiresAt: principal.ExpiresAt}
+	return id, principal, nil
+}
+
+// get は有効なセッションの利用者を返す
+func (s *sessionStore) get(id string) (Principal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[id]
+	if !exists {
+		return Principal{}, ErrSessionExpired
+	}
+	if !s.now().Before(session.expiresAt) {
+		delete(s.sessions, id)
+		return Principal{}, ErrSessionExpired
+	}
+	return session.principal, nil
+}
+
+// delete はセッションを破棄する
+func (s *sessionStore) delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
+
+// removeExpired は有効期限切れのセッションを破棄する（ロック済み前提）
+func (s *sessionStore) removeExpired(now time.Time) {
+	for id, session := range s.sessions {
+		if !now.Before(session.expiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+)
+
+// Role は利用者の権限
+// 上位の権限は下位の権限の操作を全て行える（viewer < operator < admin）
+type Role string
+
+const (
+	// RoleViewer は映像・タイムラプス・イベントの閲覧のみ行える
+	RoleViewer Role = "viewer"
+	// RoleOperator は閲覧に加えてカメラの開始・停止・設定変更・録画トリガーを行える
+	RoleOperator Role = "operator"
+	// RoleAdmin は全ての操作（カメラの追加・削除を含む）を行える
+	RoleAdmin Role = "admin"
+)
+
+// roleLevels は権限の序列
+var roleLevels = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// ParseRole は権限の名前を解析する
+func ParseRole(name string) (Role, error) {
+	role := Role(name)
+	if _, ok := roleLevels[role]; !ok {
+		return "", fmt.Errorf("不明な権限です: %q（viewer / operator / admin）", name)
+	}
+	return role, nil
+}
+
+// Allows は required の権限が必要な操作を行えるか判定する
+func (r Role) Allows(required Role) bool {
+	level, ok := roleLevels[r]
+	return ok && level >= roleLevels[required]
+}
+
+// Method は認証方式
+type Method string
+
+const (
+	MethodSession Method = "session" // ログインによるセッションCookie
+	MethodToken   Method = "token"   // APIトークン
+	MethodNone    Method = "none"    // 認証が無効（全ての操作を許可）
+)
+
+// Principal は認証された利用者を表す
+type Principal struct {
+	Name      string    // ユーザー名またはトークン名
+	Role      Role      // 権限
+	Method    Method    // 認証方式
+	ExpiresAt time.Time // セッションの有効期限（セッション以外はゼロ値）
+	Cameras   []string  // 閲覧できるカメラのIDまたはパターン（空の場合は全てのカメラ）
+}
+
+// CanViewCamera は指定したカメラを閲覧できるか判定する
+// 権限に関わらず、閲覧できないカメラは操作もできない
+func (p Principal) CanViewCamera(cameraID string) bool {
+	if p.ViewsAllCameras() {
+		return true
+	}
+	for _, pattern := range p.Cameras {
+		if matched, _ := path.Match(pattern, cameraID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ViewsAllCameras はカメラの制限がないか返す
+func (p Principal) ViewsAllCameras() bool {
+	return len(p.Cameras) == 0
+}
+
+// Anonymous は認証が無効な場合の利用者
+var Anonymous = Principal{Name: "anonymous", Role: RoleAdmin, Method: MethodNone}
+
+var (
+	// ErrNoCredentials はリクエストに認証情報がないことを表す
+	ErrNoCredentials = errors.New("認証情報がありません")
+	// ErrInvalidCredentials はユーザー名・パスワード・トークンが正しくないことを表す
+	ErrInvalidCredentials = errors.New("認証情報が正しくありません")
+	// ErrSessionExpired はセッションが存在しないか有効期限切れであることを表す
+	ErrSessionExpired = errors.New("セッションが無効か有効期限切れです")
+)
+
+// Config は認証の設定
+type Config struct {
+	Enabled      bool          `yaml:"enabled"`       // 認証の有効/無効（無効の場合は全ての操作を許可する）
+	SessionTTL   time.Duration `yaml:"session_ttl"`   // ログインセッションの有効期間（デフォルト: 24時間）
+	CookieSecure bool          `yaml:"cookie_secure"` // セッションCookieをHTTPSのみで送信する（server.tls でHTTPS配信する場合は常に有効）
+	Users        []User        `yaml:"users"`         // ログインできるユーザー
+	Tokens       []Token       `yaml:"tokens"`        // スクリプト用のAPIトークン
+}
+
+// User はパスワードでログインするユーザー
+type User struct {
+	Username     string   `yaml:"username"`
+	PasswordHash string   `yaml:"password_hash"` // bcryptでハッシュ化したパスワード
+	Role         Role     `yaml:"role"`
+	Cameras      []string `yaml:"cameras"` // 閲覧できるカメラのID（* などのワイルドカード可、未指定の場合は全て）
+}
+
+// Token はAuthorization: Bearer ヘッダーで送るAPIトークン
+type Token struct {
+	Name      string   `yaml:"name"`       // トークンの名前（ログ・/api/auth/me に表示）
+	TokenHash string   `yaml:"token_hash"` // トークンのSHA-256（16進数）
+	Role      Role     `yaml:"role"`
+	Cameras   []string `yaml:"cameras"` // 閲覧できるカメラのID（* などのワイルドカード可、未指定の場合は全て）
+}
+
+// DefaultConfig はデフォルト設定を返す
+func DefaultConfig() Config {
+	return Config{
+		Enabled:    false,
+		SessionTTL: 24 * time.Hour,
+	}
+}
+
+// Validate は設定の妥当性を検証する
+func (c Config) Validate() error {
+	if c.SessionTTL < 0 {
+		return fmt.Errorf("無効なセッションの有効期間: %s", c.SessionTTL)
+	}
+	if c.Enabled && len(c.Users) == 0 && len(c.Tokens) == 0 {
+		return fmt.Errorf("認証を有効にする場合はユーザーかトークンを1つ以上指定してください")
+	}
+
+	usernames := make(map[string]bool)
+	for i, user := range c.Users {
+		if user.Username == "" {
+			return fmt.Errorf("ユーザー %d: ユーザー名を指定してください", i)
+		}
+		if usernames[user.Username] {
+			return fmt.Errorf("ユーザー名が重複しています: %s", user.Username)
+		}
+		usernames[user.Username] = true
+		if err := validatePasswordHash(user.PasswordHash); err != nil {
+			return fmt.Errorf("ユーザー %s: %w", user.Username, err)
+		}
+		if _, err := ParseRole(string(user.Role)); err != nil {
+			return fmt.Errorf("ユーザー %s: %w", user.Username, err)
+		}
+		if err := validateCameraPatterns(user.Cameras); err != nil {
+			return fmt.Errorf("ユーザー %s: %w", user.Username, err)
+		}
+	}
+
+	names := make(map[string]bool)
+	for i, token := range c.Tokens {
+		if token.Name == "" {
+			return fmt.Errorf("トークン %d: 名前を指定してください", i)
+		}
+		if names[token.Name] {
+			return fmt.Errorf("トークン名が重複しています: %s", token.Name)
+		}
+		names[token.Name] = true
+		if _, err := decodeTokenHash(token.TokenHash); err != nil {
+			return fmt.Errorf("トークン %s: %w", token.Name, err)
+		}
+		if _, err := ParseRole(string(token.Role)); err != nil {
+			return fmt.Errorf("トークン %s: %w", token.Name, err)
+		}
+		if err := validateCameraPatterns(token.Cameras); err != nil {
+			return fmt.Errorf("トークン %s: %w", token.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateCameraPatterns は閲覧できるカメラのパターンを検証する
+func validateCameraPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return fmt.Errorf("カメラIDが空です")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("不正なカメラIDのパターンです: %q", pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package camera
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Frame は配信される1フレームを表す
+type Frame struct {
+	Data      []byte    // JPEG画像データ
+	Timestamp time.Time // キャプチャ時刻
+	Sequence  uint64    // ソース内での連番
+}
+
+// DropPolicy は購読者のバッファが満杯の場合の破棄方針
+type DropPolicy int
+
+const (
+	// DropOldest はバッファ内の最も古いフレームを破棄して新しいフレームを入れる
+	DropOldest DropPolicy = iota
+	// DropNewest は新しいフレームを破棄してバッファ内のフレームを維持する
+	DropNewest
+)
+
+// デフォルトの購読者バッファサイズ
+const defaultSubscriberBufferSize = 5
+
+// FrameBroadcaster は1つのVideoSourceのフレームを複数の購読者に配信する
+//
+// Publish は購読者の受信を待たない。遅い購読者は DropPolicy に従って
+// フレームを落とすため、他の購読者やキャプチャ処理は影響を受けない。
+type FrameBroadcaster struct {
+	mu          sync.RWMutex
+	subscribers map[uint64]*FrameSubscription
+	nextID      uint64
+
+	// 最新フレーム（スナップショット・タイムラプス用）
+	latest   Frame
+	sequence uint64
+
+	published atomic.Uint64
+	dropped   atomic.Uint64
+
+	// 直近のフレーム（フレームレート・ビットレートの計測用）
+	window frameWindow
+}
+
+// FrameSubscription はFrameBroadcasterの購読を表す
+type FrameSubscription struct {
+	id          uint64
+	ch          chan Frame
+	policy      DropPolicy
+	broadcaster *FrameBroadcaster
+	dropped     atomic.Uint64
+	once        sync.Once
+}
+
+// NewFrameBroadcaster は新しいFrameBroadcasterを作成する
+func NewFrameBroadcaster() *FrameBroadcaster {
+	return &FrameBroadcaster{
+		subscribers: make(map[uint64]*FrameSubscription),
+	}
+}
+
+// Subscribe は新しい購読を開始する
+// bufferSize が0以下の場合はデフォルト値を使用する
+func (b *FrameBroadcaster) Subscribe(bufferSize int, policy DropPolicy) *FrameSubscription {
+	if bufferSize <= 0 {
+		bufferSize = defaultSubscriberBufferSize
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	sub := &FrameSubscription{
+		id:          b.nextID,
+		ch:          make(chan Frame, bufferSize),
+		policy:      policy,
+		broadcaster: b,
+	}
+
+	b.subscribers[sub.id] = sub
+	return sub
+}
+
+// Publish はフレームを全購読者に配信する
+// 購読者への送信はブロックしないため、ロックを保持したまま配信する
+func (b *FrameBroadcaster) Publish(data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	frame := Frame{
+		Data:      data,
+		Timestamp: time.Now(),
+		Sequence:  b.sequence,
+	}
+	b.latest = frame
+	b.published.Add(1)
+	b.window.add(frame.Timestamp, len(data))
+
+	for _, sub := range b.subscribers {
+		sub.deliver(frame)
+	}
+}
+
+// deliver は購読者のバッファにフレームを入れる（ロック済み前提）
+func (s *FrameSubscription) deliver(frame Frame) {
+	select {
+	case s.ch <- frame:
+		return
+	default:
+	}
+
+	// バッファが満杯
+	s.dropped.Add(1)
+	s.broadcaster.dropped.Add(1)
+
+	if s.policy == DropNewest {
+		return
+	}
+
+	// 最も古いフレームを破棄して入れ直す
+	select {
+	case <-s.ch:
+	default:
+	}
+	select {
+	case s.ch <- frame:
+	default:
+	}
+}
+
+// Latest は最後に配信されたフレームを返す
+func (b *FrameBroadcaster) Latest() (Frame, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.latest.Data == nil {
+		return Frame{}, false
+	}
+	return b.latest, true
+}
+
+// SubscriberCount は現在の購読者数を返す
+func (b *FrameBroadcaster) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// PublishedCount は配信したフレーム総数を返す
+func (b *FrameBroadcaster) PublishedCount() uint64 {
+	return b.published.Load()
+}
+
+// DroppedCount は全購読者で破棄されたフレーム総数を返す
+func (b *FrameBroadcaster) DroppedCount() uint64 {
+	return b.dropped.Load()
+}
+
+// Reset は全購読者を切断し、保持している最新フレームを破棄する
+// ソースの停止時に呼び出す。Reset後も新たな購読・配信は可能
+func (b *FrameBroadcaster) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, sub := range b.subscribers {
+		delete(b.subscribers, id)
+		sub.once.Do(func() { close(sub.ch) })
+	}
+	b.latest = Frame{}
+	b.window = frameWindow{}
+}
+
+// Stats は now 時点の配信統計を返す（StartedAt・CaptureCommand はソースが設定する）
+func (b *FrameBroadcaster) Stats(now time.Time) SourceStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	frameRate, averageSize, bitrate := b.window.measure(now)
+	return SourceStats{
+		FrameRate:        frameRate,
+		AverageFrameSize: averageSize,
+		Bitrate:          bitrate,
+		FramesPublished:  b.published.Load(),
+		FramesDropped:    b.dropped.Load(),
+		LastFrameAt:      b.latest.Timestamp,
+		Subscribers:      len(b.subscribers),
+	}
+}
+
+// Frames はフレームを受信するチャンネルを返す
+// ソースが停止するか購読解除されるとチャンネルは閉じられる
+func (s *FrameSubscription) Frames() <-chan Frame {
+	return s.ch
+}
+
+// Dropped はこの購読者で破棄されたフレーム数を返す
+func (s *FrameSubscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe は購読を終了する。複数回呼び出しても安全
+func (s *FrameSubscription) Unsubscribe() {
+	b := s.broadcaster
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers, s.id)
+	s.once.Do(func() { close(s.ch) })
+}
@@ -0,0 +1,126 @@
+package camera
+
+import (
+	"testing"
+)
+
+func TestFrameBroadcaster_FanOut(t *testing.T) {
+	broadcaster := NewFrameBroadcaster()
+
+	sub1 := broadcaster.Subscribe(10, DropOldest)
+	defer sub1.Unsubscribe()
+	sub2 := broadcaster.Subscribe(10, DropOldest)
+	defer sub2.Unsubscribe()
+
+	if broadcaster.SubscriberCount() != 2 {
+		t.Fatalf("Expected 2 subscribers, got %d", broadcaster.SubscriberCount())
+	}
+
+	for i := 0; i < 3; i++ {
+		broadcaster.Publish([]byte{byte(i)})
+	}
+
+	// 両方の購読者が全フレームを受信する
+	for _, sub := range []*FrameSubscription{sub1, sub2} {
+		for i := 0; i < 3; i++ {
+			frame := <-sub.Frames()
+			if frame.Data[0] != byte(i) {
+				t.Errorf("Expected frame %d, got %d", i, frame.Data[0])
+			}
+			if frame.Sequence != uint64(i+1) {
+				t.Errorf("Expected sequence %d, got %d", i+1, frame.Sequence)
+			}
+		}
+	}
+
+	latest, ok := broadcaster.Latest()
+	if !ok {
+		t.Fatal("Expected latest frame to be available")
+	}
+	if latest.Data[0] != 2 {
+		t.Errorf("Expected latest frame 2, got %d", latest.Data[0])
+	}
+}
+
+func TestFrameBroadcaster_SlowConsumer(t *testing.T) {
+	broadcaster := NewFrameBroadcaster()
+
+	oldest := broadcaster.Subscribe(2, DropOldest)
+	defer oldest.Unsubscribe()
+	newest := broadcaster.Subscribe(2, DropNewest)
+	defer newest.Unsubscribe()
+
+	// 購読者が読まない状態で5フレーム配信してもブロックしない
+	for i := 0; i < 5; i++ {
+		broadcaster.Publish([]byte{byte(i)})
+	}
+
+	if oldest.Dropped() != 3 {
+		t.Errorf("Expected 3 dropped frames, got %d", oldest.Dropped())
+	}
+	if newest.Dropped() != 3 {
+		t.Errorf("Expected 3 dropped frames, got %d", newest.Dropped())
+	}
+	if broadcaster.DroppedCount() != 6 {
+		t.Errorf("Expected 6 dropped frames in total, got %d", broadcaster.DroppedCount())
+	}
+
+	// DropOldest は最新の2フレームを保持する
+	if frame := <-oldest.Frames(); frame.Data[0] != 3 {
+		t.Errorf("Expected frame 3, got %d", frame.Data[0])
+	}
+	if frame := <-oldest.Frames(); frame.Data[0] != 4 {
+		t.Errorf("Expected frame 4, got %d", frame.Data[0])
+	}
+
+	// DropNewest は最初の2フレームを保持する
+	if frame := <-newest.Frames(); frame.Data[0] != 0 {
+		t.Errorf("Expected frame 0, got %d", frame.Data[0])
+	}
+	if frame := <-newest.Frames(); frame.Data[0] != 1 {
+		t.Errorf("Expected frame 1, got %d", frame.Data[0])
+	}
+}
+
+func TestFrameBroadcaster_UnsubscribeAndReset(t *testing.T) {
+	broadcaster := NewFrameBroadcaster()
+
+	sub1 := broadcaster.Subscribe(0, DropOldest)
+	sub2 := broadcaster.Subscribe(0, DropOldest)
+
+	// 購読解除は複数回呼び出しても安全
+	sub1.Unsubscribe()
+	sub1.Unsubscribe()
+
+	if broadcaster.SubscriberCount() != 1 {
+		t.Fatalf("Expected 1 subscriber after unsubscribe, got %d", broadcaster.SubscriberCount())
+	}
+	if _, ok := <-sub1.Frames(); ok {
+		t.Error("Expected channel to be closed after unsubscribe")
+	}
+
+	broadcaster.Publish([]byte{1})
+	broadcaster.Reset()
+
+	if broadcaster.SubscriberCount() != 0 {
+		t.Errorf("Expected 0 subscribers after reset, got %d", broadcaster.SubscriberCount())
+	}
+	if _, ok := broadcaster.Latest(); ok {
+		t.Error("Expected latest frame to be cleared after reset")
+	}
+
+	// バッファ済みのフレームを読み切った後にチャンネルが閉じられる
+	<-sub2.Frames()
+	if _, ok := <-sub2.Frames(); ok {
+		t.Error("Expected channel to be closed after reset")
+	}
+	sub2.Unsubscribe()
+
+	// Reset後も購読できる
+	sub3 := broadcaster.Subscribe(0, DropOldest)
+	defer sub3.Unsubscribe()
+	broadcaster.Publish([]byte{2})
+	if frame := <-sub3.Frames(); frame.Data[0] != 2 {
+		t.Errorf("Expected frame 2, got %d", frame.Data[0])
+	}
+}
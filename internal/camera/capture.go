@@ -3,33 +3,91 @@ package camera
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"image"
 	"image/jpeg"
+	"io"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// ffmpegの入力フォーマット名
+const (
+	ffmpegInputMJPEG = "mjpeg"
+	ffmpegInputYUYV  = "yuyv422"
+)
+
+// 設定のフォーマット名（FourCC）とffmpegの -input_format の対応
+var ffmpegInputFormats = map[string]string{
+	"MJPEG": ffmpegInputMJPEG,
+	"YUYV":  ffmpegInputYUYV,
+	"UYVY":  "uyvy422",
+	"NV12":  "nv12",
+	"YU12":  "yuv420p",
+	"RGB3":  "rgb24",
+	"BGR3":  "bgr24",
+	"GREY":  "gray",
+}
+
+// defaultCaptureQuality は再エンコード時の既定の品質（ffmpegの -q:v）
+const defaultCaptureQuality = 3
+
 // V4L2Capturer はシェルコマンドを使ってV4L2デバイスから画像を取得する
 type V4L2Capturer struct {
-	devicePath string
-	width      int
-	height     int
-	fps        int
+	devicePath  string
+	width       int
+	height      int
+	fps         int
+	inputFormat string // ffmpegの -input_format
+	quality     int    // 再エンコード時の品質（ffmpegの -q:v）
 }
 
 // NewV4L2Capturer は新しいV4L2Capturerを作成する
+// カメラのYUYV出力をMJPEGに再エンコードする
 func NewV4L2Capturer(devicePath string, width, height, fps int) *V4L2Capturer {
 	return &V4L2Capturer{
-		devicePath: devicePath,
-		width:      width,
-		height:     height,
-		fps:        fps,
+		devicePath:  devicePath,
+		width:       width,
+		height:      height,
+		fps:         fps,
+		inputFormat: ffmpegInputYUYV,
+		quality:     defaultCaptureQuality,
+	}
+}
+
+// NewV4L2CapturerForSettings は映像設定とデバイスの能力からV4L2Capturerを作成する
+// カメラがMJPEGを出力できる場合は再エンコードせずにそのまま使用する
+func NewV4L2CapturerForSettings(devicePath string, capabilities VideoCapabilities, settings VideoSettings) *V4L2Capturer {
+	quality := settings.Quality
+	if quality < MinQuality || quality > MaxQuality {
+		quality = defaultCaptureQuality
+	}
+
+	return &V4L2Capturer{
+		devicePath:  devicePath,
+		width:       settings.Width,
+		height:      settings.Height,
+		fps:         settings.FrameRate,
+		inputFormat: selectInputFormat(capabilities, settings),
+		quality:     quality,
 	}
 }
 
+// InputFormat はffmpegに指定する入力フォーマットを返す
+func (c *V4L2Capturer) InputFormat() string {
+	return c.inputFormat
+}
+
+// IsPassthrough はカメラのMJPEGを再エンコードせずに使用するかを返す
+func (c *V4L2Capturer) IsPassthrough() bool {
+	return c.inputFormat == ffmpegInputMJPEG
+}
+
 // IsDeviceAvailable はV4L2デバイスが利用可能かチェックする
 func (c *V4L2Capturer) IsDeviceAvailable(ctx context.Context) bool {
 	// v4l2-ctlコマンドでデバイス情報を取得して確認
@@ -65,16 +123,7 @@ func (c *V4L2Capturer) GetDeviceInfo(ctx context.Context) (map[string]string, er
 // CaptureFrame は1フレームをキャプチャしてJPEG画像として返す
 func (c *V4L2Capturer) CaptureFrame(ctx context.Context) (image.Image, error) {
 	// ffmpegを使って1フレームをキャプチャ
-	cmd := exec.CommandContext(ctx,
-		"ffmpeg",
-		"-f", "v4l2",
-		"-video_size", fmt.Sprintf("%dx%d", c.width, c.height),
-		"-i", c.devicePath,
-		"-vframes", "1",
-		"-f", "image2",
-		"-c:v", "mjpeg",
-		"-",
-	)
+	cmd := exec.CommandContext(ctx, "ffmpeg", c.singleFrameArgs(defaultCaptureQuality)...)
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -86,7 +135,7 @@ func (c *V4L2Capturer) CaptureFrame(ctx context.Context) (image.Image, error) {
 	}
 
 	// JPEGデータを画像にデコード
-	img, err := jpeg.Decode(&stdout)
+	img, err := jpeg.Decode(bytes.NewReader(completeMJPEGFrame(stdout.Bytes())))
 	if err != nil {
 		return nil, fmt.Errorf("JPEG画像のデコードに失敗: %w", err)
 	}
@@ -96,18 +145,8 @@ func (c *V4L2Capturer) CaptureFrame(ctx context.Context) (image.Image, error) {
 
 // CaptureFrameAsJPEG は1フレームをキャプチャしてJPEGバイト配列として返す
 func (c *V4L2Capturer) CaptureFrameAsJPEG(ctx context.Context) ([]byte, error) {
-	// ffmpegを使って1フレームをJPEGとしてキャプチャ
-	cmd := exec.CommandContext(ctx,
-		"ffmpeg",
-		"-f", "v4l2",
-		"-video_size", fmt.Sprintf("%dx%d", c.width, c.height),
-		"-i", c.devicePath,
-		"-vframes", "1",
-		"-f", "image2",
-		"-c:v", "mjpeg",
-		"-q:v", "2", // 高品質JPEG
-		"-",
-	)
+	// ffmpegを使って1フレームをJPEGとしてキャプチャ（再エンコードする場合は高品質）
+	cmd := exec.CommandContext(ctx, "ffmpeg", c.singleFrameArgs(2)...)
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -118,25 +157,14 @@ func (c *V4L2Capturer) CaptureFrameAsJPEG(ctx context.Context) ([]byte, error) {
 		return nil, fmt.Errorf("JPEGフレームキャプチャに失敗: %w (stderr: %s)", err, stderr.String())
 	}
 
-	return stdout.Bytes(), nil
+	return completeMJPEGFrame(stdout.Bytes()), nil
 }
 
 // StartStream は連続キャプチャ用のストリームを開始する
 func (c *V4L2Capturer) StartStream(ctx context.Context, frameChan chan<- []byte, errorChan chan<- error) {
-	// メインカメラはカラーフォーマット対応なので、最適化された設定を使用
-	cmd := exec.CommandContext(ctx,
-		"ffmpeg",
-		"-f", "v4l2",
-		"-input_format", "yuyv422",
-		"-video_size", fmt.Sprintf("%dx%d", c.width, c.height),
-		"-r", strconv.Itoa(c.fps),
-		"-i", c.devicePath,
-		"-vf", "format=yuv420p",
-		"-f", "image2pipe",
-		"-c:v", "mjpeg",
-		"-q:v", "3",
-		"-",
-	)
+	// MJPEG対応カメラは再エンコードせず、それ以外はMJPEGに変換する
+	cmd := exec.CommandContext(ctx, "ffmpeg", c.streamArgs()...)
+	passthrough := c.IsPassthrough()
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -144,29 +172,15 @@ func (c *V4L2Capturer) StartStream(ctx context.Context, frameChan chan<- []byte,
 		return
 	}
 
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		errorChan <- fmt.Errorf("stderrパイプの作成に失敗: %w", err)
-		return
-	}
+	// 終了時のエラーメッセージに使うため、標準エラー出力の末尾を保持する
+	stderr := &stderrTail{}
+	cmd.Stderr = stderr
 
 	if err := cmd.Start(); err != nil {
 		errorChan <- fmt.Errorf("ffmpegの起動に失敗: %w", err)
 		return
 	}
 
-	// stderrを別goroutineで読み取り
-	go func() {
-		buf := make([]byte, 1024)
-		for {
-			_, err := stderr.Read(buf)
-			if err != nil {
-				break
-			}
-			// エラー情報をログに出力（実装は後で追加）
-		}
-	}()
-
 	// JPEGフレームを読み取り
 	go func() {
 		defer func() {
@@ -187,57 +201,41 @@ func (c *V4L2Capturer) StartStream(ctx context.Context, frameChan chan<- []byte,
 			default:
 				n, err := stdout.Read(buffer)
 				if err != nil {
-					if err.Error() != "EOF" {
-						errorChan <- fmt.Errorf("フレーム読み取りエラー: %w", err)
+					// 停止以外でffmpegが終了した場合は監視で再起動できるようエラーを通知する
+					if ctx.Err() == nil {
+						sendStreamError(ctx, errorChan, ffmpegExitError(err, stderr))
 					}
 					return
 				}
 
 				frameBuffer.Write(buffer[:n])
 
-				// JPEGマーカーを探してフレームを分割
+				// JPEGのマーカーを辿ってフレームを分割
 				data := frameBuffer.Bytes()
 				for {
-					// JPEGの開始マーカー（FF D8）を探す
-					startIdx := bytes.Index(data, []byte{0xFF, 0xD8})
-					if startIdx == -1 {
-						break
+					jpegFrame, rest := nextJPEGFrame(data)
+					data = rest
+					if jpegFrame == nil {
+						break // 完全なフレームがまだない
 					}
 
-					// JPEGの終了マーカー（FF D9）を探す
-					endIdx := bytes.Index(data[startIdx+2:], []byte{0xFF, 0xD9})
-					if endIdx == -1 {
-						// 完全なフレームがまだない
-						if startIdx > 0 {
-							// 不要なデータを削除
-							frameBuffer.Reset()
-							frameBuffer.Write(data[startIdx:])
-						}
-						break
+					frame := make([]byte, len(jpegFrame))
+					copy(frame, jpegFrame)
+					if passthrough {
+						frame = completeMJPEGFrame(frame)
 					}
 
-					// 完全なJPEGフレームを抽出
-					endIdx += startIdx + 2 + 2 // マーカーのサイズを含める
-					frame := make([]byte, endIdx)
-					copy(frame, data[:endIdx])
-
 					// フレームを送信
 					select {
 					case frameChan <- frame:
 					case <-ctx.Done():
 						return
 					}
-
-					// 処理済みデータを削除
-					remaining := data[endIdx:]
-					frameBuffer.Reset()
-					if len(remaining) > 0 {
-						frameBuffer.Write(remaining)
-						data = frameBuffer.Bytes()
-					} else {
-						break
-					}
 				}
+
+				// 処理済みデータを削除
+				frameBuffer.Reset()
+				frameBuffer.Write(data)
 			}
 		}
 	}()
@@ -297,3 +295,133 @@ func (c *V4L2Capturer) SetControls(ctx context.Context, controls map[string]inte
 
 	return nil
 }
+
+// inputArgs はffmpegのV4L2入力オプションを返す
+func (c *V4L2Capturer) inputArgs() []string {
+	args := []string{"-f", "v4l2"}
+	if c.inputFormat != "" {
+		args = append(args, "-input_format", c.inputFormat)
+	}
+	args = append(args, "-video_size", fmt.Sprintf("%dx%d", c.width, c.height))
+	if c.fps > 0 {
+		args = append(args, "-framerate", strconv.Itoa(c.fps))
+	}
+	return append(args, "-i", c.devicePath)
+}
+
+// encodeArgs はJPEG出力のためのコーデックオプションを返す
+// MJPEGのパススルーではカメラのフレームをそのまま出力する
+func (c *V4L2Capturer) encodeArgs(quality int) []string {
+	if c.IsPassthrough() {
+		return []string{"-c:v", "copy"}
+	}
+	return []string{"-vf", "format=yuv420p", "-c:v", "mjpeg", "-q:v", strconv.Itoa(quality)}
+}
+
+// streamArgs は連続キャプチャ用のffmpeg引数を返す
+func (c *V4L2Capturer) streamArgs() []string {
+	args := c.inputArgs()
+	args = append(args, c.encodeArgs(c.quality)...)
+	return append(args, "-f", "image2pipe", "-")
+}
+
+// CommandLine は連続キャプチャに使うffmpegのコマンドラインを返す
+func (c *V4L2Capturer) CommandLine() string {
+	return formatCommandLine("ffmpeg", c.streamArgs())
+}
+
+// singleFrameArgs は1フレームキャプチャ用のffmpeg引数を返す
+func (c *V4L2Capturer) singleFrameArgs(quality int) []string {
+	args := c.inputArgs()
+	args = append(args, "-vframes", "1")
+	args = append(args, c.encodeArgs(quality)...)
+	return append(args, "-f", "image2", "-")
+}
+
+// selectInputFormat は設定とデバイスの能力からffmpegの入力フォーマットを選ぶ
+// フォーマット毎の対応モードが取得できていない場合や、対応するモードがない場合は、どのカメラでも使えるYUYVを使用する
+func selectInputFormat(capabilities VideoCapabilities, settings VideoSettings) string {
+	format, found := selectCaptureFormat(capabilities, settings, func(format string) bool {
+		_, ok := ffmpegInputFormats[format]
+		return ok
+	})
+	if !found {
+		return ffmpegInputYUYV
+	}
+	return ffmpegInputFormats[format]
+}
+
+// selectCaptureFormat は指定の解像度・フレームレートに対応するフォーマットを選ぶ
+// 設定のフォーマット、MJPEG、その他のフォーマットの順に、usable で扱えるものを候補とする
+func selectCaptureFormat(capabilities VideoCapabilities, settings VideoSettings, usable func(format string) bool) (string, bool) {
+	resolution := Resolution{Width: settings.Width, Height: settings.Height}
+	supports := func(format FormatInfo) bool {
+		if !usable(format.Format) {
+			return false
+		}
+		rates, found := frameRatesFor(format.Sizes, resolution)
+		return found && (len(rates) == 0 || slices.Contains(rates, settings.FrameRate))
+	}
+
+	candidates := make([]FormatInfo, 0, len(capabilities.Formats))
+	for _, format := range capabilities.Formats {
+		if strings.EqualFold(format.Format, settings.Format) {
+			candidates = append(candidates, format)
+		}
+	}
+	for _, format := range capabilities.Formats {
+		if format.Format == "MJPEG" && !strings.EqualFold(settings.Format, "MJPEG") {
+			candidates = append(candidates, format)
+		}
+	}
+	candidates = append(candidates, capabilities.Formats...)
+
+	for _, format := range candidates {
+		if supports(format) {
+			return format.Format, true
+		}
+	}
+	return "", false
+}
+
+// maxStderrTail は保持する標準エラー出力の最大バイト数
+const maxStderrTail = 2048
+
+// stderrTail はffmpegの標準エラー出力の末尾を保持する
+type stderrTail struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+// Write は出力を追記し、末尾の maxStderrTail バイトのみを保持する
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.data = append(t.data, p...)
+	if excess := len(t.data) - maxStderrTail; excess > 0 {
+		t.data = t.data[excess:]
+	}
+	return len(p), nil
+}
+
+// LastLine は最後の空でない行を返す
+func (t *stderrTail) LastLine() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lines := strings.Split(strings.TrimSpace(string(t.data)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// ffmpegExitError はストリーム中にffmpegの出力が途絶えた場合のエラーを返す
+func ffmpegExitError(err error, stderr *stderrTail) error {
+	message := stderr.LastLine()
+	if errors.Is(err, io.EOF) {
+		if message == "" {
+			return errors.New("ffmpegが終了しました")
+		}
+		return fmt.Errorf("ffmpegが終了しました: %s", message)
+	}
+	return fmt.Errorf("フレーム読み取りエラー: %w (stderr: %s)", err, message)
+}
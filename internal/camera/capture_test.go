@@ -0,0 +1,96 @@
+package camera
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSelectInputFormat(t *testing.T) {
+	capabilities := VideoCapabilities{Formats: testPixelFormats}
+
+	testCases := []struct {
+		name     string
+		settings VideoSettings
+		expected string
+	}{
+		{
+			name:     "MJPEG requested and supported",
+			settings: VideoSettings{Width: 1920, Height: 1080, FrameRate: 30, Format: "MJPEG"},
+			expected: ffmpegInputMJPEG,
+		},
+		{
+			name:     "YUYV requested and supported",
+			settings: VideoSettings{Width: 640, Height: 480, FrameRate: 30, Format: "YUYV"},
+			expected: ffmpegInputYUYV,
+		},
+		{
+			name:     "YUYV requested but only MJPEG supports the mode",
+			settings: VideoSettings{Width: 1920, Height: 1080, FrameRate: 30, Format: "YUYV"},
+			expected: ffmpegInputMJPEG,
+		},
+		{
+			name:     "unknown format falls back to MJPEG",
+			settings: VideoSettings{Width: 640, Height: 480, FrameRate: 15, Format: "H264"},
+			expected: ffmpegInputMJPEG,
+		},
+		{
+			name:     "unsupported mode falls back to YUYV",
+			settings: VideoSettings{Width: 800, Height: 600, FrameRate: 30, Format: "MJPEG"},
+			expected: ffmpegInputYUYV,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := selectInputFormat(capabilities, tc.settings); got != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+
+	// フォーマット毎の対応モードが不明な場合は従来通りYUYVを使う
+	settings := VideoSettings{Width: 1280, Height: 720, FrameRate: 15, Format: "MJPEG"}
+	if got := selectInputFormat(defaultUSBCapabilities(), settings); got != ffmpegInputYUYV {
+		t.Errorf("Expected %s without probed formats, got %s", ffmpegInputYUYV, got)
+	}
+}
+
+func TestV4L2Capturer_StreamArgs(t *testing.T) {
+	capabilities := VideoCapabilities{Formats: testPixelFormats}
+
+	passthrough := NewV4L2CapturerForSettings("/dev/video0", capabilities,
+		VideoSettings{Width: 1920, Height: 1080, FrameRate: 30, Format: "MJPEG", Quality: 5})
+	if !passthrough.IsPassthrough() {
+		t.Fatal("Expected MJPEG capture to be passed through")
+	}
+	args := passthrough.streamArgs()
+	if !containsArgs(args, "-input_format", "mjpeg") || !containsArgs(args, "-c:v", "copy") {
+		t.Errorf("Expected MJPEG input copied without re-encoding, got %v", args)
+	}
+	if slices.Contains(args, "-q:v") || slices.Contains(args, "-vf") {
+		t.Errorf("Expected no encoder options for passthrough, got %v", args)
+	}
+	if !containsArgs(args, "-video_size", "1920x1080") || !containsArgs(args, "-framerate", "30") {
+		t.Errorf("Expected requested mode in args, got %v", args)
+	}
+
+	transcode := NewV4L2CapturerForSettings("/dev/video0", capabilities,
+		VideoSettings{Width: 640, Height: 480, FrameRate: 30, Format: "YUYV", Quality: 5})
+	if transcode.IsPassthrough() {
+		t.Fatal("Expected YUYV capture to be transcoded")
+	}
+	args = transcode.streamArgs()
+	if !containsArgs(args, "-input_format", "yuyv422") || !containsArgs(args, "-c:v", "mjpeg") || !containsArgs(args, "-q:v", "5") {
+		t.Errorf("Expected YUYV input encoded to MJPEG with quality 5, got %v", args)
+	}
+}
+
+// containsArgs は引数列にオプションと値が連続して含まれるかを返す
+func containsArgs(args []string, option, value string) bool {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == option && args[i+1] == value {
+			return true
+		}
+	}
+	return false
+}
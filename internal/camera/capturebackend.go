@@ -0,0 +1,80 @@
+package camera
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// StreamCapturer はデバイスからJPEGフレームを連続取得するキャプチャバックエンド
+type StreamCapturer interface {
+	// StartStream はストリームを開始し、JPEGフレームを frameChan に送る
+	// ストリームが継続できなくなった場合はエラーを errorChan に送って終了する
+	// ctx がキャンセルされるとストリームを停止する
+	StartStream(ctx context.Context, frameChan chan<- []byte, errorChan chan<- error)
+	// TestCapture は1フレームを取得してデバイスが使えるか確認する
+	TestCapture(ctx context.Context) error
+	// IsDeviceAvailable はデバイスが利用可能かチェックする
+	IsDeviceAvailable(ctx context.Context) bool
+}
+
+// CaptureBackend はUSBカメラのキャプチャ方式
+type CaptureBackend string
+
+const (
+	// CaptureBackendFFmpeg はffmpegのサブプロセスでキャプチャする（既定）
+	CaptureBackendFFmpeg CaptureBackend = "ffmpeg"
+	// CaptureBackendNative はV4L2のmmapストリーミングで直接キャプチャする（ffmpeg不要）
+	CaptureBackendNative CaptureBackend = "native"
+)
+
+// PropertyCaptureBackend はキャプチャ方式を指定する SourceConfig.Properties のキー
+const PropertyCaptureBackend = "capture_backend"
+
+// ParseCaptureBackend はキャプチャ方式の名前を解析する（空の場合は既定のffmpeg）
+func ParseCaptureBackend(name string) (CaptureBackend, error) {
+	switch CaptureBackend(name) {
+	case "", CaptureBackendFFmpeg:
+		return CaptureBackendFFmpeg, nil
+	case CaptureBackendNative:
+		return CaptureBackendNative, nil
+	default:
+		return "", fmt.Errorf("%w: 不明なキャプチャ方式 %s", ErrInvalidSettings, name)
+	}
+}
+
+// captureBackendFromProperties はソース作成設定の追加プロパティからキャプチャ方式を取得する
+func captureBackendFromProperties(properties map[string]interface{}) (CaptureBackend, error) {
+	value, exists := properties[PropertyCaptureBackend]
+	if !exists {
+		return CaptureBackendFFmpeg, nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return ParseCaptureBackend(v)
+	case CaptureBackend:
+		return ParseCaptureBackend(string(v))
+	default:
+		return "", fmt.Errorf("%w: キャプチャ方式の型が不正です: %T", ErrInvalidSettings, value)
+	}
+}
+
+// newUSBCapturer はキャプチャ方式に応じたUSBカメラのキャプチャを作成する
+func newUSBCapturer(backend CaptureBackend, device string, capabilities VideoCapabilities, settings VideoSettings) StreamCapturer {
+	if backend == CaptureBackendNative {
+		log.Printf("%s: V4L2から直接 %dx%d@%dfps をキャプチャします",
+			device, settings.Width, settings.Height, settings.FrameRate)
+		return NewNativeV4L2Capturer(device, capabilities, settings)
+	}
+
+	// カメラがMJPEGを出力できる場合は再エンコードしない
+	capturer := NewV4L2CapturerForSettings(device, capabilities, settings)
+	mode := "MJPEGに再エンコード"
+	if capturer.IsPassthrough() {
+		mode = "パススルー"
+	}
+	log.Printf("%s: 入力フォーマット %s で %dx%d@%dfps をキャプチャします（%s）",
+		device, capturer.InputFormat(), settings.Width, settings.Height, settings.FrameRate, mode)
+	return capturer
+}
@@ -10,15 +10,33 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // LinuxDiscovery はLinux環境でのカメラデバイス検出を実装する
-type LinuxDiscovery struct{}
+type LinuxDiscovery struct {
+	// probe は対応フォーマット等を列挙する（テストで差し替えられるようフィールドにしている）
+	probe func(device string) (*DeviceInfo, error)
+
+	// 定期スキャンの度に全フォーマット・解像度・フレームレートを列挙しないよう、
+	// デバイスパス毎に列挙結果をキャッシュする（同じパスでも識別子が変われば列挙し直す）
+	mu           sync.Mutex
+	capabilities map[string]cachedCapability
+}
+
+// cachedCapability はキャッシュしたデバイスの列挙結果
+type cachedCapability struct {
+	identity string
+	info     *DeviceInfo
+}
 
 // NewLinuxDiscovery は新しいLinuxDiscoveryを作成する
 func NewLinuxDiscovery() Discovery {
-	return &LinuxDiscovery{}
+	return &LinuxDiscovery{
+		probe:        probeV4L2Device,
+		capabilities: make(map[string]cachedCapability),
+	}
 }
 
 // ScanDevices はシステム内の利用可能なカメラデバイスをスキャンする
@@ -39,6 +57,9 @@ func (d *LinuxDiscovery) ScanDevices(ctx context.Context) ([]string, error) {
 		return numI < numJ
 	})
 
+	// なくなったデバイスのキャッシュを破棄する
+	d.forgetCapabilities(matches)
+
 	for _, match := range matches {
 		// コンテキストのキャンセルをチェック
 		select {
@@ -75,8 +96,7 @@ func (d *LinuxDiscovery) IsDeviceAvailable(_ context.Context, device string) boo
 		_ = file.Close()
 	}()
 
-	// V4L2デバイスかどうかの簡易チェック
-	// 実際のプロダクション環境では、より詳細なチェックが必要
+	// 映像キャプチャに対応したV4L2デバイスかチェック
 	return d.isV4L2Device(device)
 }
 
@@ -86,32 +106,29 @@ func (d *LinuxDiscovery) GetDeviceInfo(ctx context.Context, device string) (*Dev
 		return nil, fmt.Errorf("デバイスが利用できません: %s", device)
 	}
 
-	info := &DeviceInfo{
-		Device: device,
-		Name:   d.generateDeviceName(device),
-		Driver: "uvcvideo", // 仮の値、実際にはV4L2 APIから取得
-		Resolutions: []Resolution{
-			{Width: 640, Height: 480},
-			{Width: 1280, Height: 720},
-			{Width: 1920, Height: 1080},
-		},
-		Formats: []string{"MJPEG", "YUYV"},
+	// V4L2のioctlで名前・ドライバー・フォーマット毎の解像度とフレームレートを取得
+	info, err := d.probeCapabilities(device)
+	if err != nil {
+		return nil, fmt.Errorf("デバイス情報の取得に失敗: %w", err)
+	}
+
+	if info.Name == "" {
+		info.Name = d.generateDeviceName(device)
 	}
 
 	return info, nil
 }
 
-// isV4L2Device はデバイスがV4L2デバイスかチェックする
-// 簡易実装：実際にはV4L2のioctl呼び出しで確認する
+// isV4L2Device はデバイスが映像キャプチャに対応したV4L2デバイスかチェックする
+// VIDIOC_QUERYCAP で確認するため、メタデータ用ノード等は除外される
 func (d *LinuxDiscovery) isV4L2Device(device string) bool {
-	// /dev/videoXX パターンかチェック
-	matched, _ := regexp.MatchString(`^/dev/video\d+$`, device)
-	return matched
+	_, err := queryV4L2Device(device)
+	return err == nil
 }
 
 // generateDeviceName はデバイスパスから表示名を生成する
 func (d *LinuxDiscovery) generateDeviceName(device string) string {
-	// v4l2-ctlを使って実際のカメラ名を取得
+	// V4L2から実際のカメラ名を取得
 	if realName := d.getV4L2DeviceName(device); realName != "" {
 		return realName
 	}
@@ -121,8 +138,13 @@ func (d *LinuxDiscovery) generateDeviceName(device string) string {
 	return fmt.Sprintf("カメラ %d", num)
 }
 
-// getV4L2DeviceName はv4l2-ctlを使って実際のデバイス名を取得する
+// getV4L2DeviceName は実際のデバイス名を取得する
+// ioctlで取得できない場合はv4l2-ctlの出力から取得する
 func (d *LinuxDiscovery) getV4L2DeviceName(device string) string {
+	if info, err := queryV4L2Device(device); err == nil && info.Name != "" {
+		return info.Name
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -169,63 +191,105 @@ func extractDeviceNumber(device string) int {
 
 // IsMainCamera はデバイスがメインカメラ（カラー）かどうかを判定する
 func (d *LinuxDiscovery) IsMainCamera(ctx context.Context, device string) bool {
-	// v4l2-ctlでサポートフォーマットを取得
-	cmd := exec.CommandContext(ctx, "v4l2-ctl", "--device", device, "--list-formats-ext")
-	output, err := cmd.Output()
+	// V4L2から対応フォーマットを取得
+	info, err := d.probeCapabilities(device)
 	if err != nil {
-		// コマンドが失敗した場合は除外
+		// 取得できない場合は除外
 		return false
 	}
 
-	outputStr := string(output)
-
-	// グレースケールのみのデバイスは除外
-	if strings.Contains(outputStr, "GREY") && !strings.Contains(outputStr, "YUYV") && !strings.Contains(outputStr, "MJPG") {
+	// カラーフォーマットをサポートしているかチェック（グレースケールのみのデバイスは除外）
+	if !hasColorFormat(info) {
 		return false
 	}
 
-	// カラーフォーマットをサポートしているかチェック
-	hasColor := strings.Contains(outputStr, "YUYV") || strings.Contains(outputStr, "MJPG")
-
 	// 同じ物理デバイスの複数チャンネルの場合、最も小さい番号を選択
-	if hasColor {
-		deviceNum := extractDeviceNumber(device)
-
-		// 同じカメラの他のチャンネルをチェック
-		// 例: video0, video1が同じカメラの場合、video0を選択
-		for i := 0; i < deviceNum; i++ {
-			siblingDevice := fmt.Sprintf("/dev/video%d", i)
-			if d.IsDeviceAvailable(ctx, siblingDevice) {
-				// より小さい番号のデバイスがカラーをサポートしている場合は現在のデバイスをスキップ
-				siblingCmd := exec.CommandContext(ctx, "v4l2-ctl", "--device", siblingDevice, "--list-formats-ext")
-				if siblingOutput, err := siblingCmd.Output(); err == nil {
-					siblingStr := string(siblingOutput)
-					if strings.Contains(siblingStr, "YUYV") || strings.Contains(siblingStr, "MJPG") {
-						// 同じカメラ名かチェック
-						if d.haveSameCameraName(ctx, device, siblingDevice) {
-							return false // より小さい番号のデバイスを優先
-						}
-					}
-				}
-			}
+	// 例: video0, video2が同じカメラの場合、video0を選択
+	deviceNum := extractDeviceNumber(device)
+	for i := 0; i < deviceNum; i++ {
+		siblingDevice := fmt.Sprintf("/dev/video%d", i)
+		if !d.IsDeviceAvailable(ctx, siblingDevice) {
+			continue
 		}
 
-		return true
+		sibling, err := d.probeCapabilities(siblingDevice)
+		if err != nil || !hasColorFormat(sibling) {
+			continue
+		}
+
+		// より小さい番号の同じカメラがカラーをサポートしている場合は現在のデバイスをスキップ
+		if isSamePhysicalDevice(info, sibling) {
+			return false
+		}
 	}
 
-	return false
+	return true
 }
 
-// haveSameCameraName は2つのデバイスが同じカメラかチェック
-func (d *LinuxDiscovery) haveSameCameraName(_ context.Context, device1, device2 string) bool {
-	name1 := d.getV4L2DeviceName(device1)
-	name2 := d.getV4L2DeviceName(device2)
+// probeCapabilities はデバイスの名前・対応フォーマット等を返す
+// 同じデバイスパス・識別子で列挙済みの場合はキャッシュを返し、新しいノードのみ列挙する
+// 呼び出し元が変更できるよう、キャッシュのコピーを返す
+func (d *LinuxDiscovery) probeCapabilities(device string) (*DeviceInfo, error) {
+	identity := deviceIdentity(device)
+
+	d.mu.Lock()
+	cached, ok := d.capabilities[device]
+	d.mu.Unlock()
+	if ok && cached.identity == identity {
+		info := *cached.info
+		return &info, nil
+	}
 
-	if name1 == "" || name2 == "" {
-		return false
+	// 列挙に失敗した場合は一時的なエラーの可能性があるためキャッシュしない
+	info, err := d.probe(device)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.capabilities[device] = cachedCapability{identity: identity, info: info}
+	d.mu.Unlock()
+
+	copied := *info
+	return &copied, nil
+}
+
+// forgetCapabilities はスキャンで見つからなかったデバイスのキャッシュを破棄する
+func (d *LinuxDiscovery) forgetCapabilities(devices []string) {
+	present := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		present[device] = true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for device := range d.capabilities {
+		if !present[device] {
+			delete(d.capabilities, device)
+		}
 	}
+}
 
-	return name1 == name2
+// hasColorFormat はデバイスがカラーフォーマット（YUYV・MJPEG）に対応しているか判定する
+func hasColorFormat(info *DeviceInfo) bool {
+	for _, format := range info.Formats {
+		if format == "YUYV" || format == "MJPEG" {
+			return true
+		}
+	}
+	return false
+}
+
+// isSamePhysicalDevice は2つのデバイスが同じ物理カメラか判定する
+// バス上の位置が取得できる場合はそれを、できない場合はカメラ名を比較する
+func isSamePhysicalDevice(a, b *DeviceInfo) bool {
+	if a.BusInfo != "" && b.BusInfo != "" {
+		return a.BusInfo == b.BusInfo
+	}
+	if a.Name == "" || b.Name == "" {
+		return false
+	}
+	return a.Name == b.Name
 }
 
 // MockDiscovery はテスト用のモックDiscovery実装
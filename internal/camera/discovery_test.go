@@ -2,6 +2,8 @@ package camera
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -134,3 +136,51 @@ func TestMockDiscovery_AddRemoveDevice(t *testing.T) {
 		t.Fatalf("Expected 1 device after duplicate addition, got %d", len(devices))
 	}
 }
+
+func TestLinuxDiscovery_ProbeCapabilitiesCache(t *testing.T) {
+	root := setupIdentityDirs(t)
+	video0 := createDeviceFile(t, root, "video0")
+
+	discovery := NewLinuxDiscovery().(*LinuxDiscovery)
+	probes := 0
+	discovery.probe = func(device string) (*DeviceInfo, error) {
+		probes++
+		return &DeviceInfo{Device: device, Name: "Camera", Formats: []string{"MJPEG"}}, nil
+	}
+
+	// 同じデバイスは2回目以降キャッシュを使う
+	for i := 0; i < 2; i++ {
+		info, err := discovery.probeCapabilities(video0)
+		if err != nil {
+			t.Fatalf("probeCapabilities failed: %v", err)
+		}
+		info.Name = "changed" // 返した値の変更はキャッシュに影響しない
+	}
+	if probes != 1 {
+		t.Errorf("Expected 1 probe, got %d", probes)
+	}
+	if info, _ := discovery.probeCapabilities(video0); info.Name != "Camera" {
+		t.Errorf("Expected cached name Camera, got %s", info.Name)
+	}
+
+	// 同じパスに別のカメラが挿されると識別子が変わるため列挙し直す
+	link := filepath.Join(v4lByIDDir, "usb-Other_Camera-video-index0")
+	if err := os.Symlink(video0, link); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if _, err := discovery.probeCapabilities(video0); err != nil {
+		t.Fatalf("probeCapabilities failed: %v", err)
+	}
+	if probes != 2 {
+		t.Errorf("Expected a new probe after the identity changed, got %d probes", probes)
+	}
+
+	// スキャンで見つからなかったデバイスのキャッシュは破棄する
+	discovery.forgetCapabilities(nil)
+	if _, err := discovery.probeCapabilities(video0); err != nil {
+		t.Fatalf("probeCapabilities failed: %v", err)
+	}
+	if probes != 3 {
+		t.Errorf("Expected a new probe after the device vanished, got %d probes", probes)
+	}
+}
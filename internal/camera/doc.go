@@ -16,9 +16,19 @@
 //
 // # 仕様
 // - Camera Manager: 複数カメラの統合管理
-// - Camera Discovery: V4L2デバイスの自動検出・実名取得
+// - Camera Discovery: V4L2デバイスの自動検出・実名取得（対応フォーマットの列挙結果はデバイスパス・識別子毎にキャッシュし、新しいノードのみ列挙）
+// - Device Probe: V4L2のioctl（QUERYCAP / ENUM_FMT / ENUM_FRAMESIZES / ENUM_FRAMEINTERVALS）でフォーマット毎の解像度・フレームレートを取得
+// - 対応していない設定は作成時に最も近い対応設定へ補正し、API経由の変更は検証して拒否
 // - Camera Service: 個別カメラの制御・状態管理・ストリーミング
 // - V4L2 Capturer: ffmpeg経由での画像キャプチャ
+// - 入力フォーマットは設定と取得した能力から選択し、MJPEG対応カメラは再エンコードせずにそのまま配信（ハフマンテーブルは補完）
+// - Native Capturer: ffmpegを使わずV4L2のmmapストリーミングで直接取得（capture_backend: native でソース毎に選択、YUYVはGoでJPEGに変換）
+// - Source ID: /dev/v4l/by-id やUSBシリアル番号から決定的に生成（再起動・挿し直しで不変）
+// - Supervisor: ffmpegの終了・デバイスの切断・フレームの途絶を検出してエラー状態にし、指数バックオフで自動再起動（停止中のソースは対象外）
+// - Frame Broadcaster: 1つの映像ソースのフレームを複数の購読者へ配信
+// - Stats: 直近5秒間の実測フレームレート・ビットレート、破棄フレーム数、稼働時間、ffmpegのコマンドラインを提供
+// - Settings: 映像設定はソースの能力（対応解像度・フレームレート・フォーマット）に対して検証
+// - 削除したUSBカメラは明示的に再追加するまで自動検出で追加しない
 // - Thread-safe な操作をサポート
 // - エラーハンドリングとログ出力を統合
 //
@@ -26,7 +36,7 @@
 //   - v4l-utils: カメラ名の取得とデバイス制御に使用
 //     Ubuntu/Debian: sudo apt install v4l-utils
 //     Red Hat/Fedora: sudo dnf install v4l-utils
-//   - ffmpeg: 画像キャプチャとストリーミングに使用
+//   - ffmpeg: 画像キャプチャとストリーミングに使用（ネイティブキャプチャのUSBカメラでは不要）
 //     Ubuntu/Debian: sudo apt install ffmpeg
 //     Red Hat/Fedora: sudo dnf install ffmpeg
 //   - videoグループへの参加: デバイスアクセス権限
@@ -0,0 +1,116 @@
+package camera
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// 永続的なデバイス識別子の取得元（テストで差し替えられるよう変数にしている）
+var (
+	v4lByIDDir     = "/dev/v4l/by-id"
+	v4lByPathDir   = "/dev/v4l/by-path"
+	video4linuxDir = "/sys/class/video4linux"
+)
+
+// sanitizeIDPattern はIDに使用できない文字を表す
+var sanitizeIDPattern = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// usbCameraID はUSBカメラの安定したIDを生成する
+// 同じ物理カメラであれば再起動や挿し直しで /dev/videoN が変わっても同じIDになる
+func usbCameraID(device string) string {
+	sum := sha1.Sum([]byte(deviceIdentity(device)))
+	return "camera_" + hex.EncodeToString(sum[:6])
+}
+
+// x11ScreenID はX11画面キャプチャのIDをディスプレイ名から生成する
+func x11ScreenID(display string) string {
+	name := strings.Trim(sanitizeIDPattern.ReplaceAllString(display, "_"), "_")
+	if name == "" {
+		name = "default"
+	}
+	return "screen_" + name
+}
+
+// deviceIdentity はデバイスの永続的な識別子を返す
+// 優先順位: /dev/v4l/by-id → USBシリアル番号 → /dev/v4l/by-path → デバイスパス
+func deviceIdentity(device string) string {
+	resolved := resolveDevicePath(device)
+
+	if link := findDeviceLink(v4lByIDDir, resolved); link != "" {
+		return "by-id:" + link
+	}
+
+	if serial := usbSerial(resolved); serial != "" {
+		return "usb-serial:" + serial
+	}
+
+	if link := findDeviceLink(v4lByPathDir, resolved); link != "" {
+		return "by-path:" + link
+	}
+
+	return "device:" + resolved
+}
+
+// resolveDevicePath はシンボリックリンクを解決した実デバイスパスを返す
+// 解決できない場合は元のパスを返す
+func resolveDevicePath(device string) string {
+	resolved, err := filepath.EvalSymlinks(device)
+	if err != nil {
+		return device
+	}
+	return resolved
+}
+
+// findDeviceLink はディレクトリ内で指定デバイスを指すシンボリックリンク名を返す
+// 複数ある場合は名前順で最初のものを返す
+func findDeviceLink(dir, resolved string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	for _, entry := range entries {
+		if resolveDevicePath(filepath.Join(dir, entry.Name())) == resolved {
+			return entry.Name()
+		}
+	}
+	return ""
+}
+
+// usbSerial はsysfsからUSBデバイスのベンダー・製品ID・シリアル番号を取得する
+// 同じカメラの複数ノードを区別するためにインターフェース番号を含める
+func usbSerial(resolved string) string {
+	nodeDir := filepath.Join(video4linuxDir, filepath.Base(resolved))
+
+	// device はUSBインターフェースを指すので、その親がUSBデバイス
+	interfaceDir, err := filepath.EvalSymlinks(filepath.Join(nodeDir, "device"))
+	if err != nil {
+		return ""
+	}
+	usbDir := filepath.Dir(interfaceDir)
+
+	serial := readSysfsValue(filepath.Join(usbDir, "serial"))
+	if serial == "" {
+		return ""
+	}
+
+	vendor := readSysfsValue(filepath.Join(usbDir, "idVendor"))
+	product := readSysfsValue(filepath.Join(usbDir, "idProduct"))
+	index := readSysfsValue(filepath.Join(nodeDir, "index"))
+
+	return fmt.Sprintf("%s:%s:%s:%s", vendor, product, serial, index)
+}
+
+// readSysfsValue はsysfsの値を読み取る。読み取れない場合は空文字を返す
+func readSysfsValue(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,149 @@
+package camera
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupIdentityDirs はテスト用にby-id/by-path/sysfsのディレクトリを差し替える
+func setupIdentityDirs(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	origByID, origByPath, origSysfs := v4lByIDDir, v4lByPathDir, video4linuxDir
+	v4lByIDDir = filepath.Join(root, "by-id")
+	v4lByPathDir = filepath.Join(root, "by-path")
+	video4linuxDir = filepath.Join(root, "sys")
+	t.Cleanup(func() {
+		v4lByIDDir, v4lByPathDir, video4linuxDir = origByID, origByPath, origSysfs
+	})
+
+	for _, dir := range []string{v4lByIDDir, v4lByPathDir, video4linuxDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+	return root
+}
+
+// createDeviceFile はテスト用のデバイスファイルを作成する
+func createDeviceFile(t *testing.T, root, name string) string {
+	t.Helper()
+
+	path := filepath.Join(root, name)
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("Failed to create device file: %v", err)
+	}
+	return path
+}
+
+func TestUSBCameraID_ByID(t *testing.T) {
+	root := setupIdentityDirs(t)
+	video0 := createDeviceFile(t, root, "video0")
+	video2 := createDeviceFile(t, root, "video2")
+
+	link := filepath.Join(v4lByIDDir, "usb-046d_HD_Webcam_C920_ABC123-video-index0")
+	if err := os.Symlink(video0, link); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	id := usbCameraID(video0)
+	if !strings.HasPrefix(id, "camera_") {
+		t.Errorf("Expected ID to start with camera_, got %s", id)
+	}
+
+	// シンボリックリンク経由でも同じIDになる
+	if linkID := usbCameraID(link); linkID != id {
+		t.Errorf("Expected same ID via symlink, got %s and %s", id, linkID)
+	}
+
+	// 挿し直しで番号が変わっても同じIDになる
+	if err := os.Remove(link); err != nil {
+		t.Fatalf("Failed to remove symlink: %v", err)
+	}
+	if err := os.Symlink(video2, link); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	if replugged := usbCameraID(video2); replugged != id {
+		t.Errorf("Expected same ID after replug, got %s and %s", id, replugged)
+	}
+
+	// 別のカメラは別のIDになる
+	if other := usbCameraID(video0); other == id {
+		t.Errorf("Expected different ID for unrelated device, got %s", other)
+	}
+}
+
+func TestUSBCameraID_USBSerial(t *testing.T) {
+	root := setupIdentityDirs(t)
+	video0 := createDeviceFile(t, root, "video0")
+	video4 := createDeviceFile(t, root, "video4")
+
+	// sysfs: video4linux/videoN/device -> usb/1-1/1-1:1.0
+	usbDir := filepath.Join(root, "usb", "1-1")
+	interfaceDir := filepath.Join(usbDir, "1-1:1.0")
+	if err := os.MkdirAll(interfaceDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	for name, value := range map[string]string{"serial": "ABC123\n", "idVendor": "046d\n", "idProduct": "082d\n"} {
+		if err := os.WriteFile(filepath.Join(usbDir, name), []byte(value), 0644); err != nil {
+			t.Fatalf("Failed to write sysfs value: %v", err)
+		}
+	}
+
+	createNode := func(name string) {
+		nodeDir := filepath.Join(video4linuxDir, name)
+		if err := os.MkdirAll(nodeDir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(nodeDir, "index"), []byte("0\n"), 0644); err != nil {
+			t.Fatalf("Failed to write sysfs value: %v", err)
+		}
+		if err := os.Symlink(interfaceDir, filepath.Join(nodeDir, "device")); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+	}
+	createNode("video0")
+	createNode("video4")
+
+	if identity := deviceIdentity(video0); identity != "usb-serial:046d:082d:ABC123:0" {
+		t.Errorf("Unexpected identity: %s", identity)
+	}
+
+	// 同じシリアル番号のカメラは番号が違っても同じID
+	if usbCameraID(video0) != usbCameraID(video4) {
+		t.Error("Expected same ID for the same USB serial")
+	}
+}
+
+func TestUSBCameraID_Fallback(t *testing.T) {
+	setupIdentityDirs(t)
+
+	// 識別子が取得できない場合はデバイスパスから決定的に生成される
+	if usbCameraID("/dev/video0") != usbCameraID("/dev/video0") {
+		t.Error("Expected deterministic ID for the same device path")
+	}
+	if usbCameraID("/dev/video0") == usbCameraID("/dev/video1") {
+		t.Error("Expected different IDs for different device paths")
+	}
+}
+
+func TestX11ScreenID(t *testing.T) {
+	testCases := []struct {
+		display  string
+		expected string
+	}{
+		{display: ":0.0", expected: "screen_0_0"},
+		{display: ":1", expected: "screen_1"},
+		{display: "localhost:10.0", expected: "screen_localhost_10_0"},
+		{display: "", expected: "screen_default"},
+	}
+
+	for _, tc := range testCases {
+		if id := x11ScreenID(tc.display); id != tc.expected {
+			t.Errorf("x11ScreenID(%q) = %s, want %s", tc.display, id, tc.expected)
+		}
+	}
+}
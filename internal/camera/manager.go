@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"sync"
 	"time"
 )
@@ -20,6 +21,9 @@ type DefaultCameraManager struct {
 	stopCh chan struct{}
 	wg     sync.WaitGroup
 
+	// runCtx はStartで渡されたコンテキスト（API経由で開始するソースの実行に使用）
+	runCtx context.Context
+
 	// 自動検出設定
 	autoDiscovery bool
 	scanInterval  time.Duration
@@ -27,10 +31,21 @@ type DefaultCameraManager struct {
 	// VideoSource管理用
 	videoSources  map[string]VideoSource
 	sourceFactory VideoSourceFactory
+
+	// デバイス毎の設定（デバイスパスをキーとする）
+	deviceConfigs map[string]SourceConfig
+
+	// API経由で削除されたデバイス（自動検出で再追加しない）
+	removedDevices map[string]struct{}
+
+	// 映像ソースの監視（障害時の自動再起動）
+	supervisor       *Supervisor
+	supervisorCancel context.CancelFunc
+	supervisorDone   chan struct{}
 }
 
 // NewDefaultCameraManager は新しいDefaultCameraManagerを作成する
-func NewDefaultCameraManager(discovery Discovery) Manager {
+func NewDefaultCameraManager(discovery Discovery) *DefaultCameraManager {
 	// デフォルトのVideoSettings
 	defaultSettings := VideoSettings{
 		Width:      1280,
@@ -41,7 +56,7 @@ func NewDefaultCameraManager(discovery Discovery) Manager {
 		Properties: make(map[string]interface{}),
 	}
 
-	return &DefaultCameraManager{
+	m := &DefaultCameraManager{
 		discovery:       discovery,
 		defaultSettings: defaultSettings,
 		stopCh:          make(chan struct{}),
@@ -49,7 +64,11 @@ func NewDefaultCameraManager(discovery Discovery) Manager {
 		scanInterval:    30 * time.Second,
 		videoSources:    make(map[string]VideoSource),
 		sourceFactory:   NewVideoSourceFactory(),
+		deviceConfigs:   make(map[string]SourceConfig),
+		removedDevices:  make(map[string]struct{}),
 	}
+	m.supervisor = NewSupervisor(m.GetVideoSources, DefaultSupervisorOptions())
+	return m
 }
 
 // Start はカメラマネージャーを開始する
@@ -57,23 +76,22 @@ func (m *DefaultCameraManager) Start(ctx context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.runCtx = ctx
+
 	// 初期スキャンを実行
 	if _, err := m.performDiscovery(ctx); err != nil {
 		return fmt.Errorf("初期スキャンに失敗: %w", err)
 	}
 
 	// X11画面録画をデフォルトで追加（USBカメラの前に追加）
-	x11Config := SourceConfig{
-		Device: "x11:screen",
-		Settings: VideoSettings{
-			Width:      1920,
-			Height:     1080,
-			FrameRate:  15,
-			Format:     "MJPEG",
-			Quality:    3,
-			Properties: make(map[string]interface{}),
-		},
-	}
+	x11Config := m.sourceConfigFor(x11ScreenDevice, VideoSettings{
+		Width:      1920,
+		Height:     1080,
+		FrameRate:  15,
+		Format:     "MJPEG",
+		Quality:    3,
+		Properties: make(map[string]interface{}),
+	})
 
 	x11Source, err := m.sourceFactory.CreateSource(SourceTypeX11Screen, x11Config)
 	if err != nil {
@@ -97,11 +115,30 @@ func (m *DefaultCameraManager) Start(ctx context.Context) error {
 		go m.backgroundScan(ctx)
 	}
 
+	// 映像ソースの監視を開始
+	supervisorCtx, cancel := context.WithCancel(ctx)
+	m.supervisorCancel = cancel
+	m.supervisorDone = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		m.supervisor.Run(supervisorCtx)
+	}(m.supervisorDone)
+
 	return nil
 }
 
 // Stop はカメラマネージャーを停止する
 func (m *DefaultCameraManager) Stop(ctx context.Context) error {
+	// 監視はソース一覧の取得でロックを使うため、ロックを取得する前に停止する
+	m.mu.Lock()
+	cancel, done := m.supervisorCancel, m.supervisorDone
+	m.supervisorCancel, m.supervisorDone = nil, nil
+	m.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -143,28 +180,10 @@ func (m *DefaultCameraManager) performDiscovery(ctx context.Context) ([]string,
 		return nil, err
 	}
 
-	// 新しく検出されたデバイスを自動追加
-	for _, device := range devices {
-		// 既に登録済みかチェック
-		isRegistered := false
-		for _, source := range m.videoSources {
-			if source.GetInfo().Device == device {
-				isRegistered = true
-				break
-			}
-		}
-
-		if !isRegistered {
-			// デフォルト設定で自動追加
-			_, err := m.addVideoSourceInternal(ctx, device, m.defaultSettings)
-			if err != nil {
-				// ログ出力は実際の実装で行う
-				continue
-			}
-		}
-	}
+	devices = m.applyConfiguredDevices(ctx, devices)
 
 	// 存在しなくなったデバイスを検出
+	// 挿し直しで番号が変わったカメラは同じIDで追加し直すため、追加より先に削除する
 	var toRemove []string
 	for id, source := range m.videoSources {
 		// X11ScreenSourceは削除対象から除外
@@ -190,15 +209,60 @@ func (m *DefaultCameraManager) performDiscovery(ctx context.Context) ([]string,
 		m.removeVideoSourceInternal(ctx, id)
 	}
 
+	// 新しく検出されたデバイスを自動追加
+	for _, device := range devices {
+		if _, removed := m.removedDevices[device]; removed {
+			continue
+		}
+
+		// 既に登録済みかチェック
+		isRegistered := false
+		for _, source := range m.videoSources {
+			if source.GetInfo().Device == device {
+				isRegistered = true
+				break
+			}
+		}
+
+		if !isRegistered {
+			// デバイス設定（なければデフォルト設定）で自動追加
+			_, err := m.addVideoSourceInternal(ctx, device)
+			if err != nil {
+				// ログ出力は実際の実装で行う
+				continue
+			}
+		}
+	}
+
 	return devices, nil
 }
 
-// addVideoSourceInternal は内部でVideoSourceを追加する（ロック済み前提）
-func (m *DefaultCameraManager) addVideoSourceInternal(ctx context.Context, device string, settings VideoSettings) (VideoSource, error) {
-	config := SourceConfig{
-		Device:   device,
-		Settings: settings,
+// applyConfiguredDevices は検出結果に設定済みデバイスを反映する（ロック済み前提）
+// 設定のデバイスパスが /dev/v4l/by-id 等のシンボリックリンクの場合、同じ実デバイスの
+// 検出結果を設定のパスに置き換える。番号が変わっても同じ設定・IDで管理するため。
+// 検出結果に含まれない設定済みデバイスも、利用可能であれば追加する
+func (m *DefaultCameraManager) applyConfiguredDevices(ctx context.Context, devices []string) []string {
+	for configured := range m.deviceConfigs {
+		if configured == x11ScreenDevice || slices.Contains(devices, configured) {
+			continue
+		}
+
+		resolved := resolveDevicePath(configured)
+		if i := slices.Index(devices, resolved); i >= 0 {
+			devices[i] = configured
+			continue
+		}
+
+		if m.discovery.IsDeviceAvailable(ctx, configured) {
+			devices = append(devices, configured)
+		}
 	}
+	return devices
+}
+
+// addVideoSourceInternal は内部でVideoSourceを追加する（ロック済み前提）
+func (m *DefaultCameraManager) addVideoSourceInternal(ctx context.Context, device string) (VideoSource, error) {
+	config := m.sourceConfigFor(device, m.defaultSettings)
 
 	videoSource, err := m.sourceFactory.CreateSource(SourceTypeUSBCamera, config)
 	if err != nil {
@@ -207,6 +271,9 @@ func (m *DefaultCameraManager) addVideoSourceInternal(ctx context.Context, devic
 
 	// VideoSourceを管理対象に追加
 	sourceID := videoSource.GetInfo().ID
+	if _, exists := m.videoSources[sourceID]; exists {
+		return nil, fmt.Errorf("VideoSource IDが重複しています: %s", sourceID)
+	}
 	m.videoSources[sourceID] = videoSource
 
 	// VideoSourceを自動的に開始
@@ -271,7 +338,79 @@ func (m *DefaultCameraManager) SetScanInterval(interval time.Duration) {
 	m.scanInterval = interval
 }
 
+// SetDefaultSettings は自動検出したカメラに適用するデフォルト設定を変更する
+func (m *DefaultCameraManager) SetDefaultSettings(settings VideoSettings) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultSettings = settings
+}
+
+// SetDeviceConfigs はデバイス毎の設定を登録する
+// 登録されたデバイスは検出時に指定のID・名前・映像設定で追加される
+func (m *DefaultCameraManager) SetDeviceConfigs(configs []SourceConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deviceConfigs = make(map[string]SourceConfig, len(configs))
+	for _, config := range configs {
+		if config.Device == "" {
+			continue
+		}
+		m.deviceConfigs[config.Device] = config
+	}
+}
+
+// sourceConfigFor はデバイスの設定をデフォルト設定に重ねたソース作成設定を返す（ロック済み前提）
+func (m *DefaultCameraManager) sourceConfigFor(device string, defaults VideoSettings) SourceConfig {
+	config := SourceConfig{
+		Device:   device,
+		Settings: defaults,
+	}
+
+	deviceConfig, exists := m.deviceConfigs[device]
+	if !exists {
+		return config
+	}
+
+	config.ID = deviceConfig.ID
+	config.Name = deviceConfig.Name
+	config.URL = deviceConfig.URL
+	config.Properties = deviceConfig.Properties
+	config.Settings = mergeSettings(defaults, deviceConfig.Settings)
+	return config
+}
+
+// mergeSettings はoverrideで指定された値（ゼロ値以外）をbaseに上書きする
+func mergeSettings(base, override VideoSettings) VideoSettings {
+	merged := base
+	if override.Width > 0 {
+		merged.Width = override.Width
+	}
+	if override.Height > 0 {
+		merged.Height = override.Height
+	}
+	if override.FrameRate > 0 {
+		merged.FrameRate = override.FrameRate
+	}
+	if override.Format != "" {
+		merged.Format = override.Format
+	}
+	if override.Quality > 0 {
+		merged.Quality = override.Quality
+	}
+
+	merged.Properties = make(map[string]interface{}, len(base.Properties)+len(override.Properties))
+	for k, v := range base.Properties {
+		merged.Properties[k] = v
+	}
+	for k, v := range override.Properties {
+		merged.Properties[k] = v
+	}
+	return merged
+}
+
 // AddVideoSource はVideoSourceを追加する
+// 追加したソースは開始しないため、必要に応じて StartVideoSource を呼び出す
 func (m *DefaultCameraManager) AddVideoSource(ctx context.Context, sourceType VideoSourceType, config SourceConfig) (VideoSource, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -279,7 +418,7 @@ func (m *DefaultCameraManager) AddVideoSource(ctx context.Context, sourceType Vi
 	// USBカメラの場合はデバイスの存在確認
 	if sourceType == SourceTypeUSBCamera && config.Device != "" {
 		if !m.discovery.IsDeviceAvailable(ctx, config.Device) {
-			return nil, fmt.Errorf("デバイスが利用できません: %s", config.Device)
+			return nil, fmt.Errorf("%w: %s", ErrDeviceUnavailable, config.Device)
 		}
 	}
 
@@ -289,9 +428,39 @@ func (m *DefaultCameraManager) AddVideoSource(ctx context.Context, sourceType Vi
 		return nil, fmt.Errorf("VideoSourceの作成に失敗: %w", err)
 	}
 
+	// 作成関数が反映しない項目（品質・追加設定等）も含めて設定を検証・適用する
+	settings := mergeSettings(source.GetCurrentSettings(), config.Settings)
+	if err := ValidateSettings(source.GetCapabilities(), settings); err != nil {
+		return nil, err
+	}
+	if err := source.ApplySettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("VideoSourceへの設定適用に失敗: %w", err)
+	}
+
 	// VideoSourceを管理対象に追加
-	sourceID := source.GetInfo().ID
-	m.videoSources[sourceID] = source
+	info := source.GetInfo()
+	if _, exists := m.videoSources[info.ID]; exists {
+		return nil, fmt.Errorf("%w: ID %s", ErrVideoSourceExists, info.ID)
+	}
+	for _, existing := range m.videoSources {
+		if existing.GetInfo().Device == info.Device {
+			return nil, fmt.Errorf("%w: デバイス %s", ErrVideoSourceExists, info.Device)
+		}
+	}
+	m.videoSources[info.ID] = source
+
+	// USBカメラは自動検出で同じIDのまま管理されるようデバイス設定として登録する
+	if sourceType == SourceTypeUSBCamera {
+		delete(m.removedDevices, info.Device)
+		m.deviceConfigs[info.Device] = SourceConfig{
+			ID:         info.ID,
+			Name:       info.Name,
+			Device:     info.Device,
+			URL:        config.URL,
+			Settings:   source.GetCurrentSettings(),
+			Properties: config.Properties,
+		}
+	}
 
 	return source, nil
 }
@@ -325,7 +494,7 @@ func (m *DefaultCameraManager) RemoveVideoSource(ctx context.Context, id string)
 
 	source, exists := m.videoSources[id]
 	if !exists {
-		return fmt.Errorf("VideoSourceが見つかりません: %s", id)
+		return fmt.Errorf("%w: %s", ErrVideoSourceNotFound, id)
 	}
 
 	// VideoSourceが動作中の場合は停止
@@ -338,5 +507,102 @@ func (m *DefaultCameraManager) RemoveVideoSource(ctx context.Context, id string)
 	// 管理対象から削除
 	delete(m.videoSources, id)
 
+	// 接続されたままのUSBカメラが次の自動検出で再追加されないようにする
+	if info := source.GetInfo(); info.Type == SourceTypeUSBCamera {
+		m.removedDevices[info.Device] = struct{}{}
+	}
+
+	return nil
+}
+
+// StartVideoSource は指定されたIDのVideoSourceを開始する
+// ソースはリクエスト単位ではなくマネージャーのコンテキストで動作する
+func (m *DefaultCameraManager) StartVideoSource(id string) error {
+	source, err := m.lookupVideoSource(id)
+	if err != nil {
+		return err
+	}
+
+	if err := source.Start(m.runContext()); err != nil {
+		return fmt.Errorf("VideoSource %s の開始に失敗: %w", id, err)
+	}
+
+	log.Printf("VideoSource %s を開始しました", id)
 	return nil
 }
+
+// StopVideoSource は指定されたIDのVideoSourceを停止する
+func (m *DefaultCameraManager) StopVideoSource(ctx context.Context, id string) error {
+	source, err := m.lookupVideoSource(id)
+	if err != nil {
+		return err
+	}
+
+	if err := source.Stop(ctx); err != nil {
+		return fmt.Errorf("VideoSource %s の停止に失敗: %w", id, err)
+	}
+
+	log.Printf("VideoSource %s を停止しました", id)
+	return nil
+}
+
+// UpdateVideoSourceSettings は指定された値（ゼロ値以外）で映像設定を更新し、適用後の設定を返す
+// 更新後の設定はソースの能力に対して検証し、動作中のソースは新しい設定で再開始される
+func (m *DefaultCameraManager) UpdateVideoSourceSettings(id string, settings VideoSettings) (VideoSettings, error) {
+	source, err := m.lookupVideoSource(id)
+	if err != nil {
+		return VideoSettings{}, err
+	}
+
+	merged := mergeSettings(source.GetCurrentSettings(), settings)
+	if err := ValidateSettings(source.GetCapabilities(), merged); err != nil {
+		return VideoSettings{}, err
+	}
+
+	if err := source.ApplySettings(m.runContext(), merged); err != nil {
+		return VideoSettings{}, fmt.Errorf("VideoSource %s への設定適用に失敗: %w", id, err)
+	}
+
+	// 自動検出で再作成された場合も同じ設定を使用する
+	m.mu.Lock()
+	if deviceConfig, exists := m.deviceConfigs[source.GetInfo().Device]; exists {
+		deviceConfig.Settings = merged
+		m.deviceConfigs[source.GetInfo().Device] = deviceConfig
+	}
+	m.mu.Unlock()
+
+	log.Printf("VideoSource %s の設定を更新しました: %dx%d@%dfps", id, merged.Width, merged.Height, merged.FrameRate)
+	return source.GetCurrentSettings(), nil
+}
+
+// GetSourceHealth は映像ソースの監視状態（自動再起動の回数・最後のエラー）を返す
+func (m *DefaultCameraManager) GetSourceHealth(id string) (SourceHealth, bool) {
+	return m.supervisor.Health(id)
+}
+
+// GetSupportedSourceTypes は作成可能なソースタイプ一覧を返す
+func (m *DefaultCameraManager) GetSupportedSourceTypes() []VideoSourceType {
+	types := m.sourceFactory.GetSupportedTypes()
+	slices.Sort(types)
+	return types
+}
+
+// lookupVideoSource は指定されたIDのVideoSourceを取得する
+func (m *DefaultCameraManager) lookupVideoSource(id string) (VideoSource, error) {
+	source, exists := m.GetVideoSource(id)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrVideoSourceNotFound, id)
+	}
+	return source, nil
+}
+
+// runContext はソースの実行に使用するコンテキストを返す
+func (m *DefaultCameraManager) runContext() context.Context {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.runCtx == nil {
+		return context.Background()
+	}
+	return m.runCtx
+}
@@ -2,6 +2,9 @@ package camera
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -261,3 +264,218 @@ func TestDefaultCameraManager_ConcurrentAccess(t *testing.T) {
 		t.Fatalf("Expected 3 video sources after concurrent access (2 USB + X11), got %d", len(sources))
 	}
 }
+
+func TestDefaultCameraManager_DeviceConfigs(t *testing.T) {
+	ctx := context.Background()
+	mockDiscovery := NewMockDiscovery([]string{"/dev/video0", "/dev/video1"})
+
+	manager := NewDefaultCameraManager(mockDiscovery)
+	manager.SetDefaultSettings(VideoSettings{
+		Width:     640,
+		Height:    480,
+		FrameRate: 10,
+		Format:    "MJPEG",
+		Quality:   3,
+	})
+	manager.SetDeviceConfigs([]SourceConfig{
+		{
+			ID:     "entrance",
+			Name:   "玄関カメラ",
+			Device: "/dev/video0",
+			Settings: VideoSettings{
+				Width:     1920,
+				Height:    1080,
+				FrameRate: 5,
+			},
+		},
+	})
+
+	if err := manager.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() { _ = manager.Stop(ctx) }()
+
+	// 設定されたデバイスは指定のID・名前・映像設定で追加される
+	source, found := manager.GetVideoSource("entrance")
+	if !found {
+		t.Fatal("Configured video source not found by ID")
+	}
+
+	info := source.GetInfo()
+	if info.Name != "玄関カメラ" || info.Device != "/dev/video0" {
+		t.Errorf("Unexpected video source info: %+v", info)
+	}
+
+	settings := source.GetCurrentSettings()
+	if settings.Width != 1920 || settings.Height != 1080 || settings.FrameRate != 5 {
+		t.Errorf("Expected 1920x1080@5, got %dx%d@%d", settings.Width, settings.Height, settings.FrameRate)
+	}
+	if settings.Format != "MJPEG" {
+		t.Errorf("Expected default format MJPEG, got %s", settings.Format)
+	}
+
+	// 設定のないデバイスはデフォルト設定で追加される
+	for _, source := range manager.GetVideoSources() {
+		if source.GetInfo().Device != "/dev/video1" {
+			continue
+		}
+		settings := source.GetCurrentSettings()
+		if settings.Width != 640 || settings.Height != 480 || settings.FrameRate != 10 {
+			t.Errorf("Expected 640x480@10, got %dx%d@%d", settings.Width, settings.Height, settings.FrameRate)
+		}
+	}
+}
+
+func TestDefaultCameraManager_UpdateVideoSourceSettings(t *testing.T) {
+	ctx := context.Background()
+	mockDiscovery := NewMockDiscovery([]string{})
+
+	manager := NewDefaultCameraManager(mockDiscovery)
+	if err := manager.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() { _ = manager.Stop(ctx) }()
+
+	mockDiscovery.AddDevice("/dev/video0")
+	source, err := manager.AddVideoSource(ctx, SourceTypeUSBCamera, SourceConfig{ID: "desk", Device: "/dev/video0"})
+	if err != nil {
+		t.Fatalf("AddVideoSource failed: %v", err)
+	}
+
+	// 指定した項目のみ更新される
+	settings, err := manager.UpdateVideoSourceSettings("desk", VideoSettings{FrameRate: 30, Quality: 5})
+	if err != nil {
+		t.Fatalf("UpdateVideoSourceSettings failed: %v", err)
+	}
+	if settings.Width != 1280 || settings.Height != 720 || settings.FrameRate != 30 || settings.Quality != 5 {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+	if current := source.GetCurrentSettings(); current.FrameRate != 30 {
+		t.Errorf("Expected settings to be applied to the source, got %+v", current)
+	}
+
+	// 能力外の設定は拒否される
+	if _, err := manager.UpdateVideoSourceSettings("desk", VideoSettings{Width: 1000, Height: 500}); !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Expected ErrInvalidSettings for unsupported resolution, got %v", err)
+	}
+	if _, err := manager.UpdateVideoSourceSettings("unknown", VideoSettings{FrameRate: 30}); !errors.Is(err, ErrVideoSourceNotFound) {
+		t.Errorf("Expected ErrVideoSourceNotFound, got %v", err)
+	}
+
+	// 同じデバイスは重複して追加できない
+	if _, err := manager.AddVideoSource(ctx, SourceTypeUSBCamera, SourceConfig{Device: "/dev/video0"}); !errors.Is(err, ErrVideoSourceExists) {
+		t.Errorf("Expected ErrVideoSourceExists for duplicate device, got %v", err)
+	}
+}
+
+func TestDefaultCameraManager_RemovedDeviceNotRediscovered(t *testing.T) {
+	ctx := context.Background()
+	mockDiscovery := NewMockDiscovery([]string{"/dev/video0"})
+
+	manager := NewDefaultCameraManager(mockDiscovery)
+	if err := manager.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() { _ = manager.Stop(ctx) }()
+
+	var id string
+	for _, source := range manager.GetVideoSources() {
+		if source.GetInfo().Device == "/dev/video0" {
+			id = source.GetInfo().ID
+		}
+	}
+	if id == "" {
+		t.Fatal("Discovered video source not found")
+	}
+
+	if err := manager.RemoveVideoSource(ctx, id); err != nil {
+		t.Fatalf("RemoveVideoSource failed: %v", err)
+	}
+
+	// 接続されたままでも削除したデバイスは再追加されない
+	if _, err := manager.DiscoverCameras(ctx); err != nil {
+		t.Fatalf("DiscoverCameras failed: %v", err)
+	}
+	if _, found := manager.GetVideoSource(id); found {
+		t.Error("Removed video source should not be re-added by discovery")
+	}
+
+	// 明示的に追加すれば再び管理対象になる
+	if _, err := manager.AddVideoSource(ctx, SourceTypeUSBCamera, SourceConfig{Device: "/dev/video0"}); err != nil {
+		t.Fatalf("AddVideoSource failed: %v", err)
+	}
+	if _, found := manager.GetVideoSource(id); !found {
+		t.Error("Expected re-added video source to keep its ID")
+	}
+}
+
+func TestDefaultCameraManager_ReplugOnNewNode(t *testing.T) {
+	ctx := context.Background()
+	root := setupIdentityDirs(t)
+	video0 := createDeviceFile(t, root, "video0")
+	video2 := createDeviceFile(t, root, "video2")
+	link := filepath.Join(v4lByIDDir, "usb-046d_HD_Webcam_C920_ABC123-video-index0")
+	if err := os.Symlink(video0, link); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	mockDiscovery := NewMockDiscovery([]string{video0})
+	manager := NewDefaultCameraManager(mockDiscovery)
+	if err := manager.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() { _ = manager.Stop(ctx) }()
+
+	id := usbCameraID(video0)
+	if _, found := manager.GetVideoSource(id); !found {
+		t.Fatal("Discovered video source not found")
+	}
+
+	// 挿し直しで /dev/video0 から /dev/video2 に変わる
+	if err := os.Remove(link); err != nil {
+		t.Fatalf("Failed to remove symlink: %v", err)
+	}
+	if err := os.Symlink(video2, link); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	mockDiscovery.RemoveDevice(video0)
+	mockDiscovery.AddDevice(video2)
+
+	// 1回の検出で同じIDのまま新しいデバイスに切り替わる
+	if _, err := manager.DiscoverCameras(ctx); err != nil {
+		t.Fatalf("DiscoverCameras failed: %v", err)
+	}
+	source, found := manager.GetVideoSource(id)
+	if !found {
+		t.Fatal("Expected replugged camera to keep its ID")
+	}
+	if device := source.GetInfo().Device; device != video2 {
+		t.Errorf("Expected device %s after replug, got %s", video2, device)
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	capabilities := VideoCapabilities{
+		SupportedResolutions: []Resolution{{Width: 640, Height: 480}},
+		SupportedFrameRates:  []int{15, 30},
+		SupportedFormats:     []string{"MJPEG"},
+	}
+	valid := VideoSettings{Width: 640, Height: 480, FrameRate: 15, Format: "mjpeg", Quality: 3}
+
+	if err := ValidateSettings(capabilities, valid); err != nil {
+		t.Errorf("Expected valid settings, got %v", err)
+	}
+
+	invalid := []VideoSettings{
+		{Width: 1280, Height: 720, FrameRate: 15, Quality: 3},
+		{Width: 640, Height: 480, FrameRate: 60, Quality: 3},
+		{Width: 640, Height: 480, FrameRate: 15, Format: "H264", Quality: 3},
+		{Width: 640, Height: 480, FrameRate: 15, Quality: 0},
+		{Width: 640, Height: 480, FrameRate: 15, Quality: 32},
+	}
+	for _, settings := range invalid {
+		if err := ValidateSettings(capabilities, settings); !errors.Is(err, ErrInvalidSettings) {
+			t.Errorf("Expected ErrInvalidSettings for %+v, got %v", settings, err)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package camera
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// JPEGのマーカー
+const (
+	jpegMarkerPrefix = 0xFF
+	jpegMarkerSOI    = 0xD8
+	jpegMarkerEOI    = 0xD9
+	jpegMarkerDHT    = 0xC4
+	jpegMarkerSOS    = 0xDA
+	jpegMarkerTEM    = 0x01
+	jpegMarkerRST0   = 0xD0
+	jpegMarkerRST7   = 0xD7
+	jpegMarkerStuff  = 0x00 // エントロピー符号化データ中の 0xFF のエスケープ
+)
+
+// standardHuffmanTables はJPEG規格（ITU-T T.81 Annex K.3）の標準ハフマンテーブルのDHTセグメント
+// UVCカメラのMJPEGフレームはハフマンテーブルを省略し、標準テーブルの使用を前提とする
+var standardHuffmanTables = buildDHTSegment([]huffmanTable{
+	{
+		class: 0, id: 0, // 輝度DC
+		counts: [16]byte{0, 1, 5, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0},
+		values: []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+	},
+	{
+		class: 1, id: 0, // 輝度AC
+		counts: [16]byte{0, 2, 1, 3, 3, 2, 4, 3, 5, 5, 4, 4, 0, 0, 1, 125},
+		values: []byte{
+			0x01, 0x02, 0x03, 0x00, 0x04, 0x11, 0x05, 0x12,
+			0x21, 0x31, 0x41, 0x06, 0x13, 0x51, 0x61, 0x07,
+			0x22, 0x71, 0x14, 0x32, 0x81, 0x91, 0xa1, 0x08,
+			0x23, 0x42, 0xb1, 0xc1, 0x15, 0x52, 0xd1, 0xf0,
+			0x24, 0x33, 0x62, 0x72, 0x82, 0x09, 0x0a, 0x16,
+			0x17, 0x18, 0x19, 0x1a, 0x25, 0x26, 0x27, 0x28,
+			0x29, 0x2a, 0x34, 0x35, 0x36, 0x37, 0x38, 0x39,
+			0x3a, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48, 0x49,
+			0x4a, 0x53, 0x54, 0x55, 0x56, 0x57, 0x58, 0x59,
+			0x5a, 0x63, 0x64, 0x65, 0x66, 0x67, 0x68, 0x69,
+			0x6a, 0x73, 0x74, 0x75, 0x76, 0x77, 0x78, 0x79,
+			0x7a, 0x83, 0x84, 0x85, 0x86, 0x87, 0x88, 0x89,
+			0x8a, 0x92, 0x93, 0x94, 0x95, 0x96, 0x97, 0x98,
+			0x99, 0x9a, 0xa2, 0xa3, 0xa4, 0xa5, 0xa6, 0xa7,
+			0xa8, 0xa9, 0xaa, 0xb2, 0xb3, 0xb4, 0xb5, 0xb6,
+			0xb7, 0xb8, 0xb9, 0xba, 0xc2, 0xc3, 0xc4, 0xc5,
+			0xc6, 0xc7, 0xc8, 0xc9, 0xca, 0xd2, 0xd3, 0xd4,
+			0xd5, 0xd6, 0xd7, 0xd8, 0xd9, 0xda, 0xe1, 0xe2,
+			0xe3, 0xe4, 0xe5, 0xe6, 0xe7, 0xe8, 0xe9, 0xea,
+			0xf1, 0xf2, 0xf3, 0xf4, 0xf5, 0xf6, 0xf7, 0xf8,
+			0xf9, 0xfa,
+		},
+	},
+	{
+		class: 0, id: 1, // 色差DC
+		counts: [16]byte{0, 3, 1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0},
+		values: []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+	},
+	{
+		class: 1, id: 1, // 色差AC
+		counts: [16]byte{0, 2, 1, 2, 4, 4, 3, 4, 7, 5, 4, 4, 0, 1, 2, 119},
+		values: []byte{
+			0x00, 0x01, 0x02, 0x03, 0x11, 0x04, 0x05, 0x21,
+			0x31, 0x06, 0x12, 0x41, 0x51, 0x07, 0x61, 0x71,
+			0x13, 0x22, 0x32, 0x81, 0x08, 0x14, 0x42, 0x91,
+			0xa1, 0xb1, 0xc1, 0x09, 0x23, 0x33, 0x52, 0xf0,
+			0x15, 0x62, 0x72, 0xd1, 0x0a, 0x16, 0x24, 0x34,
+			0xe1, 0x25, 0xf1, 0x17, 0x18, 0x19, 0x1a, 0x26,
+			0x27, 0x28, 0x29, 0x2a, 0x35, 0x36, 0x37, 0x38,
+			0x39, 0x3a, 0x43, 0x44, 0x45, 0x46, 0x47, 0x48,
+			0x49, 0x4a, 0x53, 0x54, 0x55, 0x56, 0x57, 0x58,
+			0x59, 0x5a, 0x63, 0x64, 0x65, 0x66, 0x67, 0x68,
+			0x69, 0x6a, 0x73, 0x74, 0x75, 0x76, 0x77, 0x78,
+			0x79, 0x7a, 0x82, 0x83, 0x84, 0x85, 0x86, 0x87,
+			0x88, 0x89, 0x8a, 0x92, 0x93, 0x94, 0x95, 0x96,
+			0x97, 0x98, 0x99, 0x9a, 0xa2, 0xa3, 0xa4, 0xa5,
+			0xa6, 0xa7, 0xa8, 0xa9, 0xaa, 0xb2, 0xb3, 0xb4,
+			0xb5, 0xb6, 0xb7, 0xb8, 0xb9, 0xba, 0xc2, 0xc3,
+			0xc4, 0xc5, 0xc6, 0xc7, 0xc8, 0xc9, 0xca, 0xd2,
+			0xd3, 0xd4, 0xd5, 0xd6, 0xd7, 0xd8, 0xd9, 0xda,
+			0xe2, 0xe3, 0xe4, 0xe5, 0xe6, 0xe7, 0xe8, 0xe9,
+			0xea, 0xf2, 0xf3, 0xf4, 0xf5, 0xf6, 0xf7, 0xf8,
+			0xf9, 0xfa,
+		},
+	},
+})
+
+// huffmanTable はDHTセグメントに含めるハフマンテーブル
+type huffmanTable struct {
+	class  byte     // 0: DC、1: AC
+	id     byte     // テーブル番号
+	counts [16]byte // 符号長毎の符号数
+	values []byte   // シンボル
+}
+
+// buildDHTSegment はハフマンテーブルからDHTセグメント（マーカーを含む）を作成する
+func buildDHTSegment(tables []huffmanTable) []byte {
+	length := 2
+	for _, table := range tables {
+		length += 1 + len(table.counts) + len(table.values)
+	}
+
+	segment := []byte{jpegMarkerPrefix, jpegMarkerDHT}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(length))
+	for _, table := range tables {
+		segment = append(segment, table.class<<4|table.id)
+		segment = append(segment, table.counts[:]...)
+		segment = append(segment, table.values...)
+	}
+	return segment
+}
+
+// completeMJPEGFrame はハフマンテーブルを省略したMJPEGフレームに標準テーブルを補い、単体で表示できるJPEGにする
+// テーブルを含むフレームや解析できないデータはそのまま返す
+func completeMJPEGFrame(frame []byte) []byte {
+	if len(frame) < 4 || frame[0] != jpegMarkerPrefix || frame[1] != jpegMarkerSOI {
+		return frame
+	}
+
+	for i := 2; i+1 < len(frame); {
+		if frame[i] != jpegMarkerPrefix {
+			return frame
+		}
+
+		marker := frame[i+1]
+		switch {
+		case marker == jpegMarkerPrefix:
+			// マーカー前の埋め草
+			i++
+			continue
+		case marker == jpegMarkerDHT:
+			return frame
+		case marker == jpegMarkerSOS:
+			completed := make([]byte, 0, len(frame)+len(standardHuffmanTables))
+			completed = append(completed, frame[:i]...)
+			completed = append(completed, standardHuffmanTables...)
+			return append(completed, frame[i:]...)
+		case marker == jpegMarkerTEM || (marker >= jpegMarkerRST0 && marker <= jpegMarkerRST7):
+			// 長さを持たないマーカー
+			i += 2
+			continue
+		}
+
+		if i+4 > len(frame) {
+			return frame
+		}
+		i += 2 + int(binary.BigEndian.Uint16(frame[i+2:]))
+	}
+
+	return frame
+}
+
+// nextJPEGFrame はストリームのデータから最初の完全なJPEGフレームを切り出し、フレームと残りのデータを返す
+// 完全なフレームがまだない場合はnilと、次に読み取ったデータを繋げるべき残りのデータを返す
+func nextJPEGFrame(data []byte) (frame, rest []byte) {
+	for {
+		start := bytes.Index(data, []byte{jpegMarkerPrefix, jpegMarkerSOI})
+		if start == -1 {
+			// 開始マーカーの途中で分割されている場合に備えて最後の1バイトを残す
+			if len(data) > 0 {
+				return nil, data[len(data)-1:]
+			}
+			return nil, data
+		}
+		data = data[start:]
+
+		length := jpegFrameLength(data)
+		switch {
+		case length < 0:
+			// 壊れたフレームは捨てて次の開始マーカーを探す
+			data = data[2:]
+		case length == 0:
+			return nil, data
+		default:
+			return data[:length], data[length:]
+		}
+	}
+}
+
+// jpegFrameLength はSOIから始まるJPEGデータのEOIまでの長さを返す
+// EXIFのサムネイル等に含まれるSOI・EOIで分割しないよう、長さを持つセグメント（APPn等）は読み飛ばし、
+// SOS以降はエントロピー符号化データ（0xFF00・RSTnを除く）の後のマーカーを辿る
+// データが足りない場合は0を、マーカーの構造が壊れている場合は-1を返す
+func jpegFrameLength(data []byte) int {
+	i := 2
+	for {
+		if i+2 > len(data) {
+			return 0
+		}
+		if data[i] != jpegMarkerPrefix {
+			return -1
+		}
+
+		marker := data[i+1]
+		switch {
+		case marker == jpegMarkerPrefix:
+			// マーカー前の埋め草
+			i++
+			continue
+		case marker == jpegMarkerEOI:
+			return i + 2
+		case marker == jpegMarkerSOI:
+			return -1
+		case marker == jpegMarkerTEM || (marker >= jpegMarkerRST0 && marker <= jpegMarkerRST7):
+			// 長さを持たないマーカー
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return 0
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 {
+			return -1
+		}
+		i += 2 + length
+		if marker != jpegMarkerSOS {
+			continue
+		}
+
+		// エントロピー符号化データを読み飛ばして次のマーカーを探す
+		for {
+			next := bytes.IndexByte(data[min(i, len(data)):], jpegMarkerPrefix)
+			if next == -1 || i+next+2 > len(data) {
+				return 0
+			}
+			i += next
+			marker := data[i+1]
+			if marker == jpegMarkerStuff || (marker >= jpegMarkerRST0 && marker <= jpegMarkerRST7) {
+				i += 2
+				continue
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package camera
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// stripDHT はJPEGからDHTセグメントを取り除き、UVCカメラのMJPEGフレームを再現する
+func stripDHT(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	stripped := append([]byte{}, data[:2]...)
+	for i := 2; i < len(data); {
+		if data[i+1] == jpegMarkerSOS {
+			return append(stripped, data[i:]...)
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if data[i+1] != jpegMarkerDHT {
+			stripped = append(stripped, data[i:i+2+length]...)
+		}
+		i += 2 + length
+	}
+	t.Fatal("SOS marker not found")
+	return nil
+}
+
+func TestCompleteMJPEGFrame(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 32; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 8), G: uint8(y * 16), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 80}); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+
+	// テーブルを含むフレームはそのまま
+	if got := completeMJPEGFrame(buf.Bytes()); !bytes.Equal(got, buf.Bytes()) {
+		t.Error("Expected frame with Huffman tables to be unchanged")
+	}
+
+	frame := stripDHT(t, buf.Bytes())
+	if _, err := jpeg.Decode(bytes.NewReader(frame)); err == nil {
+		t.Fatal("Expected frame without Huffman tables to fail decoding")
+	}
+
+	completed := completeMJPEGFrame(frame)
+	decoded, err := jpeg.Decode(bytes.NewReader(completed))
+	if err != nil {
+		t.Fatalf("Expected completed frame to decode, got %v", err)
+	}
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("Expected bounds %v, got %v", img.Bounds(), decoded.Bounds())
+	}
+
+	// JPEGでないデータはそのまま
+	garbage := []byte{0x00, 0x01, 0x02, 0x03}
+	if got := completeMJPEGFrame(garbage); !bytes.Equal(got, garbage) {
+		t.Error("Expected non-JPEG data to be unchanged")
+	}
+}
+
+// encodeTestJPEG はテスト用のJPEGを作成する
+func encodeTestJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 7), G: uint8(y * 13), B: uint8(x ^ y), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withEXIFThumbnail はSOIの直後にサムネイルのJPEGを含むAPP1（EXIF）セグメントを挿入する
+func withEXIFThumbnail(t *testing.T, frame, thumbnail []byte) []byte {
+	t.Helper()
+
+	payload := append([]byte("Exif\x00\x00"), thumbnail...)
+	segment := []byte{jpegMarkerPrefix, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, frame[:2]...)
+	result = append(result, segment...)
+	return append(result, frame[2:]...)
+}
+
+func TestNextJPEGFrame(t *testing.T) {
+	frame := withEXIFThumbnail(t, encodeTestJPEG(t, 64, 48), encodeTestJPEG(t, 8, 8))
+	second := encodeTestJPEG(t, 32, 16)
+
+	stream := append([]byte{0x12, 0x34}, frame...)
+	stream = append(stream, second...)
+
+	// サムネイルのEOIで分割せず、フレーム全体を切り出す
+	got, rest := nextJPEGFrame(stream)
+	if !bytes.Equal(got, frame) {
+		t.Fatalf("Expected the whole frame with EXIF thumbnail (%d bytes), got %d bytes", len(frame), len(got))
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(got)); err != nil {
+		t.Errorf("Expected the frame to decode, got %v", err)
+	}
+
+	got, rest = nextJPEGFrame(rest)
+	if !bytes.Equal(got, second) {
+		t.Errorf("Expected the second frame, got %d bytes", len(got))
+	}
+	if len(rest) != 0 {
+		t.Errorf("Expected no remaining data, got %d bytes", len(rest))
+	}
+
+	// 途中までのフレームは残りのデータとして返す
+	for _, size := range []int{1, 3, 100, len(frame) - 1} {
+		got, rest := nextJPEGFrame(frame[:size])
+		if got != nil {
+			t.Errorf("Expected no frame from %d bytes, got %d bytes", size, len(got))
+		}
+		if !bytes.Equal(rest, frame[:size]) {
+			t.Errorf("Expected incomplete data to be kept for %d bytes", size)
+		}
+	}
+
+	// 壊れたフレームは捨てて次のフレームを切り出す
+	broken := append([]byte{jpegMarkerPrefix, jpegMarkerSOI, 0x00, 0x00}, second...)
+	if got, _ := nextJPEGFrame(broken); !bytes.Equal(got, second) {
+		t.Errorf("Expected the frame after broken data, got %d bytes", len(got))
+	}
+}
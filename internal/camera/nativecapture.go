@@ -0,0 +1,296 @@
+package camera
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ネイティブキャプチャの動作パラメーター
+const (
+	nativeBufferCount  = 4                // mmapするバッファ数
+	nativeFrameTimeout = time.Second      // 1回のフレーム待機の上限（停止の確認間隔）
+	nativeTestTimeout  = 10 * time.Second // テストキャプチャで最初のフレームを待つ上限
+)
+
+// nativePixelFormats はネイティブキャプチャで扱えるフォーマット（優先順）
+var nativePixelFormats = []string{"MJPEG", "YUYV"}
+
+var (
+	// errFrameTimeout はフレームの待機がタイムアウトしたことを表す
+	errFrameTimeout = errors.New("フレームの待機がタイムアウトしました")
+	// errCorruptedFrame はドライバーがエラーを報告したフレームを表す
+	errCorruptedFrame = errors.New("破損したフレームです")
+)
+
+// v4l2StreamFormat はデバイスに設定されたキャプチャフォーマット
+type v4l2StreamFormat struct {
+	Format       string // フォーマット名（MJPEG、YUYV）
+	Width        int
+	Height       int
+	BytesPerLine int // 非圧縮フォーマットの1行のバイト数
+}
+
+// v4l2StreamDevice はmmapストリーミングで使うV4L2デバイスの操作
+// テストでは偽のデバイスに置き換える
+type v4l2StreamDevice interface {
+	// SetFormat はキャプチャフォーマットを設定し、ドライバーが実際に選んだフォーマットを返す
+	SetFormat(format string, width, height int) (v4l2StreamFormat, error)
+	// SetFrameRate はフレームレートを設定する
+	SetFrameRate(fps int) error
+	// StartStreaming はバッファを確保・マップしてストリーミングを開始する
+	StartStreaming(bufferCount int) error
+	// ReadFrame は取得済みのフレームを1つ取り出す（データはコピーして返す）
+	// timeout までにフレームがない場合は errFrameTimeout、破損したフレームは errCorruptedFrame を返す
+	ReadFrame(timeout time.Duration) ([]byte, error)
+	// Close はストリーミングを停止してデバイスを閉じる
+	Close() error
+}
+
+// NativeV4L2Capturer はffmpegを使わずにV4L2デバイスから直接画像を取得する
+// MJPEGはそのまま、YUYVはGoでJPEGにエンコードして配信する
+type NativeV4L2Capturer struct {
+	devicePath   string
+	capabilities VideoCapabilities
+	settings     VideoSettings
+	openDevice   func(devicePath string) (v4l2StreamDevice, error)
+}
+
+// NewNativeV4L2Capturer は新しいNativeV4L2Capturerを作成する
+func NewNativeV4L2Capturer(devicePath string, capabilities VideoCapabilities, settings VideoSettings) *NativeV4L2Capturer {
+	return &NativeV4L2Capturer{
+		devicePath:   devicePath,
+		capabilities: capabilities,
+		settings:     settings,
+		openDevice:   openV4L2StreamDevice,
+	}
+}
+
+// IsDeviceAvailable はV4L2デバイスが利用可能かチェックする
+func (c *NativeV4L2Capturer) IsDeviceAvailable(_ context.Context) bool {
+	_, err := queryV4L2Device(c.devicePath)
+	return err == nil
+}
+
+// TestCapture はデバイスを開いて1フレーム取得できるか確認する
+func (c *NativeV4L2Capturer) TestCapture(ctx context.Context) error {
+	device, format, err := c.open()
+	if err != nil {
+		return err
+	}
+	defer c.closeDevice(device)
+
+	deadline := time.Now().Add(nativeTestTimeout)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		data, err := device.ReadFrame(nativeFrameTimeout)
+		if errors.Is(err, errFrameTimeout) || errors.Is(err, errCorruptedFrame) {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("テストキャプチャがタイムアウトしました")
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("テストキャプチャに失敗: %w", err)
+		}
+
+		_, err = c.encodeFrame(format, data)
+		return err
+	}
+}
+
+// StartStream は連続キャプチャを行う
+// ctx がキャンセルされるまでブロックし、終了時にデバイスを閉じる
+func (c *NativeV4L2Capturer) StartStream(ctx context.Context, frameChan chan<- []byte, errorChan chan<- error) {
+	device, format, err := c.open()
+	if err != nil {
+		sendStreamError(ctx, errorChan, err)
+		return
+	}
+	defer c.closeDevice(device)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		data, err := device.ReadFrame(nativeFrameTimeout)
+		if errors.Is(err, errFrameTimeout) || errors.Is(err, errCorruptedFrame) {
+			continue
+		}
+		if err != nil {
+			sendStreamError(ctx, errorChan, fmt.Errorf("フレーム読み取りエラー: %w", err))
+			return
+		}
+
+		// 変換できないフレームは破棄して続行する（エラーチャンネルはストリームの停止のみを通知する）
+		frame, err := c.encodeFrame(format, data)
+		if err != nil {
+			log.Printf("%s: フレームを破棄しました: %v", c.devicePath, err)
+			continue
+		}
+
+		select {
+		case frameChan <- frame:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// open はデバイスを開いてフォーマットを設定し、ストリーミングを開始する
+func (c *NativeV4L2Capturer) open() (v4l2StreamDevice, v4l2StreamFormat, error) {
+	device, err := c.openDevice(c.devicePath)
+	if err != nil {
+		return nil, v4l2StreamFormat{}, fmt.Errorf("デバイス %s のオープンに失敗: %w", c.devicePath, err)
+	}
+
+	format, err := c.configure(device)
+	if err != nil {
+		c.closeDevice(device)
+		return nil, v4l2StreamFormat{}, err
+	}
+
+	if err := device.StartStreaming(nativeBufferCount); err != nil {
+		c.closeDevice(device)
+		return nil, v4l2StreamFormat{}, fmt.Errorf("ストリーミングの開始に失敗: %w", err)
+	}
+
+	return device, format, nil
+}
+
+// configure はキャプチャフォーマットとフレームレートを設定する
+// 候補のフォーマットを順に設定し、ドライバーが扱えるフォーマットを選んだ時点で確定する
+func (c *NativeV4L2Capturer) configure(device v4l2StreamDevice) (v4l2StreamFormat, error) {
+	for _, candidate := range c.formatCandidates() {
+		format, err := device.SetFormat(candidate, c.settings.Width, c.settings.Height)
+		if err != nil {
+			return v4l2StreamFormat{}, fmt.Errorf("フォーマット %s の設定に失敗: %w", candidate, err)
+		}
+		if !slices.Contains(nativePixelFormats, format.Format) {
+			continue // ドライバーが別のフォーマットに置き換えた
+		}
+
+		if format.Width != c.settings.Width || format.Height != c.settings.Height {
+			log.Printf("%s: 解像度 %dx%d は %dx%d に調整されました",
+				c.devicePath, c.settings.Width, c.settings.Height, format.Width, format.Height)
+		}
+		if c.settings.FrameRate > 0 {
+			// フレームレートの設定に対応していないドライバーもあるため失敗しても続行する
+			if err := device.SetFrameRate(c.settings.FrameRate); err != nil {
+				log.Printf("%s: フレームレート %dfps の設定に失敗: %v", c.devicePath, c.settings.FrameRate, err)
+			}
+		}
+		return format, nil
+	}
+
+	return v4l2StreamFormat{}, fmt.Errorf("対応しているピクセルフォーマット（%s）がありません", strings.Join(nativePixelFormats, ", "))
+}
+
+// formatCandidates は設定するフォーマットの候補を優先順に返す
+// 指定の解像度・フレームレートに対応するフォーマットを先頭に、扱えるフォーマットを続ける
+func (c *NativeV4L2Capturer) formatCandidates() []string {
+	var candidates []string
+	if format, found := selectCaptureFormat(c.capabilities, c.settings, func(format string) bool {
+		return slices.Contains(nativePixelFormats, format)
+	}); found {
+		candidates = append(candidates, format)
+	}
+	for _, format := range nativePixelFormats {
+		if !slices.Contains(candidates, format) {
+			candidates = append(candidates, format)
+		}
+	}
+	return candidates
+}
+
+// encodeFrame はデバイスから取得したフレームをJPEGに変換する
+func (c *NativeV4L2Capturer) encodeFrame(format v4l2StreamFormat, data []byte) ([]byte, error) {
+	switch format.Format {
+	case "MJPEG":
+		if len(data) < 4 || data[0] != jpegMarkerPrefix || data[1] != jpegMarkerSOI {
+			return nil, fmt.Errorf("JPEGの開始マーカーがないフレームです（%dバイト）", len(data))
+		}
+		return completeMJPEGFrame(data), nil
+
+	case "YUYV":
+		img, err := yuyvToYCbCr(data, format.Width, format.Height, format.BytesPerLine)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQualityFromScale(c.settings.Quality)}); err != nil {
+			return nil, fmt.Errorf("JPEGエンコードに失敗: %w", err)
+		}
+		return buf.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("サポートされていないフォーマットです: %s", format.Format)
+	}
+}
+
+// closeDevice はデバイスを閉じる（失敗はログ出力のみ）
+func (c *NativeV4L2Capturer) closeDevice(device v4l2StreamDevice) {
+	if err := device.Close(); err != nil {
+		log.Printf("%s: デバイスのクローズに失敗: %v", c.devicePath, err)
+	}
+}
+
+// sendStreamError はエラーを送信する（停止済みの場合は破棄する）
+func sendStreamError(ctx context.Context, errorChan chan<- error, err error) {
+	select {
+	case errorChan <- err:
+	case <-ctx.Done():
+	}
+}
+
+// yuyvToYCbCr はYUYV（YUV 4:2:2）のフレームを画像に変換する
+func yuyvToYCbCr(data []byte, width, height, bytesPerLine int) (*image.YCbCr, error) {
+	if width <= 0 || height <= 0 || width%2 != 0 {
+		return nil, fmt.Errorf("YUYVフレームの解像度が不正です: %dx%d", width, height)
+	}
+	if bytesPerLine < width*2 {
+		bytesPerLine = width * 2
+	}
+	if len(data) < bytesPerLine*(height-1)+width*2 {
+		return nil, fmt.Errorf("YUYVフレームのサイズが不足しています: %dバイト（%dx%d）", len(data), width, height)
+	}
+
+	img := image.NewYCbCr(image.Rect(0, 0, width, height), image.YCbCrSubsampleRatio422)
+	for y := 0; y < height; y++ {
+		row := data[y*bytesPerLine:]
+		luma := img.Y[y*img.YStride:]
+		cb := img.Cb[y*img.CStride:]
+		cr := img.Cr[y*img.CStride:]
+
+		// 2画素毎に Y0 U Y1 V の4バイト
+		for x := 0; x < width; x += 2 {
+			i := x * 2
+			luma[x] = row[i]
+			luma[x+1] = row[i+2]
+			cb[x/2] = row[i+1]
+			cr[x/2] = row[i+3]
+		}
+	}
+	return img, nil
+}
+
+// jpegQualityFromScale は映像設定の品質（ffmpegの -q:v、1〜31で小さいほど高画質）をJPEGの品質（1〜100）に変換する
+func jpegQualityFromScale(scale int) int {
+	if scale < MinQuality || scale > MaxQuality {
+		scale = defaultCaptureQuality
+	}
+	return max(100-(scale-1)*3, 1)
+}
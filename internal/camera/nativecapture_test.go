@@ -0,0 +1,239 @@
+package camera
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStreamDevice はテスト用の偽のV4L2デバイス
+type fakeStreamDevice struct {
+	mu        sync.Mutex
+	accepted  []string // ドライバーが受け付けるフォーマット（それ以外は先頭に置き換える）
+	frames    [][]byte
+	format    v4l2StreamFormat
+	frameRate int
+	streaming bool
+	closed    bool
+}
+
+func (d *fakeStreamDevice) SetFormat(format string, width, height int) (v4l2StreamFormat, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !slices.Contains(d.accepted, format) {
+		format = d.accepted[0]
+	}
+	d.format = v4l2StreamFormat{Format: format, Width: width, Height: height, BytesPerLine: width * 2}
+	return d.format, nil
+}
+
+func (d *fakeStreamDevice) SetFrameRate(fps int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.frameRate = fps
+	return nil
+}
+
+func (d *fakeStreamDevice) StartStreaming(_ int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.streaming = true
+	return nil
+}
+
+func (d *fakeStreamDevice) ReadFrame(_ time.Duration) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.streaming {
+		return nil, errors.New("not streaming")
+	}
+	if len(d.frames) == 0 {
+		d.mu.Unlock()
+		time.Sleep(time.Millisecond)
+		d.mu.Lock()
+		return nil, errFrameTimeout
+	}
+	frame := d.frames[0]
+	d.frames = d.frames[1:]
+	if frame == nil {
+		return nil, errCorruptedFrame
+	}
+	return frame, nil
+}
+
+func (d *fakeStreamDevice) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.streaming = false
+	d.closed = true
+	return nil
+}
+
+// newFakeNativeCapturer は偽のデバイスを使うNativeV4L2Capturerを作成する
+func newFakeNativeCapturer(device *fakeStreamDevice, settings VideoSettings) *NativeV4L2Capturer {
+	capturer := NewNativeV4L2Capturer("/dev/video0", VideoCapabilities{}, settings)
+	capturer.openDevice = func(string) (v4l2StreamDevice, error) {
+		return device, nil
+	}
+	return capturer
+}
+
+// runNativeStream はストリームを開始し、最初のフレームを受け取ってから停止する
+func runNativeStream(t *testing.T, capturer *NativeV4L2Capturer) []byte {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	frameChan := make(chan []byte, 1)
+	errorChan := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		capturer.StartStream(ctx, frameChan, errorChan)
+	}()
+
+	var frame []byte
+	select {
+	case frame = <-frameChan:
+	case err := <-errorChan:
+		t.Fatalf("Unexpected stream error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a frame")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("StartStream did not return after cancellation")
+	}
+	return frame
+}
+
+// testYUYVFrame は幅4・高さ2のYUYVフレームを返す
+func testYUYVFrame() []byte {
+	return []byte{
+		16, 128, 235, 128, 81, 90, 145, 240,
+		41, 240, 210, 110, 128, 128, 128, 128,
+	}
+}
+
+func TestNativeV4L2Capturer_StreamMJPEG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 16, 8)), nil); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+
+	device := &fakeStreamDevice{
+		accepted: []string{"YUYV", "MJPEG"},
+		frames:   [][]byte{nil, stripDHT(t, buf.Bytes())},
+	}
+	capturer := newFakeNativeCapturer(device, VideoSettings{Width: 16, Height: 8, FrameRate: 30, Format: "MJPEG", Quality: 3})
+
+	frame := runNativeStream(t, capturer)
+	img, err := jpeg.Decode(bytes.NewReader(frame))
+	if err != nil {
+		t.Fatalf("Expected a decodable JPEG frame, got %v", err)
+	}
+	if img.Bounds().Dx() != 16 || img.Bounds().Dy() != 8 {
+		t.Errorf("Expected 16x8 frame, got %v", img.Bounds())
+	}
+
+	if device.format.Format != "MJPEG" {
+		t.Errorf("Expected MJPEG to be preferred, got %s", device.format.Format)
+	}
+	if device.frameRate != 30 {
+		t.Errorf("Expected frame rate 30, got %d", device.frameRate)
+	}
+	if !device.closed {
+		t.Error("Expected device to be closed after the stream stopped")
+	}
+}
+
+func TestNativeV4L2Capturer_StreamYUYV(t *testing.T) {
+	device := &fakeStreamDevice{
+		accepted: []string{"YUYV"},
+		frames:   [][]byte{testYUYVFrame()},
+	}
+	capturer := newFakeNativeCapturer(device, VideoSettings{Width: 4, Height: 2, FrameRate: 15, Format: "MJPEG", Quality: 3})
+
+	frame := runNativeStream(t, capturer)
+	img, err := jpeg.Decode(bytes.NewReader(frame))
+	if err != nil {
+		t.Fatalf("Expected YUYV to be encoded as JPEG, got %v", err)
+	}
+	if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 2 {
+		t.Errorf("Expected 4x2 frame, got %v", img.Bounds())
+	}
+}
+
+func TestNativeV4L2Capturer_UnsupportedFormat(t *testing.T) {
+	device := &fakeStreamDevice{accepted: []string{"H264"}}
+	capturer := newFakeNativeCapturer(device, VideoSettings{Width: 640, Height: 480, FrameRate: 30, Quality: 3})
+
+	if err := capturer.TestCapture(context.Background()); err == nil {
+		t.Error("Expected error when the device offers no usable format")
+	}
+	if !device.closed {
+		t.Error("Expected device to be closed after a failed open")
+	}
+}
+
+func TestNativeV4L2Capturer_OpenError(t *testing.T) {
+	capturer := NewNativeV4L2Capturer("/dev/video0", VideoCapabilities{}, VideoSettings{Width: 640, Height: 480})
+	capturer.openDevice = func(string) (v4l2StreamDevice, error) {
+		return nil, errors.New("device busy")
+	}
+
+	errorChan := make(chan error, 1)
+	capturer.StartStream(context.Background(), make(chan []byte), errorChan)
+
+	select {
+	case err := <-errorChan:
+		if err == nil {
+			t.Error("Expected open error")
+		}
+	default:
+		t.Error("Expected open error to be reported")
+	}
+}
+
+func TestYUYVToYCbCr(t *testing.T) {
+	img, err := yuyvToYCbCr(testYUYVFrame(), 4, 2, 8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(img.Y, []byte{16, 235, 81, 145, 41, 210, 128, 128}) {
+		t.Errorf("Unexpected luma: %v", img.Y)
+	}
+	if !bytes.Equal(img.Cb, []byte{128, 90, 240, 128}) || !bytes.Equal(img.Cr, []byte{128, 240, 110, 128}) {
+		t.Errorf("Unexpected chroma: Cb=%v Cr=%v", img.Cb, img.Cr)
+	}
+
+	if _, err := yuyvToYCbCr(testYUYVFrame()[:10], 4, 2, 8); err == nil {
+		t.Error("Expected error for truncated frame")
+	}
+	if _, err := yuyvToYCbCr(testYUYVFrame(), 3, 2, 8); err == nil {
+		t.Error("Expected error for odd width")
+	}
+}
+
+func TestJPEGQualityFromScale(t *testing.T) {
+	if got := jpegQualityFromScale(1); got != 100 {
+		t.Errorf("Expected quality 100 for scale 1, got %d", got)
+	}
+	if got := jpegQualityFromScale(31); got != 10 {
+		t.Errorf("Expected quality 10 for scale 31, got %d", got)
+	}
+	if got, want := jpegQualityFromScale(0), jpegQualityFromScale(defaultCaptureQuality); got != want {
+		t.Errorf("Expected default quality %d for invalid scale, got %d", want, got)
+	}
+}
@@ -0,0 +1,144 @@
+package camera
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// 映像品質（ffmpegの -q:v、小さいほど高画質）の範囲
+const (
+	MinQuality = 1
+	MaxQuality = 31
+)
+
+// ValidateSettings は映像設定がソースの能力で対応可能か検証する
+// フォーマット毎の対応モードが取得できている場合は、フォーマット・解像度・フレームレートの組み合わせで検証する
+// 能力の一覧が空の項目は制限しない
+func ValidateSettings(capabilities VideoCapabilities, settings VideoSettings) error {
+	if settings.Width <= 0 || settings.Height <= 0 {
+		return fmt.Errorf("%w: 解像度には正の値を指定してください", ErrInvalidSettings)
+	}
+	if settings.FrameRate <= 0 {
+		return fmt.Errorf("%w: フレームレートには正の値を指定してください", ErrInvalidSettings)
+	}
+
+	if settings.Format != "" && len(capabilities.SupportedFormats) > 0 &&
+		!slices.ContainsFunc(capabilities.SupportedFormats, func(format string) bool {
+			return strings.EqualFold(format, settings.Format)
+		}) {
+		return fmt.Errorf("%w: フォーマット %s はサポートされていません", ErrInvalidSettings, settings.Format)
+	}
+
+	resolution := Resolution{Width: settings.Width, Height: settings.Height}
+
+	if sizes := capabilities.frameSizes(settings.Format); len(sizes) > 0 {
+		rates, found := frameRatesFor(sizes, resolution)
+		if !found {
+			return fmt.Errorf("%w: 解像度 %dx%d はサポートされていません", ErrInvalidSettings, settings.Width, settings.Height)
+		}
+		if len(rates) > 0 && !slices.Contains(rates, settings.FrameRate) {
+			return fmt.Errorf("%w: 解像度 %dx%d ではフレームレート %d はサポートされていません（対応: %v）",
+				ErrInvalidSettings, settings.Width, settings.Height, settings.FrameRate, rates)
+		}
+	} else {
+		if len(capabilities.SupportedResolutions) > 0 && !slices.Contains(capabilities.SupportedResolutions, resolution) {
+			return fmt.Errorf("%w: 解像度 %dx%d はサポートされていません", ErrInvalidSettings, settings.Width, settings.Height)
+		}
+		if len(capabilities.SupportedFrameRates) > 0 && !slices.Contains(capabilities.SupportedFrameRates, settings.FrameRate) {
+			return fmt.Errorf("%w: フレームレート %d はサポートされていません", ErrInvalidSettings, settings.FrameRate)
+		}
+	}
+
+	if settings.Quality < MinQuality || settings.Quality > MaxQuality {
+		return fmt.Errorf("%w: 品質は%dから%dの範囲で指定してください", ErrInvalidSettings, MinQuality, MaxQuality)
+	}
+
+	return nil
+}
+
+// closestSupportedSettings は能力で対応可能な設定のうち、指定された設定に最も近いものを返す
+// 解像度は画素数が最も近いもの、フレームレートは指定値以下で最大のもの（なければ最小）を選ぶ
+func closestSupportedSettings(capabilities VideoCapabilities, settings VideoSettings) VideoSettings {
+	closest := settings
+
+	if closest.Format != "" && len(capabilities.SupportedFormats) > 0 {
+		index := slices.IndexFunc(capabilities.SupportedFormats, func(format string) bool {
+			return strings.EqualFold(format, closest.Format)
+		})
+		if index < 0 {
+			closest.Format = capabilities.SupportedFormats[0]
+		} else {
+			closest.Format = capabilities.SupportedFormats[index]
+		}
+	}
+
+	resolutions := capabilities.SupportedResolutions
+	sizes := capabilities.frameSizes(closest.Format)
+	if len(sizes) > 0 {
+		resolutions = make([]Resolution, 0, len(sizes))
+		for _, size := range sizes {
+			resolutions = append(resolutions, size.Resolution)
+		}
+	}
+
+	if len(resolutions) > 0 {
+		target := settings.Width * settings.Height
+		best := resolutions[0]
+		for _, resolution := range resolutions[1:] {
+			if abs(resolution.Width*resolution.Height-target) < abs(best.Width*best.Height-target) {
+				best = resolution
+			}
+		}
+		closest.Width, closest.Height = best.Width, best.Height
+	}
+
+	rates := capabilities.SupportedFrameRates
+	if len(sizes) > 0 {
+		rates, _ = frameRatesFor(sizes, Resolution{Width: closest.Width, Height: closest.Height})
+	}
+	if len(rates) > 0 && !slices.Contains(rates, closest.FrameRate) {
+		best := slices.Min(rates)
+		for _, rate := range rates {
+			if rate <= settings.FrameRate && rate > best {
+				best = rate
+			}
+		}
+		closest.FrameRate = best
+	}
+
+	return closest
+}
+
+// frameSizes は指定されたフォーマットの対応解像度を返す
+// フォーマットが空の場合は全フォーマットの対応解像度を返す
+func (c VideoCapabilities) frameSizes(format string) []FrameSize {
+	var sizes []FrameSize
+	for _, info := range c.Formats {
+		if format == "" || strings.EqualFold(info.Format, format) {
+			sizes = append(sizes, info.Sizes...)
+		}
+	}
+	return sizes
+}
+
+// frameRatesFor は解像度の対応フレームレートを返す（複数フォーマットの場合は和集合）
+func frameRatesFor(sizes []FrameSize, resolution Resolution) ([]int, bool) {
+	var rates []int
+	found := false
+	for _, size := range sizes {
+		if size.Resolution == resolution {
+			found = true
+			rates = append(rates, size.FrameRates...)
+		}
+	}
+	return normalizeFrameRates(rates), found
+}
+
+// abs は整数の絶対値を返す
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
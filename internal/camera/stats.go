@@ -0,0 +1,102 @@
+package camera
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statsWindow はフレームレート・ビットレートを計測する期間
+const statsWindow = 5 * time.Second
+
+// SourceStats は映像ソースの配信統計を表す
+type SourceStats struct {
+	FrameRate        float64   // 直近の実測フレームレート（fps）
+	AverageFrameSize int       // 直近の平均フレームサイズ（バイト）
+	Bitrate          float64   // 直近の実測ビットレート（bps）
+	FramesPublished  uint64    // 配信したフレームの総数
+	FramesDropped    uint64    // 購読者のバッファが満杯で破棄したフレームの総数
+	LastFrameAt      time.Time // 最後にフレームを取得した時刻（未取得の場合はゼロ値）
+	StartedAt        time.Time // 開始した時刻（停止中はゼロ値）
+	Subscribers      int       // 現在の購読者数
+	CaptureCommand   string    // キャプチャに使っている外部コマンド（使っていない場合は空）
+}
+
+// frameSample は計測に使う1フレームの記録
+type frameSample struct {
+	at   time.Time
+	size int
+}
+
+// frameWindow は statsWindow 内のフレームの時刻とサイズを保持する
+type frameWindow struct {
+	samples []frameSample
+}
+
+// add はフレームを記録し、計測期間を過ぎた記録を破棄する
+func (w *frameWindow) add(at time.Time, size int) {
+	w.samples = append(w.samples, frameSample{at: at, size: size})
+
+	expired := 0
+	for expired < len(w.samples) && at.Sub(w.samples[expired].at) > statsWindow {
+		expired++
+	}
+	if expired > 0 {
+		w.samples = append(w.samples[:0], w.samples[expired:]...)
+	}
+}
+
+// measure は now 時点の直近のフレームレート・平均フレームサイズ・ビットレートを返す
+// フレームが途絶えている場合は全て0を返す
+func (w *frameWindow) measure(now time.Time) (frameRate float64, averageSize int, bitrate float64) {
+	first := 0
+	for first < len(w.samples) && now.Sub(w.samples[first].at) > statsWindow {
+		first++
+	}
+	samples := w.samples[first:]
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+
+	total := 0
+	for _, sample := range samples {
+		total += sample.size
+	}
+	averageSize = total / len(samples)
+
+	// 最初のフレームから最後のフレームまでの間隔で割る（開始直後も過小評価しない）
+	span := samples[len(samples)-1].at.Sub(samples[0].at).Seconds()
+	if len(samples) < 2 || span <= 0 {
+		return 0, averageSize, 0
+	}
+	frameRate = float64(len(samples)-1) / span
+	bitrate = float64(total-samples[0].size) * 8 / span
+	return frameRate, averageSize, bitrate
+}
+
+// commandLiner は外部コマンドでキャプチャするキャプチャ
+type commandLiner interface {
+	// CommandLine はキャプチャに使うコマンドラインを返す
+	CommandLine() string
+}
+
+// captureCommandLine はキャプチャのコマンドラインを返す（外部コマンドを使わない場合は空）
+func captureCommandLine(capturer any) string {
+	if liner, ok := capturer.(commandLiner); ok {
+		return liner.CommandLine()
+	}
+	return ""
+}
+
+// formatCommandLine はコマンドと引数を表示用の1行にする（空白を含む引数は引用符で囲む）
+func formatCommandLine(name string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, name)
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,101 @@
+package camera
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFrameWindow_Measure(t *testing.T) {
+	var window frameWindow
+	start := time.Now()
+
+	// 10fps で 1000 バイトのフレームを2秒間
+	for i := 0; i <= 20; i++ {
+		window.add(start.Add(time.Duration(i)*100*time.Millisecond), 1000)
+	}
+	now := start.Add(2 * time.Second)
+
+	frameRate, averageSize, bitrate := window.measure(now)
+	if math.Abs(frameRate-10) > 0.01 {
+		t.Errorf("Expected 10 fps, got %f", frameRate)
+	}
+	if averageSize != 1000 {
+		t.Errorf("Expected average frame size 1000, got %d", averageSize)
+	}
+	if math.Abs(bitrate-80000) > 1 {
+		t.Errorf("Expected 80000 bps, got %f", bitrate)
+	}
+
+	// フレームが途絶えたら0になる
+	frameRate, averageSize, bitrate = window.measure(now.Add(statsWindow + time.Second))
+	if frameRate != 0 || averageSize != 0 || bitrate != 0 {
+		t.Errorf("Expected zero rates after frames stopped, got %f %d %f", frameRate, averageSize, bitrate)
+	}
+}
+
+func TestFrameWindow_DiscardsExpiredSamples(t *testing.T) {
+	var window frameWindow
+	start := time.Now()
+
+	for i := 0; i < 100; i++ {
+		window.add(start.Add(time.Duration(i)*time.Second), 1000)
+	}
+
+	if len(window.samples) > int(statsWindow/time.Second)+1 {
+		t.Errorf("Expected samples outside the window to be discarded, got %d", len(window.samples))
+	}
+}
+
+func TestFrameBroadcaster_Stats(t *testing.T) {
+	b := NewFrameBroadcaster()
+	sub := b.Subscribe(1, DropOldest)
+	defer sub.Unsubscribe()
+
+	b.Publish([]byte{1, 2, 3, 4})
+	b.Publish([]byte{5, 6})
+
+	stats := b.Stats(time.Now())
+	if stats.FramesPublished != 2 {
+		t.Errorf("Expected 2 published frames, got %d", stats.FramesPublished)
+	}
+	if stats.FramesDropped != 1 {
+		t.Errorf("Expected 1 dropped frame, got %d", stats.FramesDropped)
+	}
+	if stats.Subscribers != 1 {
+		t.Errorf("Expected 1 subscriber, got %d", stats.Subscribers)
+	}
+	if stats.AverageFrameSize != 3 {
+		t.Errorf("Expected average frame size 3, got %d", stats.AverageFrameSize)
+	}
+	if stats.LastFrameAt.IsZero() {
+		t.Error("Expected last frame time to be set")
+	}
+
+	b.Reset()
+	stats = b.Stats(time.Now())
+	if stats.AverageFrameSize != 0 || !stats.LastFrameAt.IsZero() {
+		t.Errorf("Expected frame measurements to be cleared by Reset, got %+v", stats)
+	}
+}
+
+func TestFormatCommandLine(t *testing.T) {
+	got := formatCommandLine("ffmpeg", []string{"-f", "v4l2", "-i", "/dev/v4l/by-id/usb-Camera Name-video-index0", "-"})
+	want := `ffmpeg -f v4l2 -i "/dev/v4l/by-id/usb-Camera Name-video-index0" -`
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestCaptureCommandLine(t *testing.T) {
+	capturer := NewV4L2Capturer("/dev/video0", 640, 480, 30)
+	if command := captureCommandLine(capturer); !strings.HasPrefix(command, "ffmpeg -f v4l2") || !strings.Contains(command, "/dev/video0") {
+		t.Errorf("Unexpected ffmpeg command line: %q", command)
+	}
+
+	native := NewNativeV4L2Capturer("/dev/video0", VideoCapabilities{}, VideoSettings{})
+	if command := captureCommandLine(native); command != "" {
+		t.Errorf("Expected no command line for native capture, got %q", command)
+	}
+}
@@ -0,0 +1,252 @@
+package camera
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// SupervisorOptions は映像ソース監視の設定
+type SupervisorOptions struct {
+	CheckInterval  time.Duration // 監視の間隔
+	StallTimeout   time.Duration // フレームが途絶えたとみなすまでの時間
+	InitialBackoff time.Duration // 最初の再起動までの待機時間
+	MaxBackoff     time.Duration // 再起動の待機時間の上限
+	StableDuration time.Duration // 再起動の待機時間を初期値に戻すまでの正常動作時間
+}
+
+// DefaultSupervisorOptions はデフォルトの監視設定を返す
+func DefaultSupervisorOptions() SupervisorOptions {
+	return SupervisorOptions{
+		CheckInterval:  2 * time.Second,
+		StallTimeout:   15 * time.Second,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Minute,
+		StableDuration: time.Minute,
+	}
+}
+
+// SourceHealth は映像ソースの監視状態を表す
+type SourceHealth struct {
+	RestartCount  int       // 自動再起動の回数
+	LastError     string    // 最後に検出したエラー（なければ空）
+	LastErrorAt   time.Time // 最後にエラーを検出した時刻
+	LastRestartAt time.Time // 最後に自動再起動した時刻
+	NextRestartAt time.Time // 次に再起動を試みる時刻（エラー状態の場合のみ）
+}
+
+// errFrameStall はフレームが途絶えたことを表す
+var errFrameStall = errors.New("フレームが途絶えました")
+
+// errorMarker はソースをエラー状態にできる映像ソース
+type errorMarker interface {
+	markError()
+}
+
+// supervisedSource はソース毎の監視状態
+type supervisedSource struct {
+	source      VideoSource
+	activeSince time.Time     // 動作中を確認した時刻（フレーム途絶の判定に使用）
+	backoff     time.Duration // 直近の再起動の待機時間
+	failure     error         // 動作中に受信したエラー
+	health      SourceHealth
+}
+
+// Supervisor は映像ソースのエラーとフレームの途絶を監視し、エラー状態にして自動的に再起動する
+// 再起動の間隔は失敗する度に倍にし、一定時間正常に動作したら初期値に戻す
+// 停止中（StatusInactive）のソースは利用者が停止したものとして再起動しない
+type Supervisor struct {
+	sources func() []VideoSource
+	options SupervisorOptions
+
+	// states は Check からのみ操作する（ソースの停止・開始中もAPIから状態を参照できるよう、公開用は healths に複製する）
+	states map[string]*supervisedSource
+
+	mu      sync.RWMutex
+	healths map[string]SourceHealth
+}
+
+// NewSupervisor は新しいSupervisorを作成する
+// sources は監視対象のソース一覧を返す関数（監視の度に呼び出す）
+func NewSupervisor(sources func() []VideoSource, options SupervisorOptions) *Supervisor {
+	return &Supervisor{
+		sources: sources,
+		options: options,
+		states:  make(map[string]*supervisedSource),
+		healths: make(map[string]SourceHealth),
+	}
+}
+
+// Run は ctx がキャンセルされるまで定期的に監視する
+// 再起動したソースは ctx で動作する
+func (s *Supervisor) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.options.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Check(ctx, time.Now())
+		}
+	}
+}
+
+// Check は全ソースを1回監視し、障害を検出したソースの停止・再起動を行う
+// 複数のゴルーチンから同時に呼び出さないこと
+func (s *Supervisor) Check(ctx context.Context, now time.Time) {
+	sources := s.sources()
+
+	current := make(map[string]struct{}, len(sources))
+	for _, source := range sources {
+		id := source.GetInfo().ID
+		current[id] = struct{}{}
+
+		state, exists := s.states[id]
+		if !exists || state.source != source {
+			// 新しいソース（同じIDで再作成された場合を含む）
+			state = &supervisedSource{source: source}
+			s.states[id] = state
+		}
+
+		s.collectErrors(state, now)
+
+		switch source.GetStatus() {
+		case StatusActive:
+			s.checkActive(ctx, id, state, now)
+		case StatusError:
+			if !now.Before(state.health.NextRestartAt) {
+				s.restart(ctx, id, state, now)
+			}
+		default:
+			// 利用者が停止したソースは監視をやめる
+			state.activeSince = time.Time{}
+			state.failure = nil
+			state.backoff = 0
+			state.health.NextRestartAt = time.Time{}
+		}
+
+		s.mu.Lock()
+		s.healths[id] = state.health
+		s.mu.Unlock()
+	}
+
+	// 管理対象から外れたソースの状態を破棄
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range s.states {
+		if _, exists := current[id]; !exists {
+			delete(s.states, id)
+			delete(s.healths, id)
+		}
+	}
+}
+
+// Health は映像ソースの監視状態を返す
+func (s *Supervisor) Health(id string) (SourceHealth, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	health, exists := s.healths[id]
+	return health, exists
+}
+
+// collectErrors はソースのエラーチャンネルに溜まったエラーを受信する
+func (s *Supervisor) collectErrors(state *supervisedSource, now time.Time) {
+	for {
+		select {
+		case err, ok := <-state.source.GetErrorChannel():
+			if !ok || err == nil {
+				return
+			}
+			state.health.LastError = err.Error()
+			state.health.LastErrorAt = now
+			if state.source.GetStatus() == StatusActive {
+				state.failure = err
+			}
+		default:
+			return
+		}
+	}
+}
+
+// checkActive は動作中のソースのエラーとフレームの途絶を確認する
+func (s *Supervisor) checkActive(ctx context.Context, id string, state *supervisedSource, now time.Time) {
+	if state.activeSince.IsZero() {
+		state.activeSince = now
+	}
+
+	failure := state.failure
+	if failure == nil {
+		lastFrame := state.activeSince
+		if frame, ok := state.source.LatestFrame(); ok && frame.Timestamp.After(lastFrame) {
+			lastFrame = frame.Timestamp
+		}
+		if stalled := now.Sub(lastFrame); stalled > s.options.StallTimeout {
+			failure = fmt.Errorf("%w（%s間フレームなし）", errFrameStall, stalled.Truncate(time.Second))
+		}
+	}
+
+	if failure == nil {
+		if now.Sub(state.activeSince) >= s.options.StableDuration {
+			state.backoff = 0
+		}
+		return
+	}
+
+	s.fail(ctx, id, state, failure, now)
+}
+
+// fail はソースを停止してエラー状態にし、再起動を予約する
+func (s *Supervisor) fail(ctx context.Context, id string, state *supervisedSource, failure error, now time.Time) {
+	log.Printf("映像ソース %s の障害を検出しました: %v", id, failure)
+
+	if err := state.source.Stop(ctx); err != nil {
+		log.Printf("映像ソース %s の停止に失敗: %v", id, err)
+	}
+	if marker, ok := state.source.(errorMarker); ok {
+		marker.markError()
+	}
+
+	state.health.LastError = failure.Error()
+	state.health.LastErrorAt = now
+	state.activeSince = time.Time{}
+	state.failure = nil
+	s.scheduleRestart(id, state, now)
+}
+
+// restart はエラー状態のソースを再起動する
+func (s *Supervisor) restart(ctx context.Context, id string, state *supervisedSource, now time.Time) {
+	state.health.RestartCount++
+	state.health.LastRestartAt = now
+	state.health.NextRestartAt = time.Time{}
+
+	if err := state.source.Start(ctx); err != nil {
+		log.Printf("映像ソース %s の再起動に失敗: %v", id, err)
+		if marker, ok := state.source.(errorMarker); ok {
+			marker.markError()
+		}
+		state.health.LastError = err.Error()
+		state.health.LastErrorAt = now
+		s.scheduleRestart(id, state, now)
+		return
+	}
+
+	log.Printf("映像ソース %s を再起動しました（%d回目）", id, state.health.RestartCount)
+	state.activeSince = now
+}
+
+// scheduleRestart は待機時間を延ばして次の再起動を予約する
+func (s *Supervisor) scheduleRestart(id string, state *supervisedSource, now time.Time) {
+	if state.backoff == 0 {
+		state.backoff = s.options.InitialBackoff
+	} else {
+		state.backoff = min(state.backoff*2, s.options.MaxBackoff)
+	}
+	state.health.NextRestartAt = now.Add(state.backoff)
+	log.Printf("映像ソース %s を %s 後に再起動します", id, state.backoff)
+}
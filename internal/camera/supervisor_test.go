@@ -0,0 +1,224 @@
+package camera
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeVideoSource はテスト用の偽の映像ソース
+type fakeVideoSource struct {
+	BaseVideoSource
+	startErr   error
+	startCount int
+	stopCount  int
+}
+
+func newFakeVideoSource(id string) *fakeVideoSource {
+	return &fakeVideoSource{
+		BaseVideoSource: BaseVideoSource{
+			info:        VideoSourceInfo{ID: id, Name: id},
+			broadcaster: NewFrameBroadcaster(),
+			errorChan:   make(chan error, 5),
+			status:      StatusActive,
+		},
+	}
+}
+
+func (s *fakeVideoSource) Start(_ context.Context) error {
+	s.startCount++
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.setStatus(StatusActive)
+	return nil
+}
+
+func (s *fakeVideoSource) Stop(_ context.Context) error {
+	s.stopCount++
+	s.setStatus(StatusInactive)
+	return nil
+}
+
+func (s *fakeVideoSource) setStatus(status Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *fakeVideoSource) IsAvailable(_ context.Context) bool { return true }
+
+func (s *fakeVideoSource) CaptureFrameForTimelapse(_ context.Context) ([]byte, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeVideoSource) ApplySettings(_ context.Context, _ VideoSettings) error { return nil }
+
+func testSupervisorOptions() SupervisorOptions {
+	return SupervisorOptions{
+		CheckInterval:  time.Second,
+		StallTimeout:   10 * time.Second,
+		InitialBackoff: time.Second,
+		MaxBackoff:     4 * time.Second,
+		StableDuration: time.Minute,
+	}
+}
+
+func newTestSupervisor(sources ...VideoSource) *Supervisor {
+	return NewSupervisor(func() []VideoSource { return sources }, testSupervisorOptions())
+}
+
+func TestSupervisor_RestartsAfterError(t *testing.T) {
+	source := newFakeVideoSource("cam")
+	supervisor := newTestSupervisor(source)
+	ctx := context.Background()
+	now := time.Now()
+
+	supervisor.Check(ctx, now)
+	source.errorChan <- errors.New("ffmpeg exited")
+	supervisor.Check(ctx, now.Add(time.Second))
+
+	if source.stopCount != 1 {
+		t.Errorf("Expected source to be stopped once, got %d", source.stopCount)
+	}
+	if source.GetStatus() != StatusError {
+		t.Errorf("Expected status error, got %s", source.GetStatus())
+	}
+	health, _ := supervisor.Health("cam")
+	if health.LastError != "ffmpeg exited" {
+		t.Errorf("Expected last error to be recorded, got %q", health.LastError)
+	}
+	if want := now.Add(2 * time.Second); !health.NextRestartAt.Equal(want) {
+		t.Errorf("Expected next restart at %v, got %v", want, health.NextRestartAt)
+	}
+
+	// 待機時間が経過するまでは再起動しない
+	supervisor.Check(ctx, now.Add(1500*time.Millisecond))
+	if source.startCount != 0 {
+		t.Fatalf("Expected no restart before backoff, got %d", source.startCount)
+	}
+
+	supervisor.Check(ctx, now.Add(2*time.Second))
+	if source.startCount != 1 || source.GetStatus() != StatusActive {
+		t.Fatalf("Expected source to be restarted, starts=%d status=%s", source.startCount, source.GetStatus())
+	}
+	health, _ = supervisor.Health("cam")
+	if health.RestartCount != 1 || !health.NextRestartAt.IsZero() {
+		t.Errorf("Unexpected health after restart: %+v", health)
+	}
+}
+
+func TestSupervisor_DetectsStall(t *testing.T) {
+	source := newFakeVideoSource("cam")
+	supervisor := newTestSupervisor(source)
+	ctx := context.Background()
+	now := time.Now()
+
+	supervisor.Check(ctx, now)
+	supervisor.Check(ctx, now.Add(5*time.Second))
+	if source.stopCount != 0 {
+		t.Fatal("Expected no stall before timeout")
+	}
+
+	supervisor.Check(ctx, now.Add(11*time.Second))
+	if source.stopCount != 1 || source.GetStatus() != StatusError {
+		t.Fatalf("Expected stalled source to be stopped, stops=%d status=%s", source.stopCount, source.GetStatus())
+	}
+	health, _ := supervisor.Health("cam")
+	if health.LastError == "" {
+		t.Error("Expected stall to be recorded as last error")
+	}
+}
+
+func TestSupervisor_FramesPreventStall(t *testing.T) {
+	source := newFakeVideoSource("cam")
+	supervisor := newTestSupervisor(source)
+	ctx := context.Background()
+	now := time.Now()
+
+	supervisor.Check(ctx, now.Add(-time.Minute))
+	source.broadcaster.Publish([]byte{0xff, 0xd8})
+	supervisor.Check(ctx, now.Add(5*time.Second))
+
+	if source.stopCount != 0 {
+		t.Error("Expected source with recent frames not to be stopped")
+	}
+}
+
+func TestSupervisor_BackoffDoubles(t *testing.T) {
+	source := newFakeVideoSource("cam")
+	source.startErr = errors.New("device busy")
+	supervisor := newTestSupervisor(source)
+	ctx := context.Background()
+	now := time.Now()
+
+	supervisor.Check(ctx, now)
+	source.errorChan <- errors.New("device lost")
+	supervisor.Check(ctx, now)
+
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 4 * time.Second}
+	for i, backoff := range expected {
+		health, _ := supervisor.Health("cam")
+		now = health.NextRestartAt
+		supervisor.Check(ctx, now)
+
+		health, _ = supervisor.Health("cam")
+		if got := health.NextRestartAt.Sub(now); got != backoff {
+			t.Errorf("Restart %d: expected backoff %v, got %v", i+1, backoff, got)
+		}
+		if health.RestartCount != i+1 {
+			t.Errorf("Restart %d: expected restart count %d, got %d", i+1, i+1, health.RestartCount)
+		}
+		if health.LastError != "device busy" {
+			t.Errorf("Restart %d: expected start error to be recorded, got %q", i+1, health.LastError)
+		}
+	}
+}
+
+func TestSupervisor_IgnoresStoppedSource(t *testing.T) {
+	source := newFakeVideoSource("cam")
+	source.setStatus(StatusInactive)
+	supervisor := newTestSupervisor(source)
+	ctx := context.Background()
+	now := time.Now()
+
+	supervisor.Check(ctx, now)
+	supervisor.Check(ctx, now.Add(time.Hour))
+
+	if source.startCount != 0 || source.stopCount != 0 {
+		t.Errorf("Expected stopped source to be left alone, starts=%d stops=%d", source.startCount, source.stopCount)
+	}
+	if _, exists := supervisor.Health("cam"); !exists {
+		t.Error("Expected health to be reported for stopped source")
+	}
+}
+
+func TestSupervisor_ForgetsRemovedSource(t *testing.T) {
+	source := newFakeVideoSource("cam")
+	sources := []VideoSource{source}
+	supervisor := NewSupervisor(func() []VideoSource { return sources }, testSupervisorOptions())
+
+	supervisor.Check(context.Background(), time.Now())
+	sources = nil
+	supervisor.Check(context.Background(), time.Now())
+
+	if _, exists := supervisor.Health("cam"); exists {
+		t.Error("Expected health of removed source to be discarded")
+	}
+}
+
+func TestStderrTail(t *testing.T) {
+	tail := &stderrTail{}
+	for i := 0; i < 100; i++ {
+		_, _ = tail.Write([]byte("frame= 100 fps=30\n"))
+	}
+	_, _ = tail.Write([]byte("/dev/video0: No such device\n\n"))
+
+	if got := tail.LastLine(); got != "/dev/video0: No such device" {
+		t.Errorf("Expected last stderr line, got %q", got)
+	}
+	if len(tail.data) > maxStderrTail {
+		t.Errorf("Expected tail to be bounded to %d bytes, got %d", maxStderrTail, len(tail.data))
+	}
+}
@@ -2,6 +2,7 @@ package camera
 
 import (
 	"context"
+	"errors"
 )
 
 // Status はカメラの動作状態を表す
@@ -37,8 +38,41 @@ type Manager interface {
 
 	// RemoveVideoSource はVideoSourceを削除する
 	RemoveVideoSource(ctx context.Context, id string) error
+
+	// StartVideoSource は指定されたIDのVideoSourceを開始する
+	StartVideoSource(id string) error
+
+	// StopVideoSource は指定されたIDのVideoSourceを停止する
+	StopVideoSource(ctx context.Context, id string) error
+
+	// UpdateVideoSourceSettings は指定された値（ゼロ値以外）で映像設定を更新し、適用後の設定を返す
+	UpdateVideoSourceSettings(id string, settings VideoSettings) (VideoSettings, error)
+
+	// GetSupportedSourceTypes は作成可能なソースタイプ一覧を返す
+	GetSupportedSourceTypes() []VideoSourceType
+
+	// GetSourceHealth は映像ソースの監視状態（自動再起動の回数・最後のエラー）を返す
+	GetSourceHealth(id string) (SourceHealth, bool)
 }
 
+// Manager が返すエラー
+var (
+	// ErrVideoSourceNotFound は指定されたVideoSourceが存在しない場合のエラー
+	ErrVideoSourceNotFound = errors.New("VideoSourceが見つかりません")
+
+	// ErrVideoSourceExists はIDまたはデバイスが既に登録されている場合のエラー
+	ErrVideoSourceExists = errors.New("VideoSourceは既に登録されています")
+
+	// ErrDeviceUnavailable はデバイスが利用できない場合のエラー
+	ErrDeviceUnavailable = errors.New("デバイスが利用できません")
+
+	// ErrUnsupportedSourceType は登録されていないソースタイプが指定された場合のエラー
+	ErrUnsupportedSourceType = errors.New("サポートされていないソースタイプ")
+
+	// ErrInvalidSettings は映像設定がソースの能力に合わない場合のエラー
+	ErrInvalidSettings = errors.New("映像設定が不正です")
+)
+
 // Discovery はカメラデバイスの検出機能を提供する
 type Discovery interface {
 	// ScanDevices はシステム内の利用可能なカメラデバイスをスキャンする
@@ -53,11 +87,28 @@ type Discovery interface {
 
 // DeviceInfo はカメラデバイスの詳細情報を表す
 type DeviceInfo struct {
-	Device      string       // デバイスパス
-	Name        string       // デバイス名
-	Driver      string       // ドライバー名
-	Resolutions []Resolution // サポートされる解像度
-	Formats     []string     // サポートされるフォーマット
+	Device       string       // デバイスパス
+	Name         string       // デバイス名
+	Driver       string       // ドライバー名
+	BusInfo      string       // バス上の位置（同じ物理デバイスの判定に使用）
+	Resolutions  []Resolution // サポートされる解像度（全フォーマットの和集合）
+	Formats      []string     // サポートされるフォーマット
+	PixelFormats []FormatInfo // フォーマット毎の対応解像度・フレームレート
+}
+
+// FormatInfo はピクセルフォーマット毎の対応モードを表す
+type FormatInfo struct {
+	Format      string      // フォーマット名（MJPEG、YUYV等）
+	FourCC      string      // V4L2のピクセルフォーマット（MJPG、YUYV等）
+	Description string      // ドライバーによる説明
+	Compressed  bool        // 圧縮フォーマットか
+	Sizes       []FrameSize // 対応解像度
+}
+
+// FrameSize は解像度毎の対応フレームレートを表す
+type FrameSize struct {
+	Resolution
+	FrameRates []int // 対応フレームレート（fps、昇順）
 }
 
 // Resolution はカメラの解像度を表す
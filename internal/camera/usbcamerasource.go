@@ -11,37 +11,33 @@ import (
 type USBCameraSource struct {
 	BaseVideoSource
 
-	// V4L2キャプチャ用
-	capturer *V4L2Capturer
+	// V4L2キャプチャ用（ffmpeg またはネイティブ）
+	backend  CaptureBackend
+	capturer StreamCapturer
 
 	// 制御用
-	stopCh chan struct{}
-	wg     sync.WaitGroup
+	stopCh       chan struct{}
+	cancelStream context.CancelFunc
+	wg           sync.WaitGroup
 
 	// ストリーミング用の内部チャンネル
 	internalFrameChan chan []byte
 	internalErrorChan chan error
-
-	// 最新フレーム保持用（タイムラプス用）
-	latestFrame []byte
-	latestMutex sync.RWMutex
 }
 
 // NewDirectUSBCameraSource は新しいUSBCameraSourceを作成する（Service不使用）
-func NewDirectUSBCameraSource(info VideoSourceInfo, capabilities VideoCapabilities, settings VideoSettings) VideoSource {
-	// V4L2Capturerを直接作成
-	capturer := NewV4L2Capturer(info.Device, settings.Width, settings.Height, settings.FrameRate)
-
+func NewDirectUSBCameraSource(info VideoSourceInfo, capabilities VideoCapabilities, settings VideoSettings, backend CaptureBackend) VideoSource {
 	source := &USBCameraSource{
 		BaseVideoSource: BaseVideoSource{
 			info:         info,
 			capabilities: capabilities,
 			settings:     settings,
-			frameChan:    make(chan []byte, 10),
+			broadcaster:  NewFrameBroadcaster(),
 			errorChan:    make(chan error, 5),
 			status:       StatusInactive,
 		},
-		capturer:          capturer,
+		backend:           backend,
+		capturer:          newUSBCapturer(backend, info.Device, capabilities, settings),
 		stopCh:            make(chan struct{}),
 		internalFrameChan: make(chan []byte, 10),
 		internalErrorChan: make(chan error, 5),
@@ -65,14 +61,9 @@ func (s *USBCameraSource) Start(ctx context.Context) error {
 		return fmt.Errorf("カメラのテストキャプチャに失敗: %w", err)
 	}
 
-	// ストリーミングを開始
-	go s.capturer.StartStream(ctx, s.internalFrameChan, s.internalErrorChan)
-
-	// フレーム転送ゴルーチンを開始
-	s.wg.Add(1)
-	go s.forwardFrames()
-
+	s.startStreaming(ctx)
 	s.status = StatusActive
+	s.startedAt = time.Now()
 	return nil
 }
 
@@ -85,17 +76,58 @@ func (s *USBCameraSource) Stop(_ context.Context) error {
 		return nil // 既に停止済み
 	}
 
+	s.stopStreaming()
+
+	// 購読者を切断し、古いフレームを破棄
+	s.broadcaster.Reset()
+
+	s.status = StatusInactive
+	s.startedAt = time.Time{}
+	return nil
+}
+
+// startStreaming はキャプチャとフレーム転送のゴルーチンを開始する（ロック保持中に呼び出す）
+// キャプチャは停止時にキャンセルするため、呼び出し元のコンテキストから派生させる
+func (s *USBCameraSource) startStreaming(ctx context.Context) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	s.cancelStream = cancel
+
+	// ストリーミングを開始
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.capturer.StartStream(streamCtx, s.internalFrameChan, s.internalErrorChan)
+	}()
+
+	// フレーム転送ゴルーチンを開始
+	s.wg.Add(1)
+	go s.forwardFrames()
+}
+
+// stopStreaming はキャプチャとフレーム転送を停止し、終了を待機する（ロック保持中に呼び出す）
+func (s *USBCameraSource) stopStreaming() {
 	// 停止シグナルを送信
+	if s.cancelStream != nil {
+		s.cancelStream()
+		s.cancelStream = nil
+	}
 	close(s.stopCh)
 
-	// ゴルーチンの終了を待機
+	// ゴルーチンの終了を待機（ネイティブキャプチャはデバイスを閉じるまで待つ）
 	s.wg.Wait()
 
 	// 新しいstopChを作成（再開可能にするため）
 	s.stopCh = make(chan struct{})
+}
 
-	s.status = StatusInactive
-	return nil
+// GetStats は配信統計とキャプチャのコマンドラインを返す
+func (s *USBCameraSource) GetStats() SourceStats {
+	stats := s.BaseVideoSource.GetStats()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stats.CaptureCommand = captureCommandLine(s.capturer)
+	return stats
 }
 
 // IsAvailable はカメラが利用可能かチェックする
@@ -108,25 +140,20 @@ func (s *USBCameraSource) ApplySettings(ctx context.Context, settings VideoSetti
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// アクティブな場合は停止してから再開始する（デバイスは同時に1つしか開けない）
+	active := s.status == StatusActive
+	if active {
+		s.stopStreaming()
+	}
+
 	// 新しい設定でキャプチャを再作成
-	s.capturer = NewV4L2Capturer(s.info.Device, settings.Width, settings.Height, settings.FrameRate)
+	s.capturer = newUSBCapturer(s.backend, s.info.Device, s.capabilities, settings)
 
 	// 内部設定を更新
 	s.settings = settings
 
-	// アクティブな場合は再開始が必要
-	if s.status == StatusActive {
-		// 停止してから再開始
-		s.status = StatusInactive
-		close(s.stopCh)
-		s.wg.Wait()
-		s.stopCh = make(chan struct{})
-
-		// 再開始
-		go s.capturer.StartStream(ctx, s.internalFrameChan, s.internalErrorChan)
-		s.wg.Add(1)
-		go s.forwardFrames()
-		s.status = StatusActive
+	if active {
+		s.startStreaming(ctx)
 	}
 
 	return nil
@@ -147,29 +174,8 @@ func (s *USBCameraSource) forwardFrames() {
 				return
 			}
 
-			// 最新フレームを保存（タイムラプス用）
-			s.latestMutex.Lock()
-			s.latestFrame = make([]byte, len(frame))
-			copy(s.latestFrame, frame)
-			s.latestMutex.Unlock()
-
-			// フレームを転送
-			select {
-			case s.frameChan <- frame:
-			case <-s.stopCh:
-				return
-			default:
-				// チャンネルがフルの場合は古いフレームを破棄
-				select {
-				case <-s.frameChan:
-				default:
-				}
-				select {
-				case s.frameChan <- frame:
-				case <-s.stopCh:
-					return
-				}
-			}
+			// 全購読者へ配信（最新フレームも保持される）
+			s.broadcaster.Publish(frame)
 
 		case err, ok := <-s.internalErrorChan:
 			if !ok {
@@ -216,15 +222,13 @@ func (s *USBCameraSource) CaptureFrameForTimelapse(_ context.Context) ([]byte, e
 	}
 
 	// ストリーミング中の最新フレームを取得
-	s.latestMutex.RLock()
-	defer s.latestMutex.RUnlock()
-
-	if s.latestFrame == nil {
+	latest, ok := s.broadcaster.Latest()
+	if !ok {
 		return nil, fmt.Errorf("フレームがまだ取得されていません")
 	}
 
 	// フレームのコピーを返す
-	frame := make([]byte, len(s.latestFrame))
-	copy(frame, s.latestFrame)
+	frame := make([]byte, len(latest.Data))
+	copy(frame, latest.Data)
 	return frame, nil
 }
@@ -0,0 +1,255 @@
+//go:build linux
+
+package camera
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// V4L2のioctlリクエスト番号（linux/videodev2.h）
+const (
+	vidiocQueryCap            = 0x80685600 // _IOR('V', 0, struct v4l2_capability)
+	vidiocEnumFmt             = 0xc0405602 // _IOWR('V', 2, struct v4l2_fmtdesc)
+	vidiocEnumFrameSizes      = 0xc02c564a // _IOWR('V', 74, struct v4l2_frmsizeenum)
+	vidiocEnumFrameIntervals  = 0xc034564b // _IOWR('V', 75, struct v4l2_frmivalenum)
+	v4l2BufTypeVideoCapture   = 1
+	v4l2CapVideoCapture       = 0x00000001
+	v4l2CapDeviceCaps         = 0x80000000
+	v4l2FmtFlagCompressed     = 0x0001
+	v4l2FrmSizeTypeDiscrete   = 1
+	v4l2FrmIvalTypeDiscrete   = 1
+	v4l2MaxEnumerationEntries = 256 // ドライバーの不具合で列挙が終わらない場合の上限
+)
+
+// v4l2Capability は struct v4l2_capability に対応する
+type v4l2Capability struct {
+	driver       [16]byte
+	card         [32]byte
+	busInfo      [32]byte
+	version      uint32
+	capabilities uint32
+	deviceCaps   uint32
+	reserved     [3]uint32
+}
+
+// v4l2FmtDesc は struct v4l2_fmtdesc に対応する
+type v4l2FmtDesc struct {
+	index       uint32
+	bufType     uint32
+	flags       uint32
+	description [32]byte
+	pixelFormat uint32
+	mbusCode    uint32
+	reserved    [3]uint32
+}
+
+// v4l2FrmSizeEnum は struct v4l2_frmsizeenum に対応する
+// union は discrete（width, height）と stepwise（min/max/step）を同じ領域で表す
+type v4l2FrmSizeEnum struct {
+	index       uint32
+	pixelFormat uint32
+	sizeType    uint32
+	union       [6]uint32
+	reserved    [2]uint32
+}
+
+// v4l2FrmIvalEnum は struct v4l2_frmivalenum に対応する
+// union は discrete（numerator, denominator）と stepwise（min/max/step の分数）を同じ領域で表す
+type v4l2FrmIvalEnum struct {
+	index       uint32
+	pixelFormat uint32
+	width       uint32
+	height      uint32
+	ivalType    uint32
+	union       [6]uint32
+	reserved    [2]uint32
+}
+
+// errNotCaptureDevice は映像キャプチャに対応していないV4L2デバイスを表す
+var errNotCaptureDevice = errors.New("映像キャプチャに対応していないデバイスです")
+
+// v4l2Ioctl はioctlを呼び出す（EINTRは再試行する）
+func v4l2Ioctl(fd uintptr, request uintptr, arg unsafe.Pointer) error {
+	for {
+		_, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, request, uintptr(arg))
+		if errno == unix.EINTR {
+			continue
+		}
+		if errno != 0 {
+			return errno
+		}
+		return nil
+	}
+}
+
+// queryV4L2Device はVIDIOC_QUERYCAPでデバイスの名前・ドライバーのみを取得する
+// 映像キャプチャに対応していないデバイス（メタデータ用ノード等）はエラーを返す
+func queryV4L2Device(device string) (*DeviceInfo, error) {
+	return inspectV4L2Device(device, false)
+}
+
+// probeV4L2Device はV4L2のioctlでデバイスの名前・ドライバー・対応フォーマットを取得する
+// 映像キャプチャに対応していないデバイス（メタデータ用ノード等）はエラーを返す
+func probeV4L2Device(device string) (*DeviceInfo, error) {
+	return inspectV4L2Device(device, true)
+}
+
+// inspectV4L2Device はデバイス情報を取得する。enumerate が true の場合は対応フォーマットも列挙する
+func inspectV4L2Device(device string, enumerate bool) (*DeviceInfo, error) {
+	file, err := os.OpenFile(device, os.O_RDWR|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("デバイスのオープンに失敗: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	fd := file.Fd()
+
+	var capability v4l2Capability
+	if err := v4l2Ioctl(fd, vidiocQueryCap, unsafe.Pointer(&capability)); err != nil {
+		return nil, fmt.Errorf("VIDIOC_QUERYCAPに失敗: %w", err)
+	}
+
+	caps := capability.capabilities
+	if caps&v4l2CapDeviceCaps != 0 {
+		caps = capability.deviceCaps
+	}
+	if caps&v4l2CapVideoCapture == 0 {
+		return nil, errNotCaptureDevice
+	}
+
+	info := &DeviceInfo{
+		Device:  device,
+		Name:    cString(capability.card[:]),
+		Driver:  cString(capability.driver[:]),
+		BusInfo: cString(capability.busInfo[:]),
+	}
+	if !enumerate {
+		return info, nil
+	}
+
+	formats, err := enumV4L2Formats(fd)
+	if err != nil {
+		return nil, err
+	}
+	info.PixelFormats = formats
+	info.Formats, info.Resolutions, _ = summarizeFormats(formats)
+	return info, nil
+}
+
+// enumV4L2Formats はキャプチャ用のピクセルフォーマットと解像度・フレームレートを列挙する
+func enumV4L2Formats(fd uintptr) ([]FormatInfo, error) {
+	var formats []FormatInfo
+
+	for index := uint32(0); index < v4l2MaxEnumerationEntries; index++ {
+		desc := v4l2FmtDesc{index: index, bufType: v4l2BufTypeVideoCapture}
+		if err := v4l2Ioctl(fd, vidiocEnumFmt, unsafe.Pointer(&desc)); err != nil {
+			if errors.Is(err, unix.EINVAL) {
+				break // 列挙の終端
+			}
+			return nil, fmt.Errorf("VIDIOC_ENUM_FMTに失敗: %w", err)
+		}
+
+		fourCC := fourCCString(desc.pixelFormat)
+		sizes, err := enumV4L2FrameSizes(fd, desc.pixelFormat)
+		if err != nil {
+			return nil, fmt.Errorf("フォーマット %s の解像度取得に失敗: %w", fourCC, err)
+		}
+
+		formats = append(formats, FormatInfo{
+			Format:      formatName(fourCC),
+			FourCC:      fourCC,
+			Description: cString(desc.description[:]),
+			Compressed:  desc.flags&v4l2FmtFlagCompressed != 0,
+			Sizes:       sizes,
+		})
+	}
+
+	return formats, nil
+}
+
+// enumV4L2FrameSizes はピクセルフォーマットの対応解像度を列挙する
+// 連続・ステップ指定の場合は範囲内の一般的な解像度を候補とする
+func enumV4L2FrameSizes(fd uintptr, pixelFormat uint32) ([]FrameSize, error) {
+	var resolutions []Resolution
+
+	for index := uint32(0); index < v4l2MaxEnumerationEntries; index++ {
+		size := v4l2FrmSizeEnum{index: index, pixelFormat: pixelFormat}
+		if err := v4l2Ioctl(fd, vidiocEnumFrameSizes, unsafe.Pointer(&size)); err != nil {
+			if errors.Is(err, unix.EINVAL) {
+				break
+			}
+			return nil, fmt.Errorf("VIDIOC_ENUM_FRAMESIZESに失敗: %w", err)
+		}
+
+		if size.sizeType == v4l2FrmSizeTypeDiscrete {
+			resolutions = append(resolutions, Resolution{Width: int(size.union[0]), Height: int(size.union[1])})
+			continue
+		}
+
+		// 連続・ステップ指定は1エントリのみ返される
+		resolutions = stepwiseResolutions(size.union)
+		break
+	}
+
+	sizes := make([]FrameSize, 0, len(resolutions))
+	for _, resolution := range resolutions {
+		rates, err := enumV4L2FrameRates(fd, pixelFormat, resolution)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, FrameSize{Resolution: resolution, FrameRates: rates})
+	}
+	return sizes, nil
+}
+
+// enumV4L2FrameRates は解像度毎の対応フレームレートを列挙する
+func enumV4L2FrameRates(fd uintptr, pixelFormat uint32, resolution Resolution) ([]int, error) {
+	var rates []int
+
+	for index := uint32(0); index < v4l2MaxEnumerationEntries; index++ {
+		interval := v4l2FrmIvalEnum{
+			index:       index,
+			pixelFormat: pixelFormat,
+			width:       uint32(resolution.Width),
+			height:      uint32(resolution.Height),
+		}
+		if err := v4l2Ioctl(fd, vidiocEnumFrameIntervals, unsafe.Pointer(&interval)); err != nil {
+			if errors.Is(err, unix.EINVAL) {
+				break
+			}
+			return nil, fmt.Errorf("VIDIOC_ENUM_FRAMEINTERVALSに失敗: %w", err)
+		}
+
+		if interval.ivalType == v4l2FrmIvalTypeDiscrete {
+			if fps := intervalToFPS(interval.union[0], interval.union[1]); fps > 0 {
+				rates = append(rates, fps)
+			}
+			continue
+		}
+
+		// 連続・ステップ指定は最小間隔（最大fps）と最大間隔（最小fps）の範囲で候補を選ぶ
+		rates = stepwiseFrameRates(
+			intervalToFPS(interval.union[2], interval.union[3]),
+			intervalToFPS(interval.union[0], interval.union[1]),
+		)
+		break
+	}
+
+	return normalizeFrameRates(rates), nil
+}
+
+// cString はNUL終端のバイト列を文字列に変換する
+func cString(data []byte) string {
+	for i, b := range data {
+		if b == 0 {
+			return string(data[:i])
+		}
+	}
+	return string(data)
+}
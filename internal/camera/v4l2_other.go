@@ -0,0 +1,23 @@
+//go:build !linux
+
+package camera
+
+import "errors"
+
+// errV4L2Unsupported はLinux以外でV4L2を利用しようとした場合のエラー
+var errV4L2Unsupported = errors.New("V4L2はLinuxでのみ利用できます")
+
+// queryV4L2Device はLinux以外では利用できない
+func queryV4L2Device(_ string) (*DeviceInfo, error) {
+	return nil, errV4L2Unsupported
+}
+
+// probeV4L2Device はLinux以外では利用できない
+func probeV4L2Device(_ string) (*DeviceInfo, error) {
+	return nil, errV4L2Unsupported
+}
+
+// openV4L2StreamDevice はLinux以外では利用できない
+func openV4L2StreamDevice(_ string) (v4l2StreamDevice, error) {
+	return nil, errV4L2Unsupported
+}
@@ -0,0 +1,69 @@
+//go:build linux
+
+package camera
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+func TestV4L2StructSizes(t *testing.T) {
+	// ioctlのリクエスト番号に埋め込まれた構造体サイズと一致する必要がある
+	testCases := []struct {
+		name     string
+		size     uintptr
+		expected uintptr
+	}{
+		{name: "v4l2_capability", size: unsafe.Sizeof(v4l2Capability{}), expected: (vidiocQueryCap >> 16) & 0x3fff},
+		{name: "v4l2_fmtdesc", size: unsafe.Sizeof(v4l2FmtDesc{}), expected: (vidiocEnumFmt >> 16) & 0x3fff},
+		{name: "v4l2_frmsizeenum", size: unsafe.Sizeof(v4l2FrmSizeEnum{}), expected: (vidiocEnumFrameSizes >> 16) & 0x3fff},
+		{name: "v4l2_frmivalenum", size: unsafe.Sizeof(v4l2FrmIvalEnum{}), expected: (vidiocEnumFrameIntervals >> 16) & 0x3fff},
+	}
+
+	for _, tc := range testCases {
+		if tc.size != tc.expected {
+			t.Errorf("Size of %s = %d, want %d", tc.name, tc.size, tc.expected)
+		}
+	}
+}
+
+func TestV4L2StreamIoctlNumbers(t *testing.T) {
+	// 64bitのLinuxで videodev2.h から得られる値
+	if runtime.GOARCH != "amd64" && runtime.GOARCH != "arm64" {
+		t.Skip("ioctl numbers below are for 64-bit architectures")
+	}
+
+	testCases := []struct {
+		name     string
+		request  uintptr
+		expected uintptr
+	}{
+		{name: "VIDIOC_S_FMT", request: vidiocSFmt, expected: 0xc0d05605},
+		{name: "VIDIOC_REQBUFS", request: vidiocReqBufs, expected: 0xc0145608},
+		{name: "VIDIOC_QUERYBUF", request: vidiocQueryBuf, expected: 0xc0585609},
+		{name: "VIDIOC_QBUF", request: vidiocQBuf, expected: 0xc058560f},
+		{name: "VIDIOC_DQBUF", request: vidiocDQBuf, expected: 0xc0585611},
+		{name: "VIDIOC_STREAMON", request: vidiocStreamOn, expected: 0x40045612},
+		{name: "VIDIOC_STREAMOFF", request: vidiocStreamOff, expected: 0x40045613},
+		{name: "VIDIOC_S_PARM", request: vidiocSParm, expected: 0xc0cc5616},
+	}
+
+	for _, tc := range testCases {
+		if tc.request != tc.expected {
+			t.Errorf("%s = %#x, want %#x", tc.name, tc.request, tc.expected)
+		}
+	}
+}
+
+func TestOpenV4L2StreamDevice_NotFound(t *testing.T) {
+	if _, err := openV4L2StreamDevice("/dev/video999"); err == nil {
+		t.Error("Expected error for non-existent device")
+	}
+}
+
+func TestProbeV4L2Device_NotFound(t *testing.T) {
+	if _, err := probeV4L2Device("/dev/video999"); err == nil {
+		t.Error("Expected error for non-existent device")
+	}
+}
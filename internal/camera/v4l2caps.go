@@ -0,0 +1,169 @@
+package camera
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+// 連続・ステップ指定のデバイスで候補とする一般的な解像度
+var commonResolutions = []Resolution{
+	{Width: 320, Height: 240},
+	{Width: 640, Height: 360},
+	{Width: 640, Height: 480},
+	{Width: 800, Height: 600},
+	{Width: 1024, Height: 768},
+	{Width: 1280, Height: 720},
+	{Width: 1280, Height: 960},
+	{Width: 1600, Height: 1200},
+	{Width: 1920, Height: 1080},
+	{Width: 2560, Height: 1440},
+	{Width: 3840, Height: 2160},
+}
+
+// 連続・ステップ指定のデバイスで候補とする一般的なフレームレート
+var commonFrameRates = []int{1, 2, 5, 10, 15, 20, 24, 25, 30, 50, 60}
+
+// defaultUSBCapabilities はデバイスから能力を取得できない場合の想定値
+func defaultUSBCapabilities() VideoCapabilities {
+	return VideoCapabilities{
+		SupportedResolutions: []Resolution{
+			{Width: 640, Height: 480},
+			{Width: 1280, Height: 720},
+			{Width: 1920, Height: 1080},
+		},
+		SupportedFrameRates: []int{5, 10, 15, 30},
+		SupportedFormats:    []string{"MJPEG", "YUYV"},
+	}
+}
+
+// capabilitiesFromDeviceInfo はデバイス情報から映像ソースの能力を作成する
+func capabilitiesFromDeviceInfo(info *DeviceInfo) VideoCapabilities {
+	formats, resolutions, frameRates := summarizeFormats(info.PixelFormats)
+	return VideoCapabilities{
+		SupportedResolutions: resolutions,
+		SupportedFrameRates:  frameRates,
+		SupportedFormats:     formats,
+		Formats:              info.PixelFormats,
+	}
+}
+
+// summarizeFormats はフォーマット毎の対応モードからフォーマット名・解像度・フレームレートの一覧を作成する
+// 解像度は面積の昇順、フレームレートは昇順に並べる
+func summarizeFormats(formats []FormatInfo) ([]string, []Resolution, []int) {
+	var names []string
+	var resolutions []Resolution
+	var frameRates []int
+
+	for _, format := range formats {
+		if !slices.Contains(names, format.Format) {
+			names = append(names, format.Format)
+		}
+		for _, size := range format.Sizes {
+			if !slices.Contains(resolutions, size.Resolution) {
+				resolutions = append(resolutions, size.Resolution)
+			}
+			frameRates = append(frameRates, size.FrameRates...)
+		}
+	}
+
+	slices.SortFunc(resolutions, func(a, b Resolution) int {
+		if d := a.Width*a.Height - b.Width*b.Height; d != 0 {
+			return d
+		}
+		return a.Width - b.Width
+	})
+	return names, resolutions, normalizeFrameRates(frameRates)
+}
+
+// fourCCString はV4L2のピクセルフォーマット値をFourCC文字列に変換する
+func fourCCString(pixelFormat uint32) string {
+	code := []byte{
+		byte(pixelFormat),
+		byte(pixelFormat >> 8),
+		byte(pixelFormat >> 16),
+		byte(pixelFormat >> 24),
+	}
+	return strings.TrimRight(string(code), " \x00")
+}
+
+// fourCCCode はFourCC文字列をV4L2のピクセルフォーマット値に変換する
+func fourCCCode(fourCC string) uint32 {
+	var code [4]byte
+	copy(code[:], fourCC+"    ")
+	return uint32(code[0]) | uint32(code[1])<<8 | uint32(code[2])<<16 | uint32(code[3])<<24
+}
+
+// formatFourCC は設定で使用するフォーマット名をFourCCに変換する（formatName の逆変換）
+func formatFourCC(format string) string {
+	switch strings.ToUpper(format) {
+	case "MJPEG":
+		return "MJPG"
+	default:
+		return strings.ToUpper(format)
+	}
+}
+
+// formatName はFourCCを設定で使用するフォーマット名に変換する
+// MJPEGは設定・ffmpegで使われる名前に揃え、それ以外はFourCCのまま使用する
+func formatName(fourCC string) string {
+	switch fourCC {
+	case "MJPG":
+		return "MJPEG"
+	default:
+		return fourCC
+	}
+}
+
+// intervalToFPS はフレーム間隔（秒の分数）をフレームレートに変換する
+func intervalToFPS(numerator, denominator uint32) int {
+	if numerator == 0 || denominator == 0 {
+		return 0
+	}
+	return int(math.Round(float64(denominator) / float64(numerator)))
+}
+
+// stepwiseResolutions は連続・ステップ指定の範囲に含まれる一般的な解像度と最大解像度を返す
+// values は min_width, max_width, step_width, min_height, max_height, step_height の順
+func stepwiseResolutions(values [6]uint32) []Resolution {
+	minW, maxW, stepW := int(values[0]), int(values[1]), max(int(values[2]), 1)
+	minH, maxH, stepH := int(values[3]), int(values[4]), max(int(values[5]), 1)
+
+	var resolutions []Resolution
+	for _, r := range commonResolutions {
+		if r.Width < minW || r.Width > maxW || r.Height < minH || r.Height > maxH {
+			continue
+		}
+		if (r.Width-minW)%stepW != 0 || (r.Height-minH)%stepH != 0 {
+			continue
+		}
+		resolutions = append(resolutions, r)
+	}
+
+	largest := Resolution{Width: maxW, Height: maxH}
+	if maxW > 0 && maxH > 0 && !slices.Contains(resolutions, largest) {
+		resolutions = append(resolutions, largest)
+	}
+	return resolutions
+}
+
+// stepwiseFrameRates は範囲に含まれる一般的なフレームレートと最大フレームレートを返す
+func stepwiseFrameRates(minFPS, maxFPS int) []int {
+	var rates []int
+	for _, fps := range commonFrameRates {
+		if fps >= minFPS && fps <= maxFPS {
+			rates = append(rates, fps)
+		}
+	}
+	if maxFPS > 0 {
+		rates = append(rates, maxFPS)
+	}
+	return rates
+}
+
+// normalizeFrameRates はフレームレートを昇順に並べて重複を除く
+func normalizeFrameRates(rates []int) []int {
+	rates = slices.Clone(rates)
+	slices.Sort(rates)
+	return slices.Compact(rates)
+}
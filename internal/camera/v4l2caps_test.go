@@ -0,0 +1,120 @@
+package camera
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// testPixelFormats はMJPEGで高フレームレート、YUYVで低フレームレートに対応する一般的なUVCカメラを表す
+var testPixelFormats = []FormatInfo{
+	{
+		Format: "MJPEG",
+		FourCC: "MJPG",
+		Sizes: []FrameSize{
+			{Resolution: Resolution{Width: 1920, Height: 1080}, FrameRates: []int{15, 30}},
+			{Resolution: Resolution{Width: 640, Height: 480}, FrameRates: []int{15, 30}},
+		},
+	},
+	{
+		Format: "YUYV",
+		FourCC: "YUYV",
+		Sizes: []FrameSize{
+			{Resolution: Resolution{Width: 1920, Height: 1080}, FrameRates: []int{5}},
+			{Resolution: Resolution{Width: 640, Height: 480}, FrameRates: []int{30}},
+		},
+	},
+}
+
+func TestFourCCString(t *testing.T) {
+	// V4L2_PIX_FMT_MJPEG = v4l2_fourcc('M', 'J', 'P', 'G')
+	if got := fourCCString(0x47504a4d); got != "MJPG" {
+		t.Errorf("Expected MJPG, got %s", got)
+	}
+	if got := formatName("MJPG"); got != "MJPEG" {
+		t.Errorf("Expected MJPG to be named MJPEG, got %s", got)
+	}
+	if got := formatName("YUYV"); got != "YUYV" {
+		t.Errorf("Expected YUYV to keep its name, got %s", got)
+	}
+	if got := fourCCCode(formatFourCC("MJPEG")); got != 0x47504a4d {
+		t.Errorf("Expected MJPEG to map to %#x, got %#x", 0x47504a4d, got)
+	}
+}
+
+func TestIntervalToFPS(t *testing.T) {
+	testCases := []struct {
+		numerator, denominator uint32
+		expected               int
+	}{
+		{numerator: 1, denominator: 30, expected: 30},
+		{numerator: 333333, denominator: 10000000, expected: 30},
+		{numerator: 2, denominator: 15, expected: 8},
+		{numerator: 0, denominator: 30, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		if got := intervalToFPS(tc.numerator, tc.denominator); got != tc.expected {
+			t.Errorf("intervalToFPS(%d, %d) = %d, want %d", tc.numerator, tc.denominator, got, tc.expected)
+		}
+	}
+}
+
+func TestStepwiseResolutions(t *testing.T) {
+	// 幅 320〜1280（16刻み）、高さ 240〜720（8刻み）
+	resolutions := stepwiseResolutions([6]uint32{320, 1280, 16, 240, 720, 8})
+
+	expected := []Resolution{
+		{Width: 320, Height: 240},
+		{Width: 640, Height: 360},
+		{Width: 640, Height: 480},
+		{Width: 800, Height: 600},
+		{Width: 1280, Height: 720},
+	}
+	if !slices.Equal(resolutions, expected) {
+		t.Errorf("Expected %v, got %v", expected, resolutions)
+	}
+
+	if rates := stepwiseFrameRates(5, 30); !slices.Equal(rates, []int{5, 10, 15, 20, 24, 25, 30, 30}) {
+		t.Errorf("Unexpected stepwise frame rates: %v", rates)
+	}
+}
+
+func TestCapabilitiesFromDeviceInfo(t *testing.T) {
+	capabilities := capabilitiesFromDeviceInfo(&DeviceInfo{PixelFormats: testPixelFormats})
+
+	if !slices.Equal(capabilities.SupportedFormats, []string{"MJPEG", "YUYV"}) {
+		t.Errorf("Unexpected formats: %v", capabilities.SupportedFormats)
+	}
+	expectedResolutions := []Resolution{{Width: 640, Height: 480}, {Width: 1920, Height: 1080}}
+	if !slices.Equal(capabilities.SupportedResolutions, expectedResolutions) {
+		t.Errorf("Unexpected resolutions: %v", capabilities.SupportedResolutions)
+	}
+	if !slices.Equal(capabilities.SupportedFrameRates, []int{5, 15, 30}) {
+		t.Errorf("Unexpected frame rates: %v", capabilities.SupportedFrameRates)
+	}
+}
+
+func TestValidateSettings_PerFormat(t *testing.T) {
+	capabilities := capabilitiesFromDeviceInfo(&DeviceInfo{PixelFormats: testPixelFormats})
+
+	// MJPEGでは1080p@30に対応
+	if err := ValidateSettings(capabilities, VideoSettings{Width: 1920, Height: 1080, FrameRate: 30, Format: "MJPEG", Quality: 3}); err != nil {
+		t.Errorf("Expected 1080p@30 MJPEG to be valid, got %v", err)
+	}
+
+	// YUYVでは1080pは5fpsのみ
+	err := ValidateSettings(capabilities, VideoSettings{Width: 1920, Height: 1080, FrameRate: 30, Format: "YUYV", Quality: 3})
+	if !errors.Is(err, ErrInvalidSettings) {
+		t.Errorf("Expected 1080p@30 YUYV to be invalid, got %v", err)
+	}
+
+	// 補正後の設定は対応可能な組み合わせになる
+	closest := closestSupportedSettings(capabilities, VideoSettings{Width: 1280, Height: 720, FrameRate: 15, Format: "YUYV", Quality: 3})
+	if closest.Width != 640 || closest.Height != 480 || closest.FrameRate != 30 {
+		t.Errorf("Expected 640x480@30, got %dx%d@%d", closest.Width, closest.Height, closest.FrameRate)
+	}
+	if err := ValidateSettings(capabilities, closest); err != nil {
+		t.Errorf("Expected closest settings to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+//go:build linux
+
+package camera
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// mmapストリーミング用のioctlリクエスト番号（linux/videodev2.h）
+// v4l2_format・v4l2_buffer はポインタを含みアーキテクチャでサイズが異なるため、構造体のサイズから求める
+var (
+	vidiocSFmt      = v4l2IOWR(5, unsafe.Sizeof(v4l2Format{}))
+	vidiocReqBufs   = v4l2IOWR(8, unsafe.Sizeof(v4l2RequestBuffers{}))
+	vidiocQueryBuf  = v4l2IOWR(9, unsafe.Sizeof(v4l2Buffer{}))
+	vidiocQBuf      = v4l2IOWR(15, unsafe.Sizeof(v4l2Buffer{}))
+	vidiocDQBuf     = v4l2IOWR(17, unsafe.Sizeof(v4l2Buffer{}))
+	vidiocStreamOn  = v4l2IOW(18, unsafe.Sizeof(int32(0)))
+	vidiocStreamOff = v4l2IOW(19, unsafe.Sizeof(int32(0)))
+	vidiocSParm     = v4l2IOWR(22, unsafe.Sizeof(v4l2StreamParm{}))
+)
+
+const (
+	v4l2FieldNone      = 1
+	v4l2MemoryMmap     = 1
+	v4l2BufFlagError   = 0x00000040
+	v4l2MinBufferCount = 2 // ストリーミングに必要な最小バッファ数
+)
+
+// v4l2IOWR は _IOWR('V', nr, size) のリクエスト番号を返す
+func v4l2IOWR(nr, size uintptr) uintptr {
+	return 3<<30 | size<<16 | 'V'<<8 | nr
+}
+
+// v4l2IOW は _IOW('V', nr, size) のリクエスト番号を返す
+func v4l2IOW(nr, size uintptr) uintptr {
+	return 1<<30 | size<<16 | 'V'<<8 | nr
+}
+
+// v4l2Format は struct v4l2_format に対応する
+// union はポインタを含むため、64bit環境では8バイト境界に配置される
+type v4l2Format struct {
+	bufType uint32
+	fmt     [25]uint64
+}
+
+// pix は union を struct v4l2_pix_format として参照する
+func (f *v4l2Format) pix() *v4l2PixFormat {
+	return (*v4l2PixFormat)(unsafe.Pointer(&f.fmt[0]))
+}
+
+// v4l2PixFormat は struct v4l2_pix_format に対応する
+type v4l2PixFormat struct {
+	width        uint32
+	height       uint32
+	pixelFormat  uint32
+	field        uint32
+	bytesPerLine uint32
+	sizeImage    uint32
+	colorspace   uint32
+	priv         uint32
+	flags        uint32
+	ycbcrEnc     uint32
+	quantization uint32
+	xferFunc     uint32
+}
+
+// v4l2RequestBuffers は struct v4l2_requestbuffers に対応する
+type v4l2RequestBuffers struct {
+	count        uint32
+	bufType      uint32
+	memory       uint32
+	capabilities uint32
+	reserved     uint32 // flags と予約領域
+}
+
+// v4l2Timecode は struct v4l2_timecode に対応する
+type v4l2Timecode struct {
+	tcType   uint32
+	flags    uint32
+	frames   uint8
+	seconds  uint8
+	minutes  uint8
+	hours    uint8
+	userBits [4]uint8
+}
+
+// v4l2Buffer は struct v4l2_buffer に対応する
+type v4l2Buffer struct {
+	index     uint32
+	bufType   uint32
+	bytesUsed uint32
+	flags     uint32
+	field     uint32
+	timestamp unix.Timeval
+	timecode  v4l2Timecode
+	sequence  uint32
+	memory    uint32
+	m         uintptr // union（mmapの場合は下位32bitがオフセット）
+	length    uint32
+	reserved2 uint32
+	requestFD int32
+}
+
+// v4l2StreamParm は struct v4l2_streamparm（キャプチャ用）に対応する
+type v4l2StreamParm struct {
+	bufType      uint32
+	capability   uint32
+	captureMode  uint32
+	numerator    uint32 // timeperframe（1フレームの秒数の分数）
+	denominator  uint32
+	extendedMode uint32
+	readBuffers  uint32
+	reserved     [4]uint32
+	padding      [160]byte // union の残り
+}
+
+// v4l2FileDevice はデバイスファイルに対する v4l2StreamDevice の実装
+type v4l2FileDevice struct {
+	fd        int
+	buffers   [][]byte
+	streaming bool
+}
+
+// openV4L2StreamDevice はストリーミング用にV4L2デバイスを開く
+func openV4L2StreamDevice(devicePath string) (v4l2StreamDevice, error) {
+	fd, err := unix.Open(devicePath, unix.O_RDWR|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &v4l2FileDevice{fd: fd}, nil
+}
+
+// SetFormat はキャプチャフォーマットを設定する
+func (d *v4l2FileDevice) SetFormat(format string, width, height int) (v4l2StreamFormat, error) {
+	f := v4l2Format{bufType: v4l2BufTypeVideoCapture}
+	pix := f.pix()
+	pix.width = uint32(width)
+	pix.height = uint32(height)
+	pix.pixelFormat = fourCCCode(formatFourCC(format))
+	pix.field = v4l2FieldNone
+
+	if err := v4l2Ioctl(uintptr(d.fd), vidiocSFmt, unsafe.Pointer(&f)); err != nil {
+		return v4l2StreamFormat{}, fmt.Errorf("VIDIOC_S_FMTに失敗: %w", err)
+	}
+
+	return v4l2StreamFormat{
+		Format:       formatName(fourCCString(pix.pixelFormat)),
+		Width:        int(pix.width),
+		Height:       int(pix.height),
+		BytesPerLine: int(pix.bytesPerLine),
+	}, nil
+}
+
+// SetFrameRate はフレーム間隔を設定する
+func (d *v4l2FileDevice) SetFrameRate(fps int) error {
+	parm := v4l2StreamParm{bufType: v4l2BufTypeVideoCapture, numerator: 1, denominator: uint32(fps)}
+	if err := v4l2Ioctl(uintptr(d.fd), vidiocSParm, unsafe.Pointer(&parm)); err != nil {
+		return fmt.Errorf("VIDIOC_S_PARMに失敗: %w", err)
+	}
+	return nil
+}
+
+// StartStreaming はバッファを確保・マップしてキューに入れ、ストリーミングを開始する
+func (d *v4l2FileDevice) StartStreaming(bufferCount int) error {
+	request := v4l2RequestBuffers{
+		count:   uint32(bufferCount),
+		bufType: v4l2BufTypeVideoCapture,
+		memory:  v4l2MemoryMmap,
+	}
+	if err := v4l2Ioctl(uintptr(d.fd), vidiocReqBufs, unsafe.Pointer(&request)); err != nil {
+		return fmt.Errorf("VIDIOC_REQBUFSに失敗: %w", err)
+	}
+	if request.count < v4l2MinBufferCount {
+		return fmt.Errorf("バッファを確保できません（%d個）", request.count)
+	}
+
+	for index := uint32(0); index < request.count; index++ {
+		buffer := v4l2Buffer{index: index, bufType: v4l2BufTypeVideoCapture, memory: v4l2MemoryMmap}
+		if err := v4l2Ioctl(uintptr(d.fd), vidiocQueryBuf, unsafe.Pointer(&buffer)); err != nil {
+			return fmt.Errorf("VIDIOC_QUERYBUFに失敗: %w", err)
+		}
+
+		data, err := unix.Mmap(d.fd, int64(uint32(buffer.m)), int(buffer.length), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+		if err != nil {
+			return fmt.Errorf("バッファ %d のmmapに失敗: %w", index, err)
+		}
+		d.buffers = append(d.buffers, data)
+
+		if err := d.queue(index); err != nil {
+			return err
+		}
+	}
+
+	bufType := uint32(v4l2BufTypeVideoCapture)
+	if err := v4l2Ioctl(uintptr(d.fd), vidiocStreamOn, unsafe.Pointer(&bufType)); err != nil {
+		return fmt.Errorf("VIDIOC_STREAMONに失敗: %w", err)
+	}
+	d.streaming = true
+	return nil
+}
+
+// ReadFrame はフレームを待ってバッファから取り出し、バッファをキューに戻す
+func (d *v4l2FileDevice) ReadFrame(timeout time.Duration) ([]byte, error) {
+	fds := []unix.PollFd{{Fd: int32(d.fd), Events: unix.POLLIN}}
+	n, err := unix.Poll(fds, int(timeout.Milliseconds()))
+	if errors.Is(err, unix.EINTR) || (err == nil && n == 0) {
+		return nil, errFrameTimeout
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pollに失敗: %w", err)
+	}
+	if fds[0].Revents&unix.POLLERR != 0 {
+		return nil, fmt.Errorf("デバイスでエラーが発生しました（切断された可能性があります）")
+	}
+
+	buffer := v4l2Buffer{bufType: v4l2BufTypeVideoCapture, memory: v4l2MemoryMmap}
+	if err := v4l2Ioctl(uintptr(d.fd), vidiocDQBuf, unsafe.Pointer(&buffer)); err != nil {
+		if errors.Is(err, unix.EAGAIN) {
+			return nil, errFrameTimeout
+		}
+		return nil, fmt.Errorf("VIDIOC_DQBUFに失敗: %w", err)
+	}
+	if int(buffer.index) >= len(d.buffers) {
+		return nil, fmt.Errorf("不正なバッファ番号です: %d", buffer.index)
+	}
+
+	data := d.buffers[buffer.index]
+	var frame []byte
+	if buffer.flags&v4l2BufFlagError == 0 && int(buffer.bytesUsed) <= len(data) {
+		frame = make([]byte, buffer.bytesUsed)
+		copy(frame, data[:buffer.bytesUsed])
+	}
+
+	if err := d.queue(buffer.index); err != nil {
+		return nil, err
+	}
+	if frame == nil {
+		return nil, errCorruptedFrame
+	}
+	return frame, nil
+}
+
+// Close はストリーミングを停止し、バッファを解放してデバイスを閉じる
+func (d *v4l2FileDevice) Close() error {
+	var errs []error
+
+	if d.streaming {
+		bufType := uint32(v4l2BufTypeVideoCapture)
+		if err := v4l2Ioctl(uintptr(d.fd), vidiocStreamOff, unsafe.Pointer(&bufType)); err != nil {
+			errs = append(errs, fmt.Errorf("VIDIOC_STREAMOFFに失敗: %w", err))
+		}
+		d.streaming = false
+	}
+
+	for _, data := range d.buffers {
+		if err := unix.Munmap(data); err != nil {
+			errs = append(errs, fmt.Errorf("バッファのmunmapに失敗: %w", err))
+		}
+	}
+	d.buffers = nil
+
+	if err := unix.Close(d.fd); err != nil {
+		errs = append(errs, fmt.Errorf("デバイスのクローズに失敗: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// queue はバッファをキャプチャ用のキューに入れる
+func (d *v4l2FileDevice) queue(index uint32) error {
+	buffer := v4l2Buffer{index: index, bufType: v4l2BufTypeVideoCapture, memory: v4l2MemoryMmap}
+	if err := v4l2Ioctl(uintptr(d.fd), vidiocQBuf, unsafe.Pointer(&buffer)); err != nil {
+		return fmt.Errorf("VIDIOC_QBUFに失敗: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package camera
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // VideoSourceType はソースタイプを定義
@@ -15,6 +16,9 @@ const (
 	SourceTypeX11Screen VideoSourceType = "x11_screen"
 )
 
+// x11ScreenDevice はX11画面キャプチャのデバイス名
+const x11ScreenDevice = "x11:screen"
+
 // VideoSource は全ての動画源を統一するインターフェース
 type VideoSource interface {
 	// 基本操作
@@ -23,7 +27,12 @@ type VideoSource interface {
 	IsAvailable(ctx context.Context) bool
 
 	// ストリーミング
-	GetFrameChannel() <-chan []byte
+	// Subscribe はフレーム配信を購読する。利用後は Unsubscribe を呼び出すこと
+	Subscribe(bufferSize int, policy DropPolicy) *FrameSubscription
+	// SubscriberCount は現在の購読者数を返す
+	SubscriberCount() int
+	// LatestFrame は最後に取得したフレームを返す
+	LatestFrame() (Frame, bool)
 	GetErrorChannel() <-chan error
 
 	// タイムラプス用フレーム取得
@@ -39,6 +48,8 @@ type VideoSource interface {
 
 	// ステータス取得
 	GetStatus() Status
+	// GetStats は配信統計を返す
+	GetStats() SourceStats
 }
 
 // VideoSourceInfo はソース情報を表す
@@ -56,6 +67,9 @@ type VideoCapabilities struct {
 	SupportedResolutions []Resolution
 	SupportedFrameRates  []int
 	SupportedFormats     []string
+
+	// Formats はデバイスから取得したフォーマット毎の対応モード（取得できない場合は空）
+	Formats []FormatInfo
 }
 
 // VideoSettings は動画設定を統一
@@ -73,9 +87,10 @@ type BaseVideoSource struct {
 	info         VideoSourceInfo
 	capabilities VideoCapabilities
 	settings     VideoSettings
-	frameChan    chan []byte
+	broadcaster  *FrameBroadcaster
 	errorChan    chan error
 	status       Status
+	startedAt    time.Time // 開始した時刻（停止中はゼロ値）
 	mu           sync.RWMutex
 }
 
@@ -107,12 +122,39 @@ func (b *BaseVideoSource) GetStatus() Status {
 	return b.status
 }
 
-// GetFrameChannel はフレームチャンネルを返す
-func (b *BaseVideoSource) GetFrameChannel() <-chan []byte {
-	return b.frameChan
+// Subscribe はフレーム配信を購読する
+func (b *BaseVideoSource) Subscribe(bufferSize int, policy DropPolicy) *FrameSubscription {
+	return b.broadcaster.Subscribe(bufferSize, policy)
+}
+
+// SubscriberCount は現在の購読者数を返す
+func (b *BaseVideoSource) SubscriberCount() int {
+	return b.broadcaster.SubscriberCount()
+}
+
+// LatestFrame は最後に取得したフレームを返す
+func (b *BaseVideoSource) LatestFrame() (Frame, bool) {
+	return b.broadcaster.Latest()
 }
 
 // GetErrorChannel はエラーチャンネルを返す
 func (b *BaseVideoSource) GetErrorChannel() <-chan error {
 	return b.errorChan
 }
+
+// GetStats は配信統計を返す
+func (b *BaseVideoSource) GetStats() SourceStats {
+	stats := b.broadcaster.Stats(time.Now())
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	stats.StartedAt = b.startedAt
+	return stats
+}
+
+// markError はソースをエラー状態にする（監視で障害を検出して停止した場合に使用）
+func (b *BaseVideoSource) markError() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.status = StatusError
+}
@@ -3,11 +3,13 @@ package camera
 import (
 	"context"
 	"fmt"
-	"time"
+	"log"
 )
 
 // SourceConfig はソース作成設定
 type SourceConfig struct {
+	ID         string                 // ソースID（空の場合は自動生成）
+	Name       string                 // 表示名（空の場合は自動生成）
 	Device     string                 // デバイスパス
 	URL        string                 // IP カメラの場合
 	Settings   VideoSettings          // 設定
@@ -52,7 +54,7 @@ func (f *DefaultVideoSourceFactory) Register(sourceType VideoSourceType, creator
 func (f *DefaultVideoSourceFactory) CreateSource(sourceType VideoSourceType, config SourceConfig) (VideoSource, error) {
 	creator, exists := f.creators[sourceType]
 	if !exists {
-		return nil, fmt.Errorf("サポートされていないソースタイプ: %s", sourceType)
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSourceType, sourceType)
 	}
 
 	return creator(config)
@@ -89,19 +91,36 @@ func NewUSBCameraSourceFromConfig(config SourceConfig) (VideoSource, error) {
 		fps = config.Settings.FrameRate
 	}
 
-	// デバイス名を生成（既存のdiscovery.goの機能を使用）
+	// デバイスの名前と能力をV4L2から取得（既存のdiscovery.goの機能を使用）
 	discovery := NewLinuxDiscovery()
 	deviceInfo, err := discovery.GetDeviceInfo(context.TODO(), config.Device)
-	var name string
-	if err != nil || deviceInfo == nil {
-		name = fmt.Sprintf("USB Camera (%s)", config.Device)
-	} else {
-		name = deviceInfo.Name
+	if err != nil {
+		deviceInfo = nil
+	}
+
+	name := config.Name
+	if name == "" {
+		if deviceInfo == nil {
+			name = fmt.Sprintf("USB Camera (%s)", config.Device)
+		} else {
+			name = deviceInfo.Name
+		}
+	}
+
+	backend, err := captureBackendFromProperties(config.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	// IDは設定値を優先し、なければデバイスの永続的な識別子から生成する
+	id := config.ID
+	if id == "" {
+		id = usbCameraID(config.Device)
 	}
 
 	// VideoSourceInfo を設定
 	info := VideoSourceInfo{
-		ID:          generateCameraID(),
+		ID:          id,
 		Name:        name,
 		Type:        SourceTypeUSBCamera,
 		Driver:      "v4l2",
@@ -109,15 +128,11 @@ func NewUSBCameraSourceFromConfig(config SourceConfig) (VideoSource, error) {
 		Device:      config.Device,
 	}
 
-	// VideoCapabilities を設定
-	capabilities := VideoCapabilities{
-		SupportedResolutions: []Resolution{
-			{Width: 640, Height: 480},
-			{Width: 1280, Height: 720},
-			{Width: 1920, Height: 1080},
-		},
-		SupportedFrameRates: []int{5, 10, 15, 30},
-		SupportedFormats:    []string{"MJPEG", "YUYV"},
+	// VideoCapabilities を設定（取得できない場合は一般的なUSBカメラの想定値）
+	capabilities := defaultUSBCapabilities()
+	if deviceInfo != nil && len(deviceInfo.PixelFormats) > 0 {
+		capabilities = capabilitiesFromDeviceInfo(deviceInfo)
+		info.Driver = deviceInfo.Driver
 	}
 
 	// VideoSettings を設定
@@ -129,13 +144,17 @@ func NewUSBCameraSourceFromConfig(config SourceConfig) (VideoSource, error) {
 		Quality:    3,
 		Properties: make(map[string]interface{}),
 	}
+	if config.Settings.Format != "" {
+		settings.Format = config.Settings.Format
+	}
 
-	return NewDirectUSBCameraSource(info, capabilities, settings), nil
-}
+	// カメラが対応していない設定では ffmpeg が起動に失敗するため、最も近い対応設定に置き換える
+	if err := ValidateSettings(capabilities, settings); err != nil {
+		adjusted := closestSupportedSettings(capabilities, settings)
+		log.Printf("%s: %v のため %s %dx%d@%dfps を使用します",
+			config.Device, err, adjusted.Format, adjusted.Width, adjusted.Height, adjusted.FrameRate)
+		settings = adjusted
+	}
 
-// generateCameraID はユニークなカメラIDを生成する
-func generateCameraID() string {
-	// 既存のコードでUUIDを使用しているため同じ方式を使用
-	// 簡単のため現在時刻ベースのIDを生成
-	return fmt.Sprintf("camera_%d", time.Now().UnixNano())
+	return NewDirectUSBCameraSource(info, capabilities, settings, backend), nil
 }
@@ -93,18 +93,7 @@ func (c *X11Capturer) CaptureFrameAsJPEG(ctx context.Context) ([]byte, error) {
 
 // StartStream はX11画面キャプチャのストリームを開始する
 func (c *X11Capturer) StartStream(ctx context.Context, frameChan chan<- []byte, errorChan chan<- error) {
-	cmd := exec.CommandContext(ctx,
-		"ffmpeg",
-		"-f", "x11grab",
-		"-video_size", fmt.Sprintf("%dx%d", c.width, c.height),
-		"-r", strconv.Itoa(c.fps),
-		"-i", c.display,
-		"-vf", "format=yuv420p",
-		"-f", "image2pipe",
-		"-c:v", "mjpeg",
-		"-q:v", "3",
-		"-",
-	)
+	cmd := exec.CommandContext(ctx, "ffmpeg", c.streamArgs()...)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -112,28 +101,15 @@ func (c *X11Capturer) StartStream(ctx context.Context, frameChan chan<- []byte,
 		return
 	}
 
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		errorChan <- fmt.Errorf("stderrパイプの作成に失敗: %w", err)
-		return
-	}
+	// 終了時のエラーメッセージに使うため、標準エラー出力の末尾を保持する
+	stderr := &stderrTail{}
+	cmd.Stderr = stderr
 
 	if err := cmd.Start(); err != nil {
 		errorChan <- fmt.Errorf("ffmpegの起動に失敗: %w", err)
 		return
 	}
 
-	// stderrを別goroutineで読み取り
-	go func() {
-		buf := make([]byte, 1024)
-		for {
-			_, err := stderr.Read(buf)
-			if err != nil {
-				break
-			}
-		}
-	}()
-
 	// JPEGフレームを読み取り
 	go func() {
 		defer func() {
@@ -154,39 +130,26 @@ func (c *X11Capturer) StartStream(ctx context.Context, frameChan chan<- []byte,
 			default:
 				n, err := stdout.Read(buffer)
 				if err != nil {
-					if err.Error() != "EOF" {
-						errorChan <- fmt.Errorf("フレーム読み取りエラー: %w", err)
+					// 停止以外でffmpegが終了した場合は監視で再起動できるようエラーを通知する
+					if ctx.Err() == nil {
+						sendStreamError(ctx, errorChan, ffmpegExitError(err, stderr))
 					}
 					return
 				}
 
 				frameBuffer.Write(buffer[:n])
 
-				// JPEGマーカーを探してフレームを分割
+				// JPEGのマーカーを辿ってフレームを分割
 				data := frameBuffer.Bytes()
 				for {
-					// JPEGの開始マーカー（FF D8）を探す
-					startIdx := bytes.Index(data, []byte{0xFF, 0xD8})
-					if startIdx == -1 {
-						break
-					}
-
-					// JPEGの終了マーカー（FF D9）を探す
-					endIdx := bytes.Index(data[startIdx+2:], []byte{0xFF, 0xD9})
-					if endIdx == -1 {
-						// 完全なフレームがまだない
-						if startIdx > 0 {
-							// 不要なデータを削除
-							frameBuffer.Reset()
-							frameBuffer.Write(data[startIdx:])
-						}
-						break
+					jpegFrame, rest := nextJPEGFrame(data)
+					data = rest
+					if jpegFrame == nil {
+						break // 完全なフレームがまだない
 					}
 
-					// 完全なJPEGフレームを抽出
-					endIdx += startIdx + 2 + 2 // マーカーのサイズを含める
-					frame := make([]byte, endIdx)
-					copy(frame, data[:endIdx])
+					frame := make([]byte, len(jpegFrame))
+					copy(frame, jpegFrame)
 
 					// フレームを送信
 					select {
@@ -194,18 +157,32 @@ func (c *X11Capturer) StartStream(ctx context.Context, frameChan chan<- []byte,
 					case <-ctx.Done():
 						return
 					}
-
-					// 処理済みデータを削除
-					remaining := data[endIdx:]
-					frameBuffer.Reset()
-					if len(remaining) > 0 {
-						frameBuffer.Write(remaining)
-						data = frameBuffer.Bytes()
-					} else {
-						break
-					}
 				}
+
+				// 処理済みデータを削除
+				frameBuffer.Reset()
+				frameBuffer.Write(data)
 			}
 		}
 	}()
 }
+
+// streamArgs は連続キャプチャ用のffmpeg引数を返す
+func (c *X11Capturer) streamArgs() []string {
+	return []string{
+		"-f", "x11grab",
+		"-video_size", fmt.Sprintf("%dx%d", c.width, c.height),
+		"-r", strconv.Itoa(c.fps),
+		"-i", c.display,
+		"-vf", "format=yuv420p",
+		"-f", "image2pipe",
+		"-c:v", "mjpeg",
+		"-q:v", "3",
+		"-",
+	}
+}
+
+// CommandLine は連続キャプチャに使うffmpegのコマンドラインを返す
+func (c *X11Capturer) CommandLine() string {
+	return formatCommandLine("ffmpeg", c.streamArgs())
+}
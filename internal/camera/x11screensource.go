@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
+// defaultX11Display はキャプチャ対象のX11ディスプレイ
+const defaultX11Display = ":0.0"
+
 // X11ScreenSource は画面キャプチャの VideoSource 実装
 type X11ScreenSource struct {
 	BaseVideoSource
@@ -14,8 +18,9 @@ type X11ScreenSource struct {
 	capturer *X11Capturer
 
 	// 制御用
-	stopCh chan struct{}
-	wg     sync.WaitGroup
+	stopCh       chan struct{}
+	cancelStream context.CancelFunc
+	wg           sync.WaitGroup
 
 	// ストリーミング用の内部チャンネル
 	internalFrameChan chan []byte
@@ -43,14 +48,9 @@ func (s *X11ScreenSource) Start(ctx context.Context) error {
 		return fmt.Errorf("画面キャプチャのテストに失敗: %w", err)
 	}
 
-	// ストリーミングを開始
-	go s.capturer.StartStream(ctx, s.internalFrameChan, s.internalErrorChan)
-
-	// フレーム転送ゴルーチンを開始
-	s.wg.Add(1)
-	go s.forwardFrames()
-
+	s.startStreaming(ctx)
 	s.status = StatusActive
+	s.startedAt = time.Now()
 	return nil
 }
 
@@ -63,19 +63,26 @@ func (s *X11ScreenSource) Stop(_ context.Context) error {
 		return nil // 既に停止済み
 	}
 
-	// 停止シグナルを送信
-	close(s.stopCh)
+	s.stopStreaming()
 
-	// ゴルーチンの終了を待機
-	s.wg.Wait()
-
-	// 新しいstopChを作成（再開可能にするため）
-	s.stopCh = make(chan struct{})
+	// 購読者を切断し、古いフレームを破棄
+	s.broadcaster.Reset()
 
 	s.status = StatusInactive
+	s.startedAt = time.Time{}
 	return nil
 }
 
+// GetStats は配信統計とキャプチャのコマンドラインを返す
+func (s *X11ScreenSource) GetStats() SourceStats {
+	stats := s.BaseVideoSource.GetStats()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stats.CaptureCommand = captureCommandLine(s.capturer)
+	return stats
+}
+
 // IsAvailable は画面キャプチャが利用可能かチェックする
 func (s *X11ScreenSource) IsAvailable(ctx context.Context) bool {
 	return s.capturer.IsDeviceAvailable(ctx)
@@ -86,30 +93,55 @@ func (s *X11ScreenSource) ApplySettings(ctx context.Context, settings VideoSetti
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// アクティブな場合は停止してから再開始する
+	active := s.status == StatusActive
+	if active {
+		s.stopStreaming()
+	}
+
 	// 新しい設定でキャプチャを再作成
-	s.capturer = NewX11Capturer(":0.0", settings.Width, settings.Height, settings.FrameRate)
+	s.capturer = NewX11Capturer(defaultX11Display, settings.Width, settings.Height, settings.FrameRate)
 
 	// 内部設定を更新
 	s.settings = settings
 
-	// アクティブな場合は再開始が必要
-	if s.status == StatusActive {
-		// 停止してから再開始
-		s.status = StatusInactive
-		close(s.stopCh)
-		s.wg.Wait()
-		s.stopCh = make(chan struct{})
-
-		// 再開始
-		go s.capturer.StartStream(ctx, s.internalFrameChan, s.internalErrorChan)
-		s.wg.Add(1)
-		go s.forwardFrames()
-		s.status = StatusActive
+	if active {
+		s.startStreaming(ctx)
 	}
 
 	return nil
 }
 
+// startStreaming はキャプチャとフレーム転送のゴルーチンを開始する（ロック保持中に呼び出す）
+// キャプチャは停止時にキャンセルするため、呼び出し元のコンテキストから派生させる
+func (s *X11ScreenSource) startStreaming(ctx context.Context) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	s.cancelStream = cancel
+
+	// ストリーミングを開始
+	go s.capturer.StartStream(streamCtx, s.internalFrameChan, s.internalErrorChan)
+
+	// フレーム転送ゴルーチンを開始
+	s.wg.Add(1)
+	go s.forwardFrames()
+}
+
+// stopStreaming はキャプチャとフレーム転送を停止し、終了を待機する（ロック保持中に呼び出す）
+func (s *X11ScreenSource) stopStreaming() {
+	// 停止シグナルを送信（キャンセルでffmpegも終了する）
+	if s.cancelStream != nil {
+		s.cancelStream()
+		s.cancelStream = nil
+	}
+	close(s.stopCh)
+
+	// ゴルーチンの終了を待機
+	s.wg.Wait()
+
+	// 新しいstopChを作成（再開可能にするため）
+	s.stopCh = make(chan struct{})
+}
+
 // forwardFrames はキャプチャからフレームを転送する
 func (s *X11ScreenSource) forwardFrames() {
 	defer s.wg.Done()
@@ -125,23 +157,8 @@ func (s *X11ScreenSource) forwardFrames() {
 				return
 			}
 
-			// フレームを転送
-			select {
-			case s.frameChan <- frame:
-			case <-s.stopCh:
-				return
-			default:
-				// チャンネルがフルの場合は古いフレームを破棄
-				select {
-				case <-s.frameChan:
-				default:
-				}
-				select {
-				case s.frameChan <- frame:
-				case <-s.stopCh:
-					return
-				}
-			}
+			// 全購読者へ配信（最新フレームも保持される）
+			s.broadcaster.Publish(frame)
 
 		case err, ok := <-s.internalErrorChan:
 			if !ok {
@@ -188,14 +205,25 @@ func NewX11ScreenSourceFromConfig(config SourceConfig) (VideoSource, error) {
 		fps = config.Settings.FrameRate
 	}
 
+	name := config.Name
+	if name == "" {
+		name = "画面キャプチャ :0" // ディスプレイ番号を含める
+	}
+
+	// IDは設定値を優先し、なければディスプレイ名から生成する
+	id := config.ID
+	if id == "" {
+		id = x11ScreenID(defaultX11Display)
+	}
+
 	// VideoSourceInfo を設定（ディスプレイ番号を名前に含める）
 	info := VideoSourceInfo{
-		ID:          generateCameraID(),
-		Name:        "画面キャプチャ :0", // ディスプレイ番号を含める
+		ID:          id,
+		Name:        name,
 		Type:        SourceTypeX11Screen,
 		Driver:      "x11grab",
 		Description: "X11 Screen Capture",
-		Device:      "x11:screen",
+		Device:      x11ScreenDevice,
 	}
 
 	// VideoCapabilities を設定
@@ -224,11 +252,11 @@ func NewX11ScreenSourceFromConfig(config SourceConfig) (VideoSource, error) {
 			info:         info,
 			capabilities: capabilities,
 			settings:     settings,
-			frameChan:    make(chan []byte, 10),
+			broadcaster:  NewFrameBroadcaster(),
 			errorChan:    make(chan error, 5),
 			status:       StatusInactive,
 		},
-		capturer:          NewX11Capturer(":0.0", width, height, fps),
+		capturer:          NewX11Capturer(defaultX11Display, width, height, fps),
 		stopCh:            make(chan struct{}),
 		internalFrameChan: make(chan []byte, 10),
 		internalErrorChan: make(chan error, 5),
@@ -246,6 +274,13 @@ func (s *X11ScreenSource) CaptureFrameForTimelapse(ctx context.Context) ([]byte,
 		return nil, fmt.Errorf("画面キャプチャが非アクティブです")
 	}
 
-	// X11Capturerを使って1フレームをキャプチャ
+	// ストリーミング中の最新フレームがあればそれを使用
+	if latest, ok := s.broadcaster.Latest(); ok {
+		frame := make([]byte, len(latest.Data))
+		copy(frame, latest.Data)
+		return frame, nil
+	}
+
+	// まだフレームがない場合はX11Capturerを使って1フレームをキャプチャ
 	return s.capturer.CaptureFrameAsJPEG(ctx)
 }
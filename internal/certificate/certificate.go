@@ -0,0 +1,198 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const (
+	// validity は自己署名証明書の有効期間
+	validity = 825 * 24 * time.Hour
+	// renewBefore は有効期限がこの期間内に迫った自己署名証明書を再生成する
+	renewBefore = 30 * 24 * time.Hour
+	// organization は自己署名証明書の組織名
+	organization = "Senrigan"
+)
+
+// Load は証明書ファイルと秘密鍵ファイル（PEM）を読み込む
+func Load(certFile, keyFile string) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("証明書 %s の読み込みに失敗: %w", certFile, err)
+	}
+	return cert, nil
+}
+
+// LoadOrCreateSelfSigned は保存済みの自己署名証明書を読み込み、使えない場合は生成して保存する
+// hosts には証明書に含めるホスト名・IPアドレスを指定する
+func LoadOrCreateSelfSigned(certFile, keyFile string, hosts []string) (tls.Certificate, error) {
+	return loadOrCreateSelfSigned(certFile, keyFile, hosts, time.Now())
+}
+
+// loadOrCreateSelfSigned は現在時刻を指定して LoadOrCreateSelfSigned を行う
+func loadOrCreateSelfSigned(certFile, keyFile string, hosts []string, now time.Time) (tls.Certificate, error) {
+	cert, err := Load(certFile, keyFile)
+	switch {
+	case err == nil:
+		reason := renewalReason(cert.Leaf, hosts, now)
+		if reason == "" {
+			return cert, nil
+		}
+		log.Printf("自己署名証明書を再生成します: %s", reason)
+	case errors.Is(err, os.ErrNotExist):
+		log.Printf("自己署名証明書を生成します: %s", certFile)
+	default:
+		log.Printf("保存済みの自己署名証明書を使用できないため再生成します: %v", err)
+	}
+
+	certPEM, keyPEM, err := generateSelfSigned(hosts, now)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := writeFile(certFile, certPEM, 0644); err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := writeFile(keyFile, keyPEM, 0600); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("生成した証明書の読み込みに失敗: %w", err)
+	}
+	log.Printf("自己署名証明書を保存しました: %s", certFile)
+	return cert, nil
+}
+
+// renewalReason は自己署名証明書を再生成する理由を返す（再生成が不要な場合は空文字）
+func renewalReason(leaf *x509.Certificate, hosts []string, now time.Time) string {
+	if leaf == nil {
+		return "証明書を解析できません"
+	}
+	if now.Add(renewBefore).After(leaf.NotAfter) {
+		return fmt.Sprintf("有効期限 %s が近いため", leaf.NotAfter.Format(time.DateOnly))
+	}
+	for _, host := range hosts {
+		if leaf.VerifyHostname(host) != nil {
+			return fmt.Sprintf("ホスト %s が含まれていないため", host)
+		}
+	}
+	return ""
+}
+
+// generateSelfSigned は自己署名証明書と秘密鍵をPEM形式で生成する
+func generateSelfSigned(hosts []string, now time.Time) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("秘密鍵の生成に失敗: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("シリアル番号の生成に失敗: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{organization}, CommonName: commonName(hosts)},
+		NotBefore:             now.Add(-time.Hour), // 時刻のずれを許容する
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("証明書の生成に失敗: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("秘密鍵の変換に失敗: %w", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// commonName は証明書のCommonName（最初のホスト名）を返す
+func commonName(hosts []string) string {
+	if len(hosts) == 0 {
+		return organization
+	}
+	return hosts[0]
+}
+
+// writeFile はファイルを一時ファイル経由で書き込む（書き込み途中のファイルを読み込まないため）
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("ディレクトリ %s の作成に失敗: %w", filepath.Dir(path), err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, perm); err != nil {
+		return fmt.Errorf("%s の書き込みに失敗: %w", path, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("%s の置き換えに失敗: %w", path, err)
+	}
+	return nil
+}
+
+// Fingerprint は証明書のSHA-256フィンガープリントを返す（ブラウザの警告画面での確認用）
+func Fingerprint(cert tls.Certificate) string {
+	if len(cert.Certificate) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(cert.Certificate[0])
+	return hex.EncodeToString(sum[:])
+}
+
+// DetectHosts は自己署名証明書に含めるホスト名・IPアドレスを検出する
+// localhost、ホスト名、ループバック以外のネットワークインターフェースのIPアドレスを返す
+func DetectHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append(hosts, hostname)
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		log.Printf("ネットワークインターフェースのアドレス取得に失敗: %v", err)
+		return hosts
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ip := ipNet.IP.String(); !slices.Contains(hosts, ip) {
+			hosts = append(hosts, ip)
+		}
+	}
+	return hosts
+}
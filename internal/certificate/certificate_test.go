@@ -0,0 +1,91 @@
+package certificate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadOrCreateSelfSigned_Persists(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls", "cert.pem")
+	keyFile := filepath.Join(dir, "tls", "key.pem")
+	hosts := []string{"localhost", "127.0.0.1", "senrigan.local"}
+
+	first, err := LoadOrCreateSelfSigned(certFile, keyFile, hosts)
+	if err != nil {
+		t.Fatalf("LoadOrCreateSelfSigned failed: %v", err)
+	}
+	for _, host := range hosts {
+		if err := first.Leaf.VerifyHostname(host); err != nil {
+			t.Errorf("Expected certificate to cover %s: %v", host, err)
+		}
+	}
+
+	info, err := os.Stat(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected key file permission 0600, got %o", perm)
+	}
+
+	// 再起動後も同じ証明書を使う
+	second, err := LoadOrCreateSelfSigned(certFile, keyFile, hosts[:1])
+	if err != nil {
+		t.Fatalf("LoadOrCreateSelfSigned failed: %v", err)
+	}
+	if Fingerprint(first) != Fingerprint(second) {
+		t.Error("Expected persisted certificate to be reused")
+	}
+}
+
+func TestLoadOrCreateSelfSigned_Renews(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	original, err := LoadOrCreateSelfSigned(certFile, keyFile, []string{"localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 指定したホストが含まれていない場合は再生成する
+	withHost, err := LoadOrCreateSelfSigned(certFile, keyFile, []string{"localhost", "192.168.1.10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Fingerprint(original) == Fingerprint(withHost) {
+		t.Error("Expected certificate to be regenerated for a new host")
+	}
+	if err := withHost.Leaf.VerifyHostname("192.168.1.10"); err != nil {
+		t.Errorf("Expected regenerated certificate to cover the new IP: %v", err)
+	}
+
+	// 有効期限が近い場合は再生成する
+	later := time.Now().Add(validity - renewBefore + time.Hour)
+	renewed, err := loadOrCreateSelfSigned(certFile, keyFile, []string{"localhost"}, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Fingerprint(renewed) == Fingerprint(withHost) {
+		t.Error("Expected certificate to be renewed before expiry")
+	}
+}
+
+func TestLoadOrCreateSelfSigned_ReplacesInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, []byte("broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadOrCreateSelfSigned(certFile, keyFile, []string{"localhost"}); err != nil {
+		t.Fatalf("Expected invalid certificate to be replaced, got %v", err)
+	}
+	if _, err := Load(certFile, keyFile); err != nil {
+		t.Errorf("Expected replaced certificate to load, got %v", err)
+	}
+}
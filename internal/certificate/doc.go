@@ -0,0 +1,17 @@
+// Package certificate はHTTPS配信に使う証明書の読み込みと自己署名証明書の生成を提供します。
+//
+// 主な機能:
+// - 証明書・秘密鍵ファイル（PEM）の読み込み
+// - LAN内での利用向けの自己署名証明書の生成と保存
+// - 自己署名証明書に含めるホスト名・IPアドレスの検出
+//
+// 責務:
+// - Load: 指定された証明書ファイルの読み込み
+// - LoadOrCreateSelfSigned: 保存済みの自己署名証明書の再利用と、必要な場合の再生成
+//
+// 仕様:
+// - 自己署名証明書は初回起動時に生成して保存し、再起動後も同じ証明書を使う（ブラウザの警告は初回のみ）
+// - 有効期限が近い場合（30日以内）や、指定したホストが含まれていない場合は再生成する
+// - 鍵はECDSA P-256、有効期間は825日（ブラウザが受け入れる上限）
+// - 秘密鍵ファイルは所有者のみ読み書きできる権限（0600）で保存する
+package certificate
@@ -3,9 +3,19 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"senrigan/internal/auth"
+	"senrigan/internal/camera"
+	"senrigan/internal/imaging"
+	"senrigan/internal/motion"
+	"senrigan/internal/recorder"
 	"senrigan/internal/timelapse"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
 )
 
 // Config はアプリケーション全体の設定を保持する構造体
@@ -13,6 +23,9 @@ type Config struct {
 	Server    ServerConfig     `yaml:"server"`
 	Camera    CameraConfig     `yaml:"camera"`
 	Timelapse timelapse.Config `yaml:"timelapse"`
+	Recording recorder.Config  `yaml:"recording"`
+	Motion    motion.Config    `yaml:"motion"`
+	Auth      auth.Config      `yaml:"auth"`
 }
 
 // ServerConfig はHTTPサーバーの設定
@@ -23,6 +36,59 @@ type ServerConfig struct {
 	// タイムアウト設定
 	ReadTimeout  time.Duration `yaml:"read_timeout"`  // 読み込みタイムアウト
 	WriteTimeout time.Duration `yaml:"write_timeout"` // 書き込みタイムアウト
+
+	// 他のオリジンからのアクセス（CORS・WebSocket）を許可するオリジン（例: http://localhost:3000）
+	// 未指定の場合、認証が無効なら全てのオリジンを許可し、有効なら同一オリジンのみ許可する
+	AllowedOrigins []string `yaml:"allowed_origins"`
+
+	// HTTPS配信の設定
+	TLS TLSConfig `yaml:"tls"`
+}
+
+// TLSの配信モード
+const (
+	TLSModeOff        = "off"         // HTTPで配信（デフォルト）
+	TLSModeFile       = "file"        // 指定した証明書ファイルでHTTPSを配信
+	TLSModeSelfSigned = "self_signed" // 初回起動時に生成・保存した自己署名証明書でHTTPSを配信（LAN向け）
+)
+
+// 自己署名証明書のデフォルトの保存先
+const (
+	defaultSelfSignedCertFile = "./data/tls/cert.pem"
+	defaultSelfSignedKeyFile  = "./data/tls/key.pem"
+)
+
+// TLSConfig はHTTPS配信の設定
+type TLSConfig struct {
+	Mode     string `yaml:"mode"`      // off / file / self_signed
+	CertFile string `yaml:"cert_file"` // 証明書ファイル（PEM）。self_signed の場合は保存先
+	KeyFile  string `yaml:"key_file"`  // 秘密鍵ファイル（PEM）。self_signed の場合は保存先
+
+	// 自己署名証明書に追加するホスト名・IPアドレス（localhost・ホスト名・インターフェースのIPアドレスは自動で含める）
+	Hosts []string `yaml:"hosts"`
+
+	// HTTPのリクエストをHTTPSにリダイレクトするポート（0で無効）
+	RedirectPort int `yaml:"redirect_port"`
+}
+
+// Enabled はHTTPSで配信するか返す
+func (t TLSConfig) Enabled() bool {
+	return t.Mode == TLSModeFile || t.Mode == TLSModeSelfSigned
+}
+
+// Files は証明書ファイルと秘密鍵ファイルのパスを返す
+// self_signed で未指定の場合はデフォルトの保存先（./data/tls）を返す
+func (t TLSConfig) Files() (certFile, keyFile string) {
+	certFile, keyFile = t.CertFile, t.KeyFile
+	if t.Mode == TLSModeSelfSigned {
+		if certFile == "" {
+			certFile = defaultSelfSignedCertFile
+		}
+		if keyFile == "" {
+			keyFile = defaultSelfSignedKeyFile
+		}
+	}
+	return certFile, keyFile
 }
 
 // CameraConfig はカメラ関連の設定
@@ -46,18 +112,55 @@ type CameraDevice struct {
 	FPS    int `yaml:"fps"`
 	Width  int `yaml:"width"`
 	Height int `yaml:"height"`
+
+	// キャプチャ方式（ffmpeg: ffmpeg経由（デフォルト）、native: V4L2から直接取得）
+	CaptureBackend string `yaml:"capture_backend"`
+
+	// 連続録画の設定
+	Recording recorder.SourceOptions `yaml:"recording"`
+
+	// 動体検知の設定
+	Motion motion.SourceOptions `yaml:"motion"`
 }
 
 // Load は設定を読み込む
-// 現在はデフォルト値を返すシンプルな実装
+// 設定ファイルを使わず、デフォルト値に環境変数を反映した設定を返す
 func Load() (*Config, error) {
-	// デフォルト設定を作成
-	cfg := &Config{
+	return LoadFile("")
+}
+
+// LoadFile は設定ファイルを読み込む
+// デフォルト値 → 設定ファイル → 環境変数 の順に適用する
+// path が空の場合は設定ファイルを読み込まない
+func LoadFile(path string) (*Config, error) {
+	cfg := defaultConfig()
+
+	if path != "" {
+		if err := cfg.decodeFile(path); err != nil {
+			return nil, fmt.Errorf("設定ファイル %s の読み込みに失敗: %w", path, err)
+		}
+	}
+
+	// 環境変数で上書き
+	cfg.applyEnv()
+
+	// 設定の検証
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("設定の検証に失敗: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// defaultConfig はデフォルト設定を返す
+func defaultConfig() *Config {
+	return &Config{
 		Server: ServerConfig{
-			Host:         getEnvOrDefault("SERVER_HOST", "0.0.0.0"),
-			Port:         getEnvAsIntOrDefault("PORT", 8009),
+			Host:         "0.0.0.0",
+			Port:         8009,
 			ReadTimeout:  10 * time.Second,
 			WriteTimeout: 0, // ストリーミング用にタイムアウト無効化
+			TLS:          TLSConfig{Mode: TLSModeOff},
 		},
 		Camera: CameraConfig{
 			Devices:       []CameraDevice{},
@@ -66,14 +169,44 @@ func Load() (*Config, error) {
 			DefaultHeight: 720,
 		},
 		Timelapse: timelapse.DefaultConfig(),
+		Recording: recorder.DefaultConfig(),
+		Motion:    motion.DefaultConfig(),
+		Auth:      auth.DefaultConfig(),
 	}
+}
 
-	// 設定の検証
-	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("設定の検証に失敗: %w", err)
+// decodeFile は設定ファイルの内容を既存の設定に上書きする
+// ファイルに記述されていない項目は既存の値（デフォルト値）が維持される
+func (c *Config) decodeFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
 	}
 
-	return cfg, nil
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, c)
+	case ".toml":
+		// TOMLは一度汎用の値にデコードしてからYAMLとして解釈する
+		// yamlタグと "10s" 形式の時間指定をそのまま使うため
+		var raw map[string]interface{}
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		converted, err := yaml.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		return yaml.Unmarshal(converted, c)
+	default:
+		return fmt.Errorf("サポートされていない設定ファイル形式: %s", ext)
+	}
+}
+
+// applyEnv は環境変数の値で設定を上書きする
+func (c *Config) applyEnv() {
+	c.Server.Host = getEnvOrDefault("SERVER_HOST", c.Server.Host)
+	c.Server.Port = getEnvAsIntOrDefault("PORT", c.Server.Port)
 }
 
 // Validate は設定の妥当性を検証する
@@ -82,7 +215,220 @@ func (c *Config) Validate() error {
 	if c.Server.Port < 1 || c.Server.Port > 65535 {
 		return fmt.Errorf("無効なポート番号: %d", c.Server.Port)
 	}
+	if err := c.Server.TLS.validate(c.Server.Port); err != nil {
+		return fmt.Errorf("TLS設定: %w", err)
+	}
+
+	// カメラ設定の検証
+	ids := make(map[string]bool)
+	devices := make(map[string]bool)
+	for i, device := range c.Camera.Devices {
+		if device.FPS < 0 || device.Width < 0 || device.Height < 0 {
+			return fmt.Errorf("カメラ設定 %d: FPS・解像度に負の値は指定できません", i)
+		}
+		if device.ID != "" {
+			if ids[device.ID] {
+				return fmt.Errorf("カメラIDが重複しています: %s", device.ID)
+			}
+			ids[device.ID] = true
+		}
+		if device.Device != "" {
+			if devices[device.Device] {
+				return fmt.Errorf("カメラデバイスが重複しています: %s", device.Device)
+			}
+			devices[device.Device] = true
+		}
+		if _, err := camera.ParseCaptureBackend(device.CaptureBackend); err != nil {
+			return fmt.Errorf("カメラ設定 %d: 無効なキャプチャ方式: %s", i, device.CaptureBackend)
+		}
+		if device.Recording.Format != "" && !isRecordingFormat(device.Recording.Format) {
+			return fmt.Errorf("カメラ設定 %d: 無効な録画フォーマット: %s", i, device.Recording.Format)
+		}
+		if (device.Recording.Enabled || device.Recording.Clips.Enabled) && device.Device == "" {
+			return fmt.Errorf("カメラ設定 %d: 録画にはデバイスパスの指定が必要です", i)
+		}
+		if err := validateClipOptions(device.Recording.Clips); err != nil {
+			return fmt.Errorf("カメラ設定 %d: %w", i, err)
+		}
+		if device.Motion.Enabled && device.Device == "" {
+			return fmt.Errorf("カメラ設定 %d: 動体検知にはデバイスパスの指定が必要です", i)
+		}
+		if err := validateMotionOptions(device.Motion); err != nil {
+			return fmt.Errorf("カメラ設定 %d: %w", i, err)
+		}
+	}
 
+	// 録画設定の検証（未指定の項目はデフォルト値を使用する）
+	if c.Recording.Format != "" && !isRecordingFormat(c.Recording.Format) {
+		return fmt.Errorf("無効な録画フォーマット: %s", c.Recording.Format)
+	}
+	if c.Recording.RetentionDays < 0 || c.Recording.MaxSize < 0 {
+		return fmt.Errorf("録画の保持設定に負の値は指定できません")
+	}
+	if c.Recording.SegmentDuration < 0 {
+		return fmt.Errorf("無効なセグメント長: %s", c.Recording.SegmentDuration)
+	}
+	if c.Recording.Clips.PreRoll < 0 || c.Recording.Clips.PostRoll < 0 || c.Recording.Clips.MaxDuration < 0 {
+		return fmt.Errorf("イベント録画の時間に負の値は指定できません")
+	}
+
+	// 動体検知設定の検証（未指定の項目はデフォルト値を使用する）
+	if err := validateMotionOptions(motion.SourceOptions{
+		Threshold:   c.Motion.Threshold,
+		Sensitivity: c.Motion.Sensitivity,
+	}); err != nil {
+		return err
+	}
+	if c.Motion.AnalysisWidth < 0 || c.Motion.Interval < 0 || c.Motion.EventGap < 0 || c.Motion.RetentionDays < 0 {
+		return fmt.Errorf("動体検知設定に負の値は指定できません")
+	}
+
+	// タイムラプス設定の検証
+	if !timelapse.IsOutput(c.Timelapse.Output) {
+		return fmt.Errorf("無効なタイムラプスの出力: %s", c.Timelapse.Output)
+	}
+	if c.Timelapse.RetentionDays < 0 || c.Timelapse.MaxFrameStorage < 0 {
+		return fmt.Errorf("タイムラプスのフレームの保持設定に負の値は指定できません")
+	}
+	if !imaging.IsScaleMode(c.Timelapse.Scale) {
+		return fmt.Errorf("無効なタイムラプスの拡大縮小の方法: %s", c.Timelapse.Scale)
+	}
+	if !imaging.IsResampling(c.Timelapse.Resampling) {
+		return fmt.Errorf("無効なタイムラプスの補間フィルタ: %s", c.Timelapse.Resampling)
+	}
+	if err := timelapse.ValidateOverlay(c.Timelapse.Overlay); err != nil {
+		return fmt.Errorf("タイムラプスの文字の描画設定: %w", err)
+	}
+	if err := timelapse.ValidateLayouts(c.Timelapse); err != nil {
+		return fmt.Errorf("タイムラプスのレイアウト設定: %w", err)
+	}
+
+	// 認証設定の検証
+	if err := c.Auth.Validate(); err != nil {
+		return fmt.Errorf("認証設定: %w", err)
+	}
+
+	return nil
+}
+
+// validateClipOptions はイベント録画の時間と時間帯を検証する
+func validateClipOptions(options recorder.ClipOptions) error {
+	if options.PreRoll < 0 || options.PostRoll < 0 || options.MaxDuration < 0 {
+		return fmt.Errorf("イベント録画の時間に負の値は指定できません")
+	}
+	for _, window := range options.Schedule {
+		if err := window.Validate(); err != nil {
+			return fmt.Errorf("イベント録画の時間帯: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateMotionOptions は動体検知の閾値・感度・マスク領域を検証する
+func validateMotionOptions(options motion.SourceOptions) error {
+	if options.Threshold < 0 || options.Threshold > 255 {
+		return fmt.Errorf("無効な動体検知の閾値: %d", options.Threshold)
+	}
+	if options.Sensitivity < 0 || options.Sensitivity > 100 {
+		return fmt.Errorf("無効な動体検知の感度: %d", options.Sensitivity)
+	}
+	for i, mask := range options.Masks {
+		if mask.X < 0 || mask.Y < 0 || mask.Width <= 0 || mask.Height <= 0 ||
+			mask.X+mask.Width > 1 || mask.Y+mask.Height > 1 {
+			return fmt.Errorf("無効なマスク領域 %d: 0〜1の比率で指定してください", i)
+		}
+	}
+	return nil
+}
+
+// isRecordingFormat は録画フォーマットがサポートされているか判定する
+func isRecordingFormat(format string) bool {
+	return format == recorder.FormatMP4 || format == recorder.FormatMKV
+}
+
+// DefaultSettings はカメラのデフォルト映像設定を返す
+func (c CameraConfig) DefaultSettings() camera.VideoSettings {
+	return camera.VideoSettings{
+		Width:      c.DefaultWidth,
+		Height:     c.DefaultHeight,
+		FrameRate:  c.DefaultFPS,
+		Format:     "MJPEG",
+		Quality:    3,
+		Properties: make(map[string]interface{}),
+	}
+}
+
+// RecordingOptions はデバイスパスをキーとした録画設定を返す
+func (c CameraConfig) RecordingOptions() map[string]recorder.SourceOptions {
+	options := make(map[string]recorder.SourceOptions, len(c.Devices))
+	for _, device := range c.Devices {
+		if device.Device == "" {
+			continue
+		}
+		options[device.Device] = device.Recording
+	}
+	return options
+}
+
+// MotionOptions はデバイスパスをキーとした動体検知設定を返す
+func (c CameraConfig) MotionOptions() map[string]motion.SourceOptions {
+	options := make(map[string]motion.SourceOptions, len(c.Devices))
+	for _, device := range c.Devices {
+		if device.Device == "" {
+			continue
+		}
+		options[device.Device] = device.Motion
+	}
+	return options
+}
+
+// SourceConfigs はデバイスパスが指定されたカメラ設定をソース作成設定に変換する
+// 未指定の値はゼロのままにし、ソース作成時のデフォルト値に任せる
+func (c CameraConfig) SourceConfigs() []camera.SourceConfig {
+	configs := make([]camera.SourceConfig, 0, len(c.Devices))
+	for _, device := range c.Devices {
+		if device.Device == "" {
+			continue // デバイスパスがない設定は対象外（自動検出に任せる）
+		}
+		properties := make(map[string]interface{})
+		if device.CaptureBackend != "" {
+			properties[camera.PropertyCaptureBackend] = device.CaptureBackend
+		}
+		configs = append(configs, camera.SourceConfig{
+			ID:     device.ID,
+			Name:   device.Name,
+			Device: device.Device,
+			Settings: camera.VideoSettings{
+				Width:     device.Width,
+				Height:    device.Height,
+				FrameRate: device.FPS,
+			},
+			Properties: properties,
+		})
+	}
+	return configs
+}
+
+// validate はTLS設定の妥当性を検証する
+func (t TLSConfig) validate(port int) error {
+	switch t.Mode {
+	case "", TLSModeOff, TLSModeSelfSigned:
+	case TLSModeFile:
+		if t.CertFile == "" || t.KeyFile == "" {
+			return fmt.Errorf("mode が file の場合は cert_file と key_file を指定してください")
+		}
+	default:
+		return fmt.Errorf("無効なモード: %s（off / file / self_signed）", t.Mode)
+	}
+
+	if t.RedirectPort != 0 {
+		if !t.Enabled() {
+			return fmt.Errorf("redirect_port はHTTPSで配信する場合のみ指定できます")
+		}
+		if t.RedirectPort < 1 || t.RedirectPort > 65535 || t.RedirectPort == port {
+			return fmt.Errorf("無効なリダイレクト用のポート番号: %d", t.RedirectPort)
+		}
+	}
 	return nil
 }
 
@@ -91,6 +437,11 @@ func (c *Config) ServerAddress() string {
 	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
 }
 
+// RedirectAddress はHTTPからHTTPSへリダイレクトするサーバーのリッスンアドレスを返す
+func (c *Config) RedirectAddress() string {
+	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.TLS.RedirectPort)
+}
+
 // getEnvOrDefault は環境変数を取得し、設定されていない場合はデフォルト値を返す
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -2,7 +2,12 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"senrigan/internal/auth"
+	"senrigan/internal/camera"
 )
 
 // TestConfigLoad は設定の読み込みをテストする
@@ -141,6 +146,96 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectErr: false, // デバイスパスが空でも自動検出されるのでOK
 		},
+		{
+			name: "無効なキャプチャ方式",
+			config: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8009,
+				},
+				Camera: CameraConfig{
+					Devices: []CameraDevice{
+						{
+							ID:             "camera1",
+							Device:         "/dev/video0",
+							CaptureBackend: "gstreamer",
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "認証が有効でユーザー・トークンなし",
+			config: &Config{
+				Server: ServerConfig{Host: "localhost", Port: 8009},
+				Auth:   auth.Config{Enabled: true},
+			},
+			expectErr: true,
+		},
+		{
+			name: "認証ユーザーの無効な権限",
+			config: &Config{
+				Server: ServerConfig{Host: "localhost", Port: 8009},
+				Auth: auth.Config{
+					Enabled: true,
+					Users: []auth.User{
+						{Username: "admin", PasswordHash: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", Role: "root"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "認証トークンの不正なハッシュ",
+			config: &Config{
+				Server: ServerConfig{Host: "localhost", Port: 8009},
+				Auth: auth.Config{
+					Enabled: true,
+					Tokens:  []auth.Token{{Name: "backup", TokenHash: "secret", Role: auth.RoleViewer}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "自己署名証明書とリダイレクト",
+			config: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8443,
+					TLS:  TLSConfig{Mode: TLSModeSelfSigned, RedirectPort: 8009},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "TLSの無効なモード",
+			config: &Config{
+				Server: ServerConfig{Host: "localhost", Port: 8009, TLS: TLSConfig{Mode: "acme"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "TLSの証明書ファイル未指定",
+			config: &Config{
+				Server: ServerConfig{Host: "localhost", Port: 8009, TLS: TLSConfig{Mode: TLSModeFile, CertFile: "cert.pem"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "HTTP配信でリダイレクト用のポートを指定",
+			config: &Config{
+				Server: ServerConfig{Host: "localhost", Port: 8009, TLS: TLSConfig{Mode: TLSModeOff, RedirectPort: 8080}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "リダイレクト用のポートがHTTPSと重複",
+			config: &Config{
+				Server: ServerConfig{Host: "localhost", Port: 8443, TLS: TLSConfig{Mode: TLSModeSelfSigned, RedirectPort: 8443}},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -202,3 +297,189 @@ func TestEnvironmentVariables(t *testing.T) {
 		t.Errorf("環境変数のポートが反映されていません: got %d, want 9999", cfg.Server.Port)
 	}
 }
+
+// TestLoadFile は設定ファイルの読み込みをテストする
+func TestLoadFile(t *testing.T) {
+	yamlContent := `server:
+  host: 127.0.0.1
+  port: 8100
+  read_timeout: 30s
+camera:
+  default_fps: 10
+  devices:
+    - id: entrance
+      name: 玄関カメラ
+      device: /dev/video0
+      fps: 5
+      width: 640
+      height: 480
+      capture_backend: native
+timelapse:
+  capture_interval: 5s
+  quality: 4
+`
+	tomlContent := `[server]
+host = "127.0.0.1"
+port = 8100
+read_timeout = "30s"
+
+[camera]
+default_fps = 10
+
+[[camera.devices]]
+id = "entrance"
+name = "玄関カメラ"
+device = "/dev/video0"
+fps = 5
+width = 640
+height = 480
+capture_backend = "native"
+
+[timelapse]
+capture_interval = "5s"
+quality = 4
+`
+
+	testCases := []struct {
+		name     string
+		filename string
+		content  string
+	}{
+		{name: "YAML", filename: "config.yaml", content: yamlContent},
+		{name: "TOML", filename: "config.toml", content: tomlContent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.filename)
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("設定ファイルの作成に失敗しました: %v", err)
+			}
+
+			cfg, err := LoadFile(path)
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗しました: %v", err)
+			}
+
+			if cfg.Server.Host != "127.0.0.1" || cfg.Server.Port != 8100 {
+				t.Errorf("サーバー設定が反映されていません: %s", cfg.ServerAddress())
+			}
+			if cfg.Server.ReadTimeout != 30*time.Second {
+				t.Errorf("読み込みタイムアウトが反映されていません: %v", cfg.Server.ReadTimeout)
+			}
+
+			// ファイルに記述されていない項目はデフォルト値が維持される
+			if cfg.Camera.DefaultFPS != 10 {
+				t.Errorf("デフォルトFPSが反映されていません: %d", cfg.Camera.DefaultFPS)
+			}
+			if cfg.Camera.DefaultWidth != 1280 {
+				t.Errorf("デフォルト幅が維持されていません: %d", cfg.Camera.DefaultWidth)
+			}
+			if cfg.Timelapse.CaptureInterval != 5*time.Second || cfg.Timelapse.Quality != 4 {
+				t.Errorf("タイムラプス設定が反映されていません: %+v", cfg.Timelapse)
+			}
+			if !cfg.Timelapse.Enabled {
+				t.Error("タイムラプスの有効設定が維持されていません")
+			}
+
+			sourceConfigs := cfg.Camera.SourceConfigs()
+			if len(sourceConfigs) != 1 {
+				t.Fatalf("カメラ設定の数が一致しません: got %d, want 1", len(sourceConfigs))
+			}
+			source := sourceConfigs[0]
+			if source.ID != "entrance" || source.Name != "玄関カメラ" || source.Device != "/dev/video0" {
+				t.Errorf("カメラ設定が反映されていません: %+v", source)
+			}
+			if source.Settings.FrameRate != 5 || source.Settings.Width != 640 || source.Settings.Height != 480 {
+				t.Errorf("カメラの映像設定が反映されていません: %+v", source.Settings)
+			}
+			if source.Properties[camera.PropertyCaptureBackend] != "native" {
+				t.Errorf("キャプチャ方式が反映されていません: %+v", source.Properties)
+			}
+		})
+	}
+}
+
+// TestLoadFileEnvironmentOverride は環境変数が設定ファイルより優先されることをテストする
+func TestLoadFileEnvironmentOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte("server:\n  host: 127.0.0.1\n  port: 8100\n"), 0644); err != nil {
+		t.Fatalf("設定ファイルの作成に失敗しました: %v", err)
+	}
+
+	t.Setenv("PORT", "9100")
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("設定ファイルの読み込みに失敗しました: %v", err)
+	}
+
+	if cfg.Server.Host != "127.0.0.1" {
+		t.Errorf("設定ファイルのホストが反映されていません: got %s", cfg.Server.Host)
+	}
+	if cfg.Server.Port != 9100 {
+		t.Errorf("環境変数のポートが優先されていません: got %d, want 9100", cfg.Server.Port)
+	}
+}
+
+// TestLoadFileErrors は不正な設定ファイルのエラーをテストする
+func TestLoadFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name     string
+		filename string
+		content  string
+	}{
+		{name: "未対応の拡張子", filename: "config.json", content: "{}"},
+		{name: "不正なYAML", filename: "broken.yaml", content: "server: [\n"},
+		{name: "重複したカメラID", filename: "duplicate.yaml", content: "camera:\n  devices:\n    - id: cam\n      device: /dev/video0\n    - id: cam\n      device: /dev/video2\n"},
+		{name: "範囲外の動体検知感度", filename: "sensitivity.yaml", content: "motion:\n  sensitivity: 150\n"},
+		{name: "不正な録画時間帯", filename: "schedule.yaml", content: "camera:\n  devices:\n    - device: /dev/video0\n      recording:\n        clips:\n          enabled: true\n          schedule:\n            - {start: \"25:00\", end: \"08:00\"}\n"},
+		{name: "範囲外のマスク領域", filename: "mask.yaml", content: "camera:\n  devices:\n    - device: /dev/video0\n      motion:\n        enabled: true\n        masks:\n          - {x: 0.5, y: 0, width: 0.8, height: 1}\n"},
+		{name: "無効なタイムラプスの出力", filename: "timelapse.yaml", content: "timelapse:\n  output: separate\n"},
+		{name: "無効な文字の描画位置", filename: "overlay.yaml", content: "timelapse:\n  overlay:\n    enabled: true\n    position: center\n"},
+		{name: "存在しないフォントファイル", filename: "font.yaml", content: "timelapse:\n  overlay:\n    font_file: /nonexistent/font.ttf\n"},
+		{name: "負の録画の保持期間", filename: "recording.yaml", content: "recording:\n  retention_days: -1\n"},
+		{name: "負のフレームの保存サイズの上限", filename: "storage.yaml", content: "timelapse:\n  max_frame_storage_mb: -1\n"},
+		{name: "無効な拡大縮小の方法", filename: "scale.yaml", content: "timelapse:\n  scale: crop\n"},
+		{name: "無効な補間フィルタ", filename: "resampling.yaml", content: "timelapse:\n  resampling: lanczos\n"},
+		{name: "存在しないレイアウト", filename: "layout.yaml", content: "timelapse:\n  layout: lobby\n"},
+		{name: "格子の外のセル", filename: "cell.yaml", content: "timelapse:\n  layouts:\n    lobby:\n      type: grid\n      cols: 2\n      rows: 2\n      cells:\n        - {source: cam1, col: 1, row: 0, col_span: 2}\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.filename)
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("設定ファイルの作成に失敗しました: %v", err)
+			}
+
+			if _, err := LoadFile(path); err == nil {
+				t.Error("エラーが期待されましたが、エラーが発生しませんでした")
+			}
+		})
+	}
+
+	// 存在しないファイル
+	if _, err := LoadFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("存在しないファイルでエラーが発生しませんでした")
+	}
+}
+
+// TestTLSFiles は証明書ファイルのパスの決定をテストする
+func TestTLSFiles(t *testing.T) {
+	certFile, keyFile := TLSConfig{Mode: TLSModeSelfSigned}.Files()
+	if certFile != "./data/tls/cert.pem" || keyFile != "./data/tls/key.pem" {
+		t.Errorf("自己署名証明書のデフォルトの保存先が一致しません: %s, %s", certFile, keyFile)
+	}
+
+	certFile, keyFile = TLSConfig{Mode: TLSModeFile, CertFile: "/etc/senrigan/cert.pem", KeyFile: "/etc/senrigan/key.pem"}.Files()
+	if certFile != "/etc/senrigan/cert.pem" || keyFile != "/etc/senrigan/key.pem" {
+		t.Errorf("指定した証明書ファイルが使われていません: %s, %s", certFile, keyFile)
+	}
+
+	if (TLSConfig{Mode: TLSModeOff}).Enabled() {
+		t.Error("off の場合はHTTPSを無効にする必要があります")
+	}
+}
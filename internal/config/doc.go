@@ -4,13 +4,13 @@
 // 設定ファイルからの読み込みや環境変数からの設定値の取得を行います。
 //
 // 責務:
-//   - 設定ファイル（config.yaml）の読み込み
+//   - 設定ファイル（config.yaml / config.toml）の読み込み
 //   - 環境変数からの設定値のオーバーライド
 //   - デフォルト値の提供
 //   - 設定値の検証
 //
 // 仕様:
-//   - YAMLファイルベースの設定
+//   - YAML/TOMLファイルベースの設定（拡張子で判別）
 //   - 環境変数による設定値のオーバーライド対応
 //   - 必須項目のバリデーション
 package config
@@ -7,6 +7,25 @@ import (
 	"time"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+	CookieAuthScopes = "cookieAuth.Scopes"
+)
+
+// Defines values for AuthUserMethod.
+const (
+	None    AuthUserMethod = "none"
+	Session AuthUserMethod = "session"
+	Token   AuthUserMethod = "token"
+)
+
+// Defines values for AuthUserRole.
+const (
+	Admin    AuthUserRole = "admin"
+	Operator AuthUserRole = "operator"
+	Viewer   AuthUserRole = "viewer"
+)
+
 // Defines values for CameraInfoStatus.
 const (
 	CameraInfoStatusActive   CameraInfoStatus = "active"
@@ -14,11 +33,66 @@ const (
 	CameraInfoStatusInactive CameraInfoStatus = "inactive"
 )
 
+// Defines values for ConfigOutput.
+const (
+	Both      ConfigOutput = "both"
+	Combined  ConfigOutput = "combined"
+	PerSource ConfigOutput = "per_source"
+)
+
+// Defines values for ConfigResampling.
+const (
+	Bilinear   ConfigResampling = "bilinear"
+	CatmullRom ConfigResampling = "catmull_rom"
+	Nearest    ConfigResampling = "nearest"
+)
+
+// Defines values for ConfigScale.
+const (
+	Fill    ConfigScale = "fill"
+	Fit     ConfigScale = "fit"
+	Stretch ConfigScale = "stretch"
+)
+
 // Defines values for HealthResponseStatus.
 const (
 	Healthy HealthResponseStatus = "healthy"
 )
 
+// Defines values for MosaicLayoutType.
+const (
+	Auto  MosaicLayoutType = "auto"
+	Focus MosaicLayoutType = "focus"
+	Grid  MosaicLayoutType = "grid"
+	Pip   MosaicLayoutType = "pip"
+)
+
+// Defines values for OverlayConfigPosition.
+const (
+	BottomLeft  OverlayConfigPosition = "bottom_left"
+	BottomRight OverlayConfigPosition = "bottom_right"
+	TopLeft     OverlayConfigPosition = "top_left"
+	TopRight    OverlayConfigPosition = "top_right"
+)
+
+// Defines values for StreamControlMessageType.
+const (
+	Pause         StreamControlMessageType = "pause"
+	Ping          StreamControlMessageType = "ping"
+	Resume        StreamControlMessageType = "resume"
+	SetFps        StreamControlMessageType = "set_fps"
+	SetResolution StreamControlMessageType = "set_resolution"
+)
+
+// Defines values for StreamServerMessageType.
+const (
+	StreamServerMessageTypeAck   StreamServerMessageType = "ack"
+	StreamServerMessageTypeError StreamServerMessageType = "error"
+	StreamServerMessageTypeFrame StreamServerMessageType = "frame"
+	StreamServerMessageTypeHello StreamServerMessageType = "hello"
+	StreamServerMessageTypePong  StreamServerMessageType = "pong"
+)
+
 // Defines values for SystemStatusResponseStatus.
 const (
 	Running  SystemStatusResponseStatus = "running"
@@ -34,10 +108,67 @@ const (
 	VideoStatusRecording VideoStatus = "recording"
 )
 
+// AuthUser 認証された利用者
+type AuthUser struct {
+	// Cameras 閲覧できるカメラのIDまたはパターン（省略時は全てのカメラ）
+	Cameras *[]string `json:"cameras,omitempty"`
+
+	// ExpiresAt セッションの有効期限（セッション以外は省略）
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Method 認証方式（none は認証が無効）
+	Method AuthUserMethod `json:"method"`
+
+	// Role 権限（viewer は閲覧のみ、operator はカメラの開始・停止・設定変更、admin はカメラの追加・削除も可能）
+	Role AuthUserRole `json:"role"`
+
+	// Username ユーザー名またはAPIトークンの名前
+	Username string `json:"username"`
+}
+
+// AuthUserMethod 認証方式（none は認証が無効）
+type AuthUserMethod string
+
+// AuthUserRole 権限（viewer は閲覧のみ、operator はカメラの開始・停止・設定変更、admin はカメラの追加・削除も可能）
+type AuthUserRole string
+
+// CameraCapabilities defines model for CameraCapabilities.
+type CameraCapabilities struct {
+	// Formats 対応フォーマット
+	Formats []string `json:"formats"`
+
+	// FrameRates 対応フレームレート（fps）
+	FrameRates []int `json:"frame_rates"`
+
+	// Resolutions 対応解像度
+	Resolutions []Resolution `json:"resolutions"`
+}
+
+// CameraHealth 監視による障害検出・自動再起動の状態
+type CameraHealth struct {
+	// LastError 最後に検出したエラー（プロセスの終了・フレームの途絶等）
+	LastError *string `json:"last_error,omitempty"`
+
+	// LastErrorAt 最後にエラーを検出した時刻
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+
+	// LastRestartAt 最後に自動再起動した時刻
+	LastRestartAt *time.Time `json:"last_restart_at,omitempty"`
+
+	// NextRestartAt 次に再起動を試みる時刻（エラー状態の場合のみ）
+	NextRestartAt *time.Time `json:"next_restart_at,omitempty"`
+
+	// RestartCount 障害検出による自動再起動の回数
+	RestartCount int `json:"restart_count"`
+}
+
 // CameraInfo defines model for CameraInfo.
 type CameraInfo struct {
+	Capabilities *CameraCapabilities `json:"capabilities,omitempty"`
+
 	// Device カメラデバイスのパス
-	Device string `json:"device"`
+	Device string        `json:"device"`
+	Health *CameraHealth `json:"health,omitempty"`
 
 	// Id カメラの一意識別子
 	Id string `json:"id"`
@@ -48,6 +179,9 @@ type CameraInfo struct {
 
 	// Status カメラの動作状態
 	Status *CameraInfoStatus `json:"status,omitempty"`
+
+	// Type ソースタイプ
+	Type *string `json:"type,omitempty"`
 }
 
 // CameraInfoStatus カメラの動作状態
@@ -55,16 +189,85 @@ type CameraInfoStatus string
 
 // CameraSettings defines model for CameraSettings.
 type CameraSettings struct {
+	// Format 入力フォーマット
+	Format *string `json:"format,omitempty"`
+
 	// Fps フレームレート（fps）
 	Fps int `json:"fps"`
 
 	// Height 画像の高さ（ピクセル）
 	Height int `json:"height"`
 
+	// Properties ソース固有の追加設定
+	Properties *map[string]interface{} `json:"properties,omitempty"`
+
+	// Quality JPEG品質（ffmpegの -q:v、小さいほど高画質）
+	Quality *int `json:"quality,omitempty"`
+
 	// Width 画像の幅（ピクセル）
 	Width int `json:"width"`
 }
 
+// CameraSettingsUpdate 変更する映像設定（指定した項目のみ変更）
+type CameraSettingsUpdate struct {
+	// Format 入力フォーマット
+	Format *string `json:"format,omitempty"`
+
+	// Fps フレームレート（fps）
+	Fps *int `json:"fps,omitempty"`
+
+	// Height 画像の高さ（ピクセル）
+	Height *int `json:"height,omitempty"`
+
+	// Properties ソース固有の追加設定（指定したキーのみ上書き）
+	Properties *map[string]interface{} `json:"properties,omitempty"`
+
+	// Quality JPEG品質（ffmpegの -q:v、小さいほど高画質）
+	Quality *int `json:"quality,omitempty"`
+
+	// Width 画像の幅（ピクセル）
+	Width *int `json:"width,omitempty"`
+}
+
+// CameraStats カメラの配信統計（フレームレート・ビットレートは直近5秒間の実測値）
+type CameraStats struct {
+	// AverageFrameSize 平均フレームサイズ（バイト）
+	AverageFrameSize int `json:"average_frame_size"`
+
+	// Bitrate 実測ビットレート（bps）
+	Bitrate float64 `json:"bitrate"`
+
+	// CameraId カメラID
+	CameraId string `json:"camera_id"`
+
+	// CaptureCommand キャプチャに使っているffmpegのコマンドライン（ffmpegを使わない場合は省略）
+	CaptureCommand *string `json:"capture_command,omitempty"`
+
+	// Fps 実測フレームレート
+	Fps float64 `json:"fps"`
+
+	// FramesDropped クライアントの受信が追いつかずに破棄したフレームの総数
+	FramesDropped int64 `json:"frames_dropped"`
+
+	// FramesPublished 配信したフレームの総数
+	FramesPublished int64 `json:"frames_published"`
+
+	// LastFrameAt 最後にフレームを取得した時刻
+	LastFrameAt *time.Time `json:"last_frame_at,omitempty"`
+
+	// RestartCount 障害検出による自動再起動の回数
+	RestartCount int `json:"restart_count"`
+
+	// StartedAt キャプチャを開始した時刻（停止中は省略）
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// Subscribers 現在の配信先（ストリーム・録画・動体検知等）の数
+	Subscribers int `json:"subscribers"`
+
+	// UptimeSeconds キャプチャを開始してからの秒数（停止中は0）
+	UptimeSeconds int64 `json:"uptime_seconds"`
+}
+
 // CamerasResponse defines model for CamerasResponse.
 type CamerasResponse struct {
 	// Cameras カメラ情報の配列
@@ -79,23 +282,73 @@ type Config struct {
 	// Enabled タイムラプス有効/無効
 	Enabled *bool `json:"enabled,omitempty"`
 
-	// MaxFrameBuffer 最大フレームバッファサイズ
+	// Layout 結合フレームに使用するレイアウト名（auto は映像ソース数に合わせた格子）
+	Layout *string `json:"layout,omitempty"`
+
+	// MaxFrameBuffer 動画に追加していないフレームの上限（超えると更新間隔を待たずに動画を更新）
 	MaxFrameBuffer *int `json:"max_frame_buffer,omitempty"`
 
+	// MaxFrameStorageMb 保存したフレームの合計サイズの上限（MB、0は無制限）。超えると保持期間内でも古い日付から削除する
+	MaxFrameStorageMb *int `json:"max_frame_storage_mb,omitempty"`
+
+	// Output 出力する動画（combined は結合した動画、per_source は映像ソース毎の動画、both は両方）
+	Output *ConfigOutput `json:"output,omitempty"`
+
 	// OutputFormat 出力フォーマット
 	OutputFormat *string `json:"output_format,omitempty"`
 
+	// Overlay フレームに描画する文字（結合フレームではタイル毎、映像ソース毎のフレームではフレーム全体に描画）
+	Overlay *OverlayConfig `json:"overlay,omitempty"`
+
 	// Quality 動画品質 (1-5)
-	Quality    *int        `json:"quality,omitempty"`
-	Resolution *Resolution `json:"resolution,omitempty"`
+	Quality *int `json:"quality,omitempty"`
+
+	// Resampling 拡大縮小の補間フィルタ（catmull_rom は最も鮮明で、bilinear より処理に時間がかかる）
+	Resampling *ConfigResampling `json:"resampling,omitempty"`
+	Resolution *Resolution       `json:"resolution,omitempty"`
 
-	// RetentionDays 保持期間（日数）
+	// RetentionDays 保存したフレームの保持期間（日数、0は無期限）
 	RetentionDays *int `json:"retention_days,omitempty"`
 
+	// Scale タイルとアスペクト比が異なる映像の拡大縮小（fit は余白を黒で塗りつぶして収める、
+	// fill ははみ出す部分を切り取って埋める、stretch は引き伸ばす）
+	Scale *ConfigScale `json:"scale,omitempty"`
+
 	// UpdateInterval 動画更新間隔
 	UpdateInterval *string `json:"update_interval,omitempty"`
 }
 
+// ConfigOutput 出力する動画（combined は結合した動画、per_source は映像ソース毎の動画、both は両方）
+type ConfigOutput string
+
+// ConfigResampling 拡大縮小の補間フィルタ（catmull_rom は最も鮮明で、bilinear より処理に時間がかかる）
+type ConfigResampling string
+
+// ConfigScale タイルとアスペクト比が異なる映像の拡大縮小（fit は余白を黒で塗りつぶして収める、
+// fill ははみ出す部分を切り取って埋める、stretch は引き伸ばす）
+type ConfigScale string
+
+// CreateCameraRequest defines model for CreateCameraRequest.
+type CreateCameraRequest struct {
+	// Device デバイスパス（USBカメラでは必須）
+	Device *string `json:"device,omitempty"`
+
+	// Id カメラID（省略時はデバイスから生成）
+	Id *string `json:"id,omitempty"`
+
+	// Name 表示名（省略時はデバイスから生成）
+	Name *string `json:"name,omitempty"`
+
+	// Settings 変更する映像設定（指定した項目のみ変更）
+	Settings *CameraSettingsUpdate `json:"settings,omitempty"`
+
+	// Start 追加後にキャプチャを開始するか
+	Start *bool `json:"start,omitempty"`
+
+	// Type ソースタイプ（usb_camera、x11_screen 等の登録済みタイプ）
+	Type string `json:"type"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	// Details エラーの詳細情報（開発用）
@@ -108,6 +361,21 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
+// EventsResponse defines model for EventsResponse.
+type EventsResponse struct {
+	// Events イベントの配列
+	Events []MotionEvent `json:"events"`
+}
+
+// FramesResponse defines model for FramesResponse.
+type FramesResponse struct {
+	// Frames フレームの配列（撮影順）
+	Frames []TimelapseFrame `json:"frames"`
+
+	// Total 期間内のフレーム数（間引く前）
+	Total int `json:"total"`
+}
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
 	// Status サーバーの稼働状況
@@ -117,6 +385,129 @@ type HealthResponse struct {
 // HealthResponseStatus サーバーの稼働状況
 type HealthResponseStatus string
 
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	// Password パスワード
+	Password string `json:"password"`
+
+	// Username ユーザー名
+	Username string `json:"username"`
+}
+
+// MosaicLayout 結合フレームのレイアウト
+type MosaicLayout struct {
+	// Cells grid の映像ソース毎のセル（指定のない映像ソースは空いたセルに名前順で配置）
+	Cells *[]MosaicLayoutCell `json:"cells,omitempty"`
+
+	// Cols grid の列数
+	Cols *int `json:"cols,omitempty"`
+
+	// Main pip・focus で大きく表示する映像ソースID（省略時は名前順で最初の映像ソース）
+	Main *string `json:"main,omitempty"`
+
+	// Name レイアウト名
+	Name string `json:"name"`
+
+	// Position pip の小窓を並べる角（top_left 等）、focus の帯の位置（right, bottom）
+	Position *string `json:"position,omitempty"`
+
+	// Rows grid の行数
+	Rows *int `json:"rows,omitempty"`
+
+	// Size pip の小窓の幅、focus の帯の幅（出力に対する割合）
+	Size *float64 `json:"size,omitempty"`
+
+	// Type 種類（auto は映像ソース数に合わせた格子、grid はセルを指定した格子、
+	// pip はメインの映像ソースと小窓、focus はメインの映像ソースと他の映像ソースの帯）
+	Type MosaicLayoutType `json:"type"`
+}
+
+// MosaicLayoutType 種類（auto は映像ソース数に合わせた格子、grid はセルを指定した格子、
+// pip はメインの映像ソースと小窓、focus はメインの映像ソースと他の映像ソースの帯）
+type MosaicLayoutType string
+
+// MosaicLayoutCell defines model for MosaicLayoutCell.
+type MosaicLayoutCell struct {
+	// Col 列（0から）
+	Col int `json:"col"`
+
+	// ColSpan 横に結合するセル数
+	ColSpan *int `json:"col_span,omitempty"`
+
+	// Row 行（0から）
+	Row int `json:"row"`
+
+	// RowSpan 縦に結合するセル数
+	RowSpan *int `json:"row_span,omitempty"`
+
+	// Source 映像ソースID
+	Source string `json:"source"`
+}
+
+// MosaicLayoutsResponse defines model for MosaicLayoutsResponse.
+type MosaicLayoutsResponse struct {
+	// Active 使用中のレイアウト名
+	Active  string         `json:"active"`
+	Layouts []MosaicLayout `json:"layouts"`
+}
+
+// MotionEvent defines model for MotionEvent.
+type MotionEvent struct {
+	// CameraId 動きを検知したカメラのID
+	CameraId string `json:"camera_id"`
+
+	// CameraName 動きを検知したカメラの表示名
+	CameraName string `json:"camera_name"`
+
+	// EndTime 最後に動きを検知した時刻
+	EndTime time.Time `json:"end_time"`
+
+	// Id イベントID
+	Id string `json:"id"`
+
+	// InProgress 動きが継続中か
+	InProgress bool `json:"in_progress"`
+
+	// PeakScore イベント中の最大スコア（変化した画素の割合 0-1）
+	PeakScore float64 `json:"peak_score"`
+
+	// SnapshotUrl スナップショット画像のURL
+	SnapshotUrl string `json:"snapshot_url"`
+
+	// StartTime 最初に動きを検知した時刻
+	StartTime time.Time `json:"start_time"`
+}
+
+// OverlayConfig フレームに描画する文字（結合フレームではタイル毎、映像ソース毎のフレームではフレーム全体に描画）
+type OverlayConfig struct {
+	// Background 文字の背景に半透明の四角形を描画する
+	Background *bool `json:"background,omitempty"`
+
+	// CameraName カメラ名を描画する
+	CameraName *bool `json:"camera_name,omitempty"`
+
+	// Enabled 文字の描画の有効/無効
+	Enabled bool `json:"enabled"`
+
+	// FontFile TrueType/OpenTypeフォントファイル（省略時は内蔵フォント）
+	FontFile *string `json:"font_file,omitempty"`
+
+	// FontSize 文字の大きさ（ピクセル、0は画像の高さに合わせる）
+	FontSize *int `json:"font_size,omitempty"`
+
+	// Position 描画位置
+	Position *OverlayConfigPosition `json:"position,omitempty"`
+
+	// Text 任意の文字列
+	Text *string `json:"text,omitempty"`
+
+	// Timestamp 撮影時刻を描画する
+	Timestamp *bool `json:"timestamp,omitempty"`
+}
+
+// OverlayConfigPosition 描画位置
+type OverlayConfigPosition string
+
 // Resolution defines model for Resolution.
 type Resolution struct {
 	// Height 高さ（ピクセル）
@@ -135,6 +526,12 @@ type ServerInfo struct {
 	Port int `json:"port"`
 }
 
+// SetLayoutRequest defines model for SetLayoutRequest.
+type SetLayoutRequest struct {
+	// Name 使用するレイアウト名
+	Name string `json:"name"`
+}
+
 // StatusResponse defines model for StatusResponse.
 type StatusResponse struct {
 	// ActiveSources アクティブな映像ソース数
@@ -159,6 +556,60 @@ type StatusResponse struct {
 	TotalVideos *int `json:"total_videos,omitempty"`
 }
 
+// StreamControlMessage WebSocketストリームでクライアントから送信する制御メッセージ
+type StreamControlMessage struct {
+	// Fps 最大フレームレート（set_fps、0で制限なし）
+	Fps *int `json:"fps,omitempty"`
+
+	// Height 最大高さ（set_resolution、0で制限なし）
+	Height *int `json:"height,omitempty"`
+
+	// Type 制御の種類
+	Type StreamControlMessageType `json:"type"`
+
+	// Width 最大幅（set_resolution、0で制限なし）
+	Width *int `json:"width,omitempty"`
+}
+
+// StreamControlMessageType 制御の種類
+type StreamControlMessageType string
+
+// StreamServerMessage WebSocketストリームでサーバーから送信するテキストメッセージ
+type StreamServerMessage struct {
+	// CameraId カメラID（hello）
+	CameraId *string `json:"camera_id,omitempty"`
+
+	// Fps 最大フレームレートの設定（hello / ack）
+	Fps *int `json:"fps,omitempty"`
+
+	// Height フレームの高さ（frame）、または最大高さの設定（hello / ack）
+	Height *int `json:"height,omitempty"`
+
+	// Message エラーメッセージ（error）
+	Message *string `json:"message,omitempty"`
+
+	// Paused 一時停止中か（hello / ack）
+	Paused *bool `json:"paused,omitempty"`
+
+	// Sequence フレームの連番（frame）
+	Sequence *int64 `json:"sequence,omitempty"`
+
+	// Size 直後に送信するJPEGのバイト数（frame）
+	Size *int `json:"size,omitempty"`
+
+	// Timestamp フレームのキャプチャ時刻（frame）、または送信時刻（pong）
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+
+	// Type メッセージの種類
+	Type StreamServerMessageType `json:"type"`
+
+	// Width フレームの幅（frame）、または最大幅の設定（hello / ack）
+	Width *int `json:"width,omitempty"`
+}
+
+// StreamServerMessageType メッセージの種類
+type StreamServerMessageType string
+
 // SystemStatusResponse defines model for SystemStatusResponse.
 type SystemStatusResponse struct {
 	// Cameras 設定されているカメラの台数
@@ -172,15 +623,51 @@ type SystemStatusResponse struct {
 // SystemStatusResponseStatus システムの動作状態
 type SystemStatusResponseStatus string
 
+// TimelapseFrame defines model for TimelapseFrame.
+type TimelapseFrame struct {
+	// SourceId 映像ソースID（結合フレームは省略）
+	SourceId *string `json:"source_id,omitempty"`
+
+	// ThumbnailUrl サムネイル画像のURL
+	ThumbnailUrl string `json:"thumbnail_url"`
+
+	// Timestamp 撮影時刻
+	Timestamp time.Time `json:"timestamp"`
+
+	// Url フレーム画像のURL
+	Url string `json:"url"`
+}
+
+// TriggerResponse defines model for TriggerResponse.
+type TriggerResponse struct {
+	// CameraId カメラID
+	CameraId string `json:"camera_id"`
+
+	// Extended 録画中のクリップを延長した場合はtrue
+	Extended bool `json:"extended"`
+
+	// FileName 録画中のクリップのファイル名
+	FileName string `json:"file_name"`
+
+	// StartTime クリップの開始時刻（プリロールを含む）
+	StartTime time.Time `json:"start_time"`
+
+	// Triggers クリップを開始・延長したトリガーの種類
+	Triggers []string `json:"triggers"`
+
+	// Until 録画を続ける予定の時刻
+	Until time.Time `json:"until"`
+}
+
 // Video defines model for Video.
 type Video struct {
 	// Date 作成日時
 	Date time.Time `json:"date"`
 
-	// Duration 動画時間
+	// Duration 動画時間（撮影時刻の記録がある動画のみ）
 	Duration *string `json:"duration,omitempty"`
 
-	// EndTime 録画終了時刻
+	// EndTime 最後のフレームの撮影時刻（撮影時刻の記録がある動画のみ）
 	EndTime *time.Time `json:"end_time,omitempty"`
 
 	// FilePath ファイルパス
@@ -189,13 +676,16 @@ type Video struct {
 	// FileSize ファイルサイズ（バイト）
 	FileSize int64 `json:"file_size"`
 
-	// FrameCount 総フレーム数
+	// FrameCount 総フレーム数（撮影時刻の記録がある動画のみ）
 	FrameCount *int `json:"frame_count,omitempty"`
 
 	// SourceCount 結合された映像ソース数
 	SourceCount *int `json:"source_count,omitempty"`
 
-	// StartTime 録画開始時刻
+	// SourceId 映像ソースID（映像ソース毎の動画のみ、結合した動画は省略）
+	SourceId *string `json:"source_id,omitempty"`
+
+	// StartTime 最初のフレームの撮影時刻（撮影時刻の記録がある動画のみ）
 	StartTime *time.Time `json:"start_time,omitempty"`
 
 	// Status タイムラプス状態
@@ -207,3 +697,103 @@ type VideoStatus string
 
 // VideoList defines model for VideoList.
 type VideoList = []Video
+
+// VideoSeekResponse defines model for VideoSeekResponse.
+type VideoSeekResponse struct {
+	// FrameTimestamp 再生位置のフレームの撮影時刻（指定した時刻に最も近いフレーム）
+	FrameTimestamp time.Time `json:"frame_timestamp"`
+
+	// Offset 動画の再生位置（秒）
+	Offset float64 `json:"offset"`
+
+	// Url 再生位置を指定した動画のURL（メディアフラグメント `#t=` 付き）
+	Url   string `json:"url"`
+	Video Video  `json:"video"`
+}
+
+// GetCameraSnapshotParams defines parameters for GetCameraSnapshot.
+type GetCameraSnapshotParams struct {
+	// Width 最大幅（ピクセル）
+	Width *int `form:"width,omitempty" json:"width,omitempty"`
+
+	// Height 最大高さ（ピクセル）
+	Height *int `form:"height,omitempty" json:"height,omitempty"`
+
+	// Quality JPEG品質（指定時は再エンコードする）
+	Quality *int `form:"quality,omitempty" json:"quality,omitempty"`
+}
+
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
+	// CameraId カメラIDで絞り込み
+	CameraId *string `form:"cameraId,omitempty" json:"cameraId,omitempty"`
+
+	// From この時刻以降に終了したイベントに絞り込み
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To この時刻以前に開始したイベントに絞り込み
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit 最大件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetMosaicParams defines parameters for GetMosaic.
+type GetMosaicParams struct {
+	// Layout 使用するレイアウト名（省略時はタイムラプスで使用中のレイアウト）
+	Layout *string `form:"layout,omitempty" json:"layout,omitempty"`
+}
+
+// GetTimelapseFramesParams defines parameters for GetTimelapseFrames.
+type GetTimelapseFramesParams struct {
+	// Source 映像ソースID（省略時は結合フレーム）
+	Source *string `form:"source,omitempty" json:"source,omitempty"`
+
+	// From この時刻以降に撮影したフレームに絞り込み（省略時は当日の0時）
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To この時刻以前に撮影したフレームに絞り込み（省略時は現在）
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit 最大件数
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTimelapseFrameParams defines parameters for GetTimelapseFrame.
+type GetTimelapseFrameParams struct {
+	// Source 映像ソースID（省略時は結合フレーム）
+	Source *string `form:"source,omitempty" json:"source,omitempty"`
+
+	// Width 最大幅（ピクセル）
+	Width *int `form:"width,omitempty" json:"width,omitempty"`
+
+	// Height 最大高さ（ピクセル）
+	Height *int `form:"height,omitempty" json:"height,omitempty"`
+}
+
+// GetTimelapseVideosParams defines parameters for GetTimelapseVideos.
+type GetTimelapseVideosParams struct {
+	// Source 映像ソースIDで絞り込み（指定した映像ソース毎の動画のみ）
+	Source *string `form:"source,omitempty" json:"source,omitempty"`
+}
+
+// SeekTimelapseVideoParams defines parameters for SeekTimelapseVideo.
+type SeekTimelapseVideoParams struct {
+	// At 再生したい時刻（RFC 3339）
+	At time.Time `form:"at" json:"at"`
+
+	// Source 映像ソースID（省略時は結合した動画）
+	Source *string `form:"source,omitempty" json:"source,omitempty"`
+}
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginRequest
+
+// CreateCameraJSONRequestBody defines body for CreateCamera for application/json ContentType.
+type CreateCameraJSONRequestBody = CreateCameraRequest
+
+// UpdateCameraSettingsJSONRequestBody defines body for UpdateCameraSettings for application/json ContentType.
+type UpdateCameraSettingsJSONRequestBody = CameraSettingsUpdate
+
+// SetTimelapseLayoutJSONRequestBody defines body for SetTimelapseLayout for application/json ContentType.
+type SetTimelapseLayoutJSONRequestBody = SetLayoutRequest
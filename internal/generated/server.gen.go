@@ -6,6 +6,7 @@ package generated
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
@@ -13,27 +14,87 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// ログイン
+	// (POST /api/auth/login)
+	Login(c *gin.Context)
+	// ログアウト
+	// (POST /api/auth/logout)
+	Logout(c *gin.Context)
+	// ログイン中の利用者取得
+	// (GET /api/auth/me)
+	GetCurrentUser(c *gin.Context)
 	// カメラ一覧取得
 	// (GET /api/cameras)
 	GetCameras(c *gin.Context)
+	// カメラ追加
+	// (POST /api/cameras)
+	CreateCamera(c *gin.Context)
+	// カメラ削除
+	// (DELETE /api/cameras/{cameraId})
+	DeleteCamera(c *gin.Context, cameraId string)
+	// カメラ詳細取得
+	// (GET /api/cameras/{cameraId})
+	GetCamera(c *gin.Context, cameraId string)
+	// カメラ設定変更
+	// (PATCH /api/cameras/{cameraId})
+	UpdateCameraSettings(c *gin.Context, cameraId string)
+	// カメラスナップショット取得
+	// (GET /api/cameras/{cameraId}/snapshot)
+	GetCameraSnapshot(c *gin.Context, cameraId string, params GetCameraSnapshotParams)
+	// カメラ開始
+	// (POST /api/cameras/{cameraId}/start)
+	StartCamera(c *gin.Context, cameraId string)
+	// カメラ統計取得
+	// (GET /api/cameras/{cameraId}/stats)
+	GetCameraStats(c *gin.Context, cameraId string)
+	// カメラ停止
+	// (POST /api/cameras/{cameraId}/stop)
+	StopCamera(c *gin.Context, cameraId string)
 	// カメラMJPEGストリーム
 	// (GET /api/cameras/{cameraId}/stream)
 	GetCameraStream(c *gin.Context, cameraId string)
+	// イベント録画トリガー
+	// (POST /api/cameras/{cameraId}/trigger)
+	TriggerCameraRecording(c *gin.Context, cameraId string)
 	// カメラWebSocketストリーム
 	// (GET /api/cameras/{cameraId}/ws)
 	GetCameraWebSocket(c *gin.Context, cameraId string)
+	// 動体検知イベント一覧取得
+	// (GET /api/events)
+	GetEvents(c *gin.Context, params GetEventsParams)
+	// 動体検知イベントのスナップショット取得
+	// (GET /api/events/{eventId}/snapshot)
+	GetEventSnapshot(c *gin.Context, eventId string)
+	// カメラのモザイク画像取得
+	// (GET /api/mosaic)
+	GetMosaic(c *gin.Context, params GetMosaicParams)
 	// システム状態取得
 	// (GET /api/status)
 	GetStatus(c *gin.Context)
 	// タイムラプス設定取得
 	// (GET /api/timelapse/config)
 	GetTimelapseConfig(c *gin.Context)
+	// タイムラプスのフレーム一覧取得
+	// (GET /api/timelapse/frames)
+	GetTimelapseFrames(c *gin.Context, params GetTimelapseFramesParams)
+	// タイムラプスのフレーム取得
+	// (GET /api/timelapse/frames/{timestamp})
+	GetTimelapseFrame(c *gin.Context, timestamp time.Time, params GetTimelapseFrameParams)
+	// タイムラプスのレイアウト変更
+	// (PUT /api/timelapse/layout)
+	SetTimelapseLayout(c *gin.Context)
+	// タイムラプスのレイアウト一覧取得
+	// (GET /api/timelapse/layouts)
+	GetTimelapseLayouts(c *gin.Context)
 	// タイムラプスシステム状態
 	// (GET /api/timelapse/status)
 	GetTimelapseStatus(c *gin.Context)
 	// タイムラプス動画一覧取得
 	// (GET /api/timelapse/videos)
-	GetTimelapseVideos(c *gin.Context)
+	GetTimelapseVideos(c *gin.Context, params GetTimelapseVideosParams)
+	// タイムラプス動画の再生位置取得
+	// (GET /api/timelapse/videos/seek)
+	SeekTimelapseVideo(c *gin.Context, params SeekTimelapseVideoParams)
 	// ヘルスチェック
 	// (GET /health)
 	HealthCheck(c *gin.Context)
@@ -48,9 +109,56 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Login(c)
+}
+
+// Logout operation middleware
+func (siw *ServerInterfaceWrapper) Logout(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Logout(c)
+}
+
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCurrentUser(c)
+}
+
 // GetCameras operation middleware
 func (siw *ServerInterfaceWrapper) GetCameras(c *gin.Context) {
 
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -61,6 +169,246 @@ func (siw *ServerInterfaceWrapper) GetCameras(c *gin.Context) {
 	siw.Handler.GetCameras(c)
 }
 
+// CreateCamera operation middleware
+func (siw *ServerInterfaceWrapper) CreateCamera(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateCamera(c)
+}
+
+// DeleteCamera operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCamera(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteCamera(c, cameraId)
+}
+
+// GetCamera operation middleware
+func (siw *ServerInterfaceWrapper) GetCamera(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCamera(c, cameraId)
+}
+
+// UpdateCameraSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCameraSettings(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateCameraSettings(c, cameraId)
+}
+
+// GetCameraSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetCameraSnapshot(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCameraSnapshotParams
+
+	// ------------- Optional query parameter "width" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "width", c.Request.URL.Query(), &params.Width)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter width: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "height" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "height", c.Request.URL.Query(), &params.Height)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter height: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "quality" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "quality", c.Request.URL.Query(), &params.Quality)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter quality: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCameraSnapshot(c, cameraId, params)
+}
+
+// StartCamera operation middleware
+func (siw *ServerInterfaceWrapper) StartCamera(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StartCamera(c, cameraId)
+}
+
+// GetCameraStats operation middleware
+func (siw *ServerInterfaceWrapper) GetCameraStats(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCameraStats(c, cameraId)
+}
+
+// StopCamera operation middleware
+func (siw *ServerInterfaceWrapper) StopCamera(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StopCamera(c, cameraId)
+}
+
 // GetCameraStream operation middleware
 func (siw *ServerInterfaceWrapper) GetCameraStream(c *gin.Context) {
 
@@ -75,6 +423,10 @@ func (siw *ServerInterfaceWrapper) GetCameraStream(c *gin.Context) {
 		return
 	}
 
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -85,6 +437,34 @@ func (siw *ServerInterfaceWrapper) GetCameraStream(c *gin.Context) {
 	siw.Handler.GetCameraStream(c, cameraId)
 }
 
+// TriggerCameraRecording operation middleware
+func (siw *ServerInterfaceWrapper) TriggerCameraRecording(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "cameraId" -------------
+	var cameraId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "cameraId", c.Param("cameraId"), &cameraId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.TriggerCameraRecording(c, cameraId)
+}
+
 // GetCameraWebSocket operation middleware
 func (siw *ServerInterfaceWrapper) GetCameraWebSocket(c *gin.Context) {
 
@@ -99,6 +479,10 @@ func (siw *ServerInterfaceWrapper) GetCameraWebSocket(c *gin.Context) {
 		return
 	}
 
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -109,9 +493,125 @@ func (siw *ServerInterfaceWrapper) GetCameraWebSocket(c *gin.Context) {
 	siw.Handler.GetCameraWebSocket(c, cameraId)
 }
 
+// GetEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsParams
+
+	// ------------- Optional query parameter "cameraId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cameraId", c.Request.URL.Query(), &params.CameraId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cameraId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEvents(c, params)
+}
+
+// GetEventSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetEventSnapshot(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", c.Param("eventId"), &eventId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter eventId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetEventSnapshot(c, eventId)
+}
+
+// GetMosaic operation middleware
+func (siw *ServerInterfaceWrapper) GetMosaic(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMosaicParams
+
+	// ------------- Optional query parameter "layout" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "layout", c.Request.URL.Query(), &params.Layout)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter layout: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMosaic(c, params)
+}
+
 // GetStatus operation middleware
 func (siw *ServerInterfaceWrapper) GetStatus(c *gin.Context) {
 
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -125,6 +625,10 @@ func (siw *ServerInterfaceWrapper) GetStatus(c *gin.Context) {
 // GetTimelapseConfig operation middleware
 func (siw *ServerInterfaceWrapper) GetTimelapseConfig(c *gin.Context) {
 
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -135,9 +639,156 @@ func (siw *ServerInterfaceWrapper) GetTimelapseConfig(c *gin.Context) {
 	siw.Handler.GetTimelapseConfig(c)
 }
 
+// GetTimelapseFrames operation middleware
+func (siw *ServerInterfaceWrapper) GetTimelapseFrames(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTimelapseFramesParams
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", c.Request.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter source: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTimelapseFrames(c, params)
+}
+
+// GetTimelapseFrame operation middleware
+func (siw *ServerInterfaceWrapper) GetTimelapseFrame(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "timestamp" -------------
+	var timestamp time.Time
+
+	err = runtime.BindStyledParameterWithOptions("simple", "timestamp", c.Param("timestamp"), &timestamp, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter timestamp: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTimelapseFrameParams
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", c.Request.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter source: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "width" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "width", c.Request.URL.Query(), &params.Width)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter width: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "height" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "height", c.Request.URL.Query(), &params.Height)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter height: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTimelapseFrame(c, timestamp, params)
+}
+
+// SetTimelapseLayout operation middleware
+func (siw *ServerInterfaceWrapper) SetTimelapseLayout(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetTimelapseLayout(c)
+}
+
+// GetTimelapseLayouts operation middleware
+func (siw *ServerInterfaceWrapper) GetTimelapseLayouts(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTimelapseLayouts(c)
+}
+
 // GetTimelapseStatus operation middleware
 func (siw *ServerInterfaceWrapper) GetTimelapseStatus(c *gin.Context) {
 
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -151,6 +802,68 @@ func (siw *ServerInterfaceWrapper) GetTimelapseStatus(c *gin.Context) {
 // GetTimelapseVideos operation middleware
 func (siw *ServerInterfaceWrapper) GetTimelapseVideos(c *gin.Context) {
 
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTimelapseVideosParams
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", c.Request.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter source: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTimelapseVideos(c, params)
+}
+
+// SeekTimelapseVideo operation middleware
+func (siw *ServerInterfaceWrapper) SeekTimelapseVideo(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SeekTimelapseVideoParams
+
+	// ------------- Required query parameter "at" -------------
+
+	if paramValue := c.Query("at"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument at is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "at", c.Request.URL.Query(), &params.At)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter at: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", c.Request.URL.Query(), &params.Source)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter source: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -158,7 +871,7 @@ func (siw *ServerInterfaceWrapper) GetTimelapseVideos(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetTimelapseVideos(c)
+	siw.Handler.SeekTimelapseVideo(c, params)
 }
 
 // HealthCheck operation middleware
@@ -201,12 +914,32 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/api/auth/login", wrapper.Login)
+	router.POST(options.BaseURL+"/api/auth/logout", wrapper.Logout)
+	router.GET(options.BaseURL+"/api/auth/me", wrapper.GetCurrentUser)
 	router.GET(options.BaseURL+"/api/cameras", wrapper.GetCameras)
+	router.POST(options.BaseURL+"/api/cameras", wrapper.CreateCamera)
+	router.DELETE(options.BaseURL+"/api/cameras/:cameraId", wrapper.DeleteCamera)
+	router.GET(options.BaseURL+"/api/cameras/:cameraId", wrapper.GetCamera)
+	router.PATCH(options.BaseURL+"/api/cameras/:cameraId", wrapper.UpdateCameraSettings)
+	router.GET(options.BaseURL+"/api/cameras/:cameraId/snapshot", wrapper.GetCameraSnapshot)
+	router.POST(options.BaseURL+"/api/cameras/:cameraId/start", wrapper.StartCamera)
+	router.GET(options.BaseURL+"/api/cameras/:cameraId/stats", wrapper.GetCameraStats)
+	router.POST(options.BaseURL+"/api/cameras/:cameraId/stop", wrapper.StopCamera)
 	router.GET(options.BaseURL+"/api/cameras/:cameraId/stream", wrapper.GetCameraStream)
+	router.POST(options.BaseURL+"/api/cameras/:cameraId/trigger", wrapper.TriggerCameraRecording)
 	router.GET(options.BaseURL+"/api/cameras/:cameraId/ws", wrapper.GetCameraWebSocket)
+	router.GET(options.BaseURL+"/api/events", wrapper.GetEvents)
+	router.GET(options.BaseURL+"/api/events/:eventId/snapshot", wrapper.GetEventSnapshot)
+	router.GET(options.BaseURL+"/api/mosaic", wrapper.GetMosaic)
 	router.GET(options.BaseURL+"/api/status", wrapper.GetStatus)
 	router.GET(options.BaseURL+"/api/timelapse/config", wrapper.GetTimelapseConfig)
+	router.GET(options.BaseURL+"/api/timelapse/frames", wrapper.GetTimelapseFrames)
+	router.GET(options.BaseURL+"/api/timelapse/frames/:timestamp", wrapper.GetTimelapseFrame)
+	router.PUT(options.BaseURL+"/api/timelapse/layout", wrapper.SetTimelapseLayout)
+	router.GET(options.BaseURL+"/api/timelapse/layouts", wrapper.GetTimelapseLayouts)
 	router.GET(options.BaseURL+"/api/timelapse/status", wrapper.GetTimelapseStatus)
 	router.GET(options.BaseURL+"/api/timelapse/videos", wrapper.GetTimelapseVideos)
+	router.GET(options.BaseURL+"/api/timelapse/videos/seek", wrapper.SeekTimelapseVideo)
 	router.GET(options.BaseURL+"/health", wrapper.HealthCheck)
 }
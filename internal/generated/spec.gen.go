@@ -18,48 +18,168 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9Ra7U8bRxr/V6q5+3AnmdjmpU35VvWqHqc7qQpV78MJWYM9xtvuW3fHNFyElN0NiQnk",
-	"4BIgISElJCQQOFx6aVJeTPLHjHeNP/EvnGZm1971jl+ILlGqStVie5/X3/P8nmcmV0BWU3RNRSo2wfAV",
-	"YGYLSIHs8XOoIAOOqHmN/qUbmo4MLCH2XQ5NSlnEn8ysIelY0lQwDIi9S5wN4jwnzg3iLBJ7k9iHxCoT",
-	"59/EPgQJgC5DRZcRGAbJHJpMTko5pKVAAuApnX5oYkNSJ8B0Aki5TtKtcvXgqndt4XTvnlt66u4tRkRn",
-	"meVpkVgVKqiz4NON7drmkbt4KyKSfm1vEudF46ci6SbCWFInWIh+b6A8GAa/SzYDnPSjm+ShHQ1+Td/E",
-	"EBfNzpa5c8vVk7XazVfezBw1Ti0qYPgfAGaxNIlAAkhq4xEZhmaAsbAHje9arJ5OAAN9X5QMlKPSpBzw",
-	"o5QIshzya6zxujb+LcpianqLMzGs5HWRX84ycf5DnApxHgUPpbNKKa+bZ5XZcOjTQwmgwMuSQr39OJUA",
-	"iqTyP9INYyQVowlkUGsKSJoo4LjC2tKx6ywQq1zfvUes5bNKiThLxP6J2MfE2W3R+Ul/Vz0/SDlc6KDG",
-	"PZzprCPdf7GLkpbM0DgGeht+tk+IeQmZuqaaKJ4RXh+d0OY5M+6jn2m0Zm65pbsUXBgpPeKatYzphmHQ",
-	"MOBUzJvABqEDmpqXJkR267hooAyNkDEJZe5AHhZlDIZBv8kgG/bHu112T36ur9yp3186q5RqW7dbksBf",
-	"itUxUuG4jHIR+dgookQsXm9YV3jEOt5dYh96a7PuzcNk7dqGe/OwKXpc02QEVSpbgZczeQMqKDNezOeR",
-	"EVGSvphKxZxYu+pubkULZpE4Dv3E3iD2S9Zlj4AIpVoR60WcyWuGAnE0XIo+GIuXe+PIvfmACX7OVP3I",
-	"9JQiIeMvxmL2fRHKEp6KKBmIKZhbphVyxzp9sfPRH9J9Q38Eoeoe6lZ0BjI1uchldUbipeYv2XsYqfSP",
-	"TA5OmVETY/GuvnnozVve2np95c5ZpeTdfeot73PcxC0q6jmI2yEyXQDiAHgPfvFW9jkuI7Flb8QbdKxC",
-	"vqD9vX2B5xCGkiws8G0G1Qoluucvar/s80o/q5TqK3O11aPa0nbE1VBNMErpKLG2Xa5v/BhxiAVGhXLG",
-	"RMYkMjJcikC8gkwTTqBOCmhnclgvrRD7IKKmelTy1taJdZdYO8S6FjJqnjnFv3rN/r/elQMDIwObRD3q",
-	"zwjKuNA+Be0J/SXzZTEIWcV1blFOf2GFOL3ApE9FGTz4sJv5vmqR1ZciBRS1uB1z9kKY6RQls55Zsjs5",
-	"ftqf6kqIPVDhKEOdeIYtaCbumh/i7DDQHdLRz3nAHqLtMHWB/SfCtK4Z59XwkE9CYQ0XU6lPwyPQ0NDA",
-	"0LkGB+aob40wSAwv7aHMJ8eMqRWNLBJC+jHNo3Od2E+Is0KsHe/eIzoF2SesVA+95X1h88wWDQOpOMO2",
-	"AMEstfDaXduuz/+3tnRcPdjjE3Bt6Tggvk3i7PI5vTOBd2Hs5+unzkl33g5zdsaU/onaGcxy+jZkLUMT",
-	"ZzihxGXTKeD1fCMGnEG8VdstHYMECAge0Jf7sKQg4XqCNQNOoEzRFIWmevKmtrTNEe5bbx+45cP6jQVW",
-	"rGybo0P6bFihpOKPB4XuYA1DmedWAJrarwu+J0JwiEhvdMrESOmG1rbD7en2nlu+T6xlYs8T6xmjibnI",
-	"frWwz41ptqFQnQn7G2e1bsNIqAt1XPV+ZcG/TkHTdtsziqpK08nkGDh41HSdPkb4ovnTnvii4U2i43D+",
-	"TVCsLSOHELXVkzWvtEhHqFW7Z5jmigYMKEo4Pa3a9ZU7LXNT/4AyONRmmM9lmKo4s7HWUntpV4+un7OU",
-	"8pKMMjoUkVu4PQnPPSCGSSpXhrqJmk+Z/lT/QF+6vy+VvtBmxmZaxb0nojVoM/G6DTH24MWhTz7uqZJ5",
-	"68tqRRULCznc7loriC00gsJhZNJW5stFd7EUlOq6kE4aKgaE8mlxdEx7fWXO3Zo7dwdtV7uttBIvXJTV",
-	"jByvR9ojZIRRrnFUkwA6ZG15rFu1skILAzAMi0Sn4Y9V7l8lPvX0tM/zWo+v8tMJIPkzVVZTMcwykfxs",
-	"DYwi1ZAmoPrR1wgqrJ6juX3w5PTZSrPvhrreR41XiVX+7KuR6vGyt3WP5kHCckT0Z1+NgASYRIbJhabZ",
-	"CEYXXh2pUJfAMBi4kLowwOKKC8zRJNSlZIgdJhB+C5KoHlw9fbZF7Nvuwor7+m6wV6wCpps3rpEcGAZf",
-	"IuwfxAC2uDK6Yor7U6kgcoijH+q6LGXZu8lvTd75eA56O3Fp0iHLTZtDHW46jdLQ/9GC6Coq1B+ad4PF",
-	"jMHaLCoKNKbiNvLY0sTDCZOCnrsJxuhb4Twmr/CHkdx00sQGBVy7zHrzN0KZXQ/n9G9/+eqLL4PBZ4cZ",
-	"u86OeveJfbs+c6v6ZqOHPI9y/RRxtF9iZFDT2x6xjfyJHdmCYeBXsV8+gUMgXPb88KmZkB7OuqfHusJO",
-	"KcpY0qGBk5f7FOkyyvUZSJchP9hvKmu0xnFJpemKq4rlXBDRRxR4g6nB9wm8RornT5/NEWuTWHPEnuVn",
-	"BB92HQgDeL56+MF8m1qg2uzHbIjwKe3vaHxUy36HsLhCrF3vX09rr+43KuSsUnL3r3sPn7rl9dMnM3zu",
-	"aFMzDdkfbtmkU+l4ABt2B77vsqA5jPv3g2Fo9oOEfPr9meOt7XAQtIN5G2x1hHpzBhNiO3q8sh252mMn",
-	"nsTa4cOZf8nRE5WPBjvSO2Ny4XYrTHFzYAr78YH2s1ZjY9TuR7aZ3+ZqlG3cA4kzHVm0VvlyQFtYbBj3",
-	"p7qeMv11oN6/hXqXwxvXIIyt2IUPNMliY2OpboRWmO2udd2qJQwud2a7enKHWGWOsnOm+j1Udw913ck/",
-	"f538baRfYHivIGgeFwpB0HIwEFfNT4jOs6Q17PmGq36HIGgu4D3lP+zLbyTzYZO7lj+/z+qRyCM3ZcS+",
-	"XXt8dLpzq31m+f3c5wWU/e5dprTlGrBbIK15b++Je3BArF1+vls92GsNqnOPTd+HxLGIvcXuhn4KRZFr",
-	"pCFsnD+LBmZ+nRtWDhKgaMhgGBQw1oeTSVnLQrmgmZjdMAE6+Po64kQrMKm+8rh+9Qmj2212dTVLnIf+",
-	"v5FiF1j+YO7bO5240nWQqZU3aovXm6/6bVn0qj/UuaVX7usNNuYJdpOmJH+IFEkSQ5jfCjUFNLE7PTb9",
-	"vwAAAP//ogTaPDUnAAA=",
+	"H4sIAAAAAAAC/+x9+1Pb1rrov+Lxvj/cOxeCeaQ7YWbPne52n7Nzb3tPp2nPuXObjBH2ArRjS64kp8np",
+	"ZMaSQ2ICFEp4hISUkJBAoJi0eRQChD9GyIaf+BfOrG9pSUvSkiXThNDdzjmzS2xL61vf+t6v9W0yI+cL",
+	"soQkTU12f5tUMwMoL8CfHxa1gS9VpOC/s0jNKGJBE2Up2Z3cXxndX9429SnTGDH1eavytD65vF8aTLYk",
+	"C4pcQIomInhFRsgjRVCDbziY/nn/yZKpL5n6qGkMm8aqWV4wy09NvXruY1N/Y+rzpr5ulr83jV2zvG2W",
+	"nx9uV+pzen3qcW3WMPV1a3DZ1J+YetV59HB7KNmSFDWUh/W0qwWU7E6qmiJK/clrLfQDQVGEq/jf6EpB",
+	"VJCaFrQgeKaxZZbLpvGLWV4yy89NvVqbG7Jubdbm5g9mxw+3K74f7G09thanTX2dgEhA6ZOVPH55Mito",
+	"qFUT8yjZEgQrj7QBORuG49r0prU9drhdkWQJJUx9naJ+pH59wbq1SVZCUjGf7P4qqSJVxU+3JDX5EsL/",
+	"xY8lL3KWVeQcCi5aW35K9ndZRN8gBS9IT6pq6rtmScenK2gyfMWe2sH0sLU0bJa3LH2utvbQLG/tL69Z",
+	"1bvW4lDt3guzpAvZvCj5n9rf3bFuPcBPDd06mF00DcMaW98v73i3RYBJtiTp4smWJLyOu7OiihRJyHN2",
+	"Z5afYFoyXpnlbWt81CGzDz87Z5Yr8NU6OW1rfNQaGsUgXBHyhRzQDSwYWA+jEn1dFBWUxaA6i9sYds7X",
+	"hVTu/QfKaBjSj4A7PhIKQq+YEynPeDmIEBGHg6z1N9bunFmeMo2nwCE/YIIsV5rigT5FyKO0Imio4Qrl",
+	"H2GFB/SPyuF2pa+g8jlOlDTUjxTecgpS5VwRvz50uf2lR1Z5zHr9hH3zf1NQX7I7+ac2V1a12YKq7XPn",
+	"ncEVfYfDLu/de4uD5/Bz+jsSctpAEO76vUf7T6ZNfdU0KqYxfHB3zqo+ry3OWTdfYy64uWINT1k3Rvdf",
+	"/mINT5l6tX7rVW1wOCAqc4KqpZGiyBx5W5srWW9GTH3Vfq0+gwnXWMZMVN7G4qg8Y5bXsFAyNvESL429",
+	"1zfM8pbn8PTqQWmy/vJVfW2InFyAPFwYuFLRAcNZ2jQmWJBqs4ZV2Yot+mA5BamaoGiNFwxg8QirSehK",
+	"xGo/Lpj6qruIMbH/9AmWesYwWQrkvr1zcopYVDx4YY1XiHxsRu5TSDJyUeIA4yEjSltBYrLu/VCbesYK",
+	"qlRLgBGDbMCsHE7w56Q+OSiQMj5x1Yg3OQLuWksyiy6LGZ54dhRD+aZZHjeNRULNYAVssntMtmXR5bbL",
+	"YhbJKR5uBxxWjYbOZutrLUkx2wgmvbq3UapdH9tfu2NVHltr4x6AiKHTzqU7vi5iteDCcn3xtTXuVTj4",
+	"a2MR6yP6U97bVaRpotQf8yjO01/jJzVBK6qNIbOGp/Z25hyJRRWykNHEyyjZkhQl508iui56VCb9LkQP",
+	"BVfeAZmyiY0+vPUZD0KKam+a4DlSDYvZpI14h9wYVIWT/HkGmzw9zNFag4+tW/e4atiF/NP//dnf/pWH",
+	"h74C7wCiNK7z4vbTLcm8cEXM42P5INWSzIsS+Ud7C0cdDyCxf4Czh/rkllUew/ph9Y6pT4E+mcS2EDZy",
+	"V31r/rkjch0v5oRsVsQLCbnPmM81pYhaws7fuve6Njfk2IfEkkxyTu3ropATtavBLWGEW7f1/ecrGHF9",
+	"+QLqN/VqovXr7stmSbeejWHnRb9u6lum/vRg9U59cgt+69lqJ4PdzvaoXX8jZrWBBsi1NgcbY7a940wE",
+	"an1UjqmHruucbjRxf1nAiolDysRU12exwrvzwCqPEcQfbldqIzet6l2idg8eDNbvVYnCI4+QffwG+KXz",
+	"eFikPXXmPfGI76hMYw3/GI5qb+NW7d6GqY96bL93zErHyj5nO6LZJ4w1NEGLUIUHg6N7uwv1lz/tL1cA",
+	"EA7RgcV9mxC0+6G+Xr/3Yn/3+9P1pYmD6dt4L9X52saPVmmRxznCZaQI/ShN/BNV/E8eq24+t+7f9MBg",
+	"vASD6TXABsZTuUJe72Co60xHeycP972ipvBFAgAa3NPhdqU3qI3au852nsWk71rAcrE3x1gAUjHfS5Yk",
+	"ijzd0OY693FcGysjFLSigtIZOZ8XJO4718zyI3CTdPyHvrq3s2vqjyCGdN00hh3CNo3nIJSem+UhfPJg",
+	"grmUb0zgB40xU18x9evU+PfEfVyIyTOJ1r7E5a5cR6JVlApFLU2wk8j/A74DKxaOOYEVwJU/d6QSrXD2",
+	"+EgSnalEq5hg7N1Ea6b7ciIjF67i94p5oR91FMQCSrTGFpj0VDkUzILfcfbUmVhnCdCq6awiFwqIi/x1",
+	"G5PGQ0AsdpessZm93QVTH9nf3QHxsWjqw6Z+19RX6w9e1B5dt0WY14Ot/zLm83faOxgYRUn7oCvJI3Eb",
+	"xkKxNyeqAzwoCYPHXfVMR2cq1sLg5BJebuxTe5h5whqbtt7MHMnRPUbPErwIRUPZkCiql+mMCTtKyOzq",
+	"cLtCIoZ7G2tHip+qxV68Zi9SOJReH3tjzS070tsaBNFtbIIsW6G0v3Uw8nN9cguHIbG/c7u2OFeff0zC",
+	"JDj060VDBw8NxQKGL62ijCxl1SZR8QRTvoGXqi9N1Kae+XCS8lsxH6TikJ7PWHQFbottOHIUjasKOBwT",
+	"YPTAtv205z2dcMtU/RypBVlSES/cEJJBcLRErTxoPfiJnLFVmYkbN2RiHFFxQwoDdwOy1Cf28+AmGgkf",
+	"h3JZyJEN9AnFHD6zDjXpN+xqE1Vr56eD6dsHdydxrmNpwq9N4KEA/SNJ6M2hrOf9fMOR+NQPIMAyYxqb",
+	"JKXRRhIJ7qt7ZTmHBInIrqtyUfOCLhQ1OQB8/eU41oMeqYk1bH1ymfgT+HMi/40nZrlijY8eblfwq3BG",
+	"gDgbjmVbm3qG43DjFVCz97CoeLBtrY378YEkTRGkDEr3yZkiFzd54YpN3b3Fvj6keHbSfiaV8u/DGp7C",
+	"gkBftRMTNndeJ9repxX2Nm6RZMn+q0FTx1LU1Jdr917Upp+RY8Ri/M0g5BiwWrNfbkyQ33jscEaUuECr",
+	"mgwMmu/1Ap7q6ApAvrd731q7w9Ve1nhlf7niGIgs5J/+1SzpKSx3ry9YlVfw4ZBZMtgd7e3er43oOPM1",
+	"fdu6MYhzdjhNs2jq12szj/e27hDpZWdw4LS5+5KLWsFPTBk53ytKIEp8B3HzNXYT4W0Eb4fbFfprTDM2",
+	"xcF+bcSW9AJS0qpcVDKIR1br35GIFvltr6wN4F/tbSzUpn15NAYs95VYOMraADfnRPaWZp1eusV8oSts",
+	"d42dYPJgcKnLSMkJV6PE27+Rn9niye/f2cB18umfeHuJ/97eevp/sE7c6SgfTkEqhh5D6sEBjv5KSFCC",
+	"Qm94wVpcqm9UwY2s7j+aww4Sxssjs7xqGrv41AUtX8zl0oqch1OdK5mGcVCt1u58h2mxpNO3J8CUuWXd",
+	"fFIfv4GTJbMGfps+AoblsGkMe08ZP4NUDZSeAx+zGj9v6qabmkpMKUhDEv5HOitcVb2n0Awzs9yIvf2Z",
+	"x1hcOkxME9R80aJmhBzyrJ3sE7VkmKpYNfVlENmbZvkuGPGV2vokzj1jCb3ihImwjcQcJHaWRA14a2e2",
+	"PruDjZ2tCVNfshZmTOMWWPqviGi1xr4zDR3LmZJ+QeoTczn8GPz/LpinswflZatyA0vSyk18uGPTxGuz",
+	"5oedJ1VNQVoGuNnanjL10b3tDVN/Zuqzh9tDFyTmyMlu8TrJlqT9GD+JDDGyEO3dPpDkMw4r/D28DE8E",
+	"Q9ZBa0JBgoaIafI5+rqIyTNgWoTmT9i0CeRMDrcrX57/KxPFWMIo2h08eOAPDdgZla5cW+/VVjHbVlR7",
+	"W1NdH2TTf/84/R+oNyPkiaPaKkpZdIWbdIlw5X31Gx5gQYHUJ+drlVAlHz+z4mRTjrhi/bvrB9MP30HS",
+	"xY67Uqcp0mIjNgjNuIb5D2Bd6cNc+y1umuVwu+ImV8ySfqW9Pa1mFISkRH0N/JJZ7CPVNiqQDnWeGjpq",
+	"fga+5ZnTf8NZpHBvIIs0QcxxvQGakdar+0+f1188I27B4XblYHq4Pvu6PrkckvQOybmzb6wvVw8WfvBs",
+	"FiSDJOTSKlIuI8XO3HPre1RV6EeNFsB0VoaY5rZpbHiW2Xtdqc3Ng7AEC9QFagQ2Rb56Q1RFJNopkBQm",
+	"7glcxiQcfgToMi0V829n0SzPOuGd5pyxT2X8Flg60huzAeDB/i/gn4bDTvzXiAwChR1rV+KTPbjhq3Rp",
+	"tJUvxDzKCQUVATC8KhhN1oRcEAjGwq6yAJGAAP4K67Yxa2jUH3cFRyYqV0S2TlfnYY9kwsOxF54wfgmQ",
+	"jlNm2bbKozhn/FxnVC/JyV/1Zojph1GEay/Ng/oTuV+UQnVlQVDVb2SFp5pAQ5rldYDdE29yHvo11WW/",
+	"rnjMAYG35U9lVRAznzDOeZQvXvX538FCTZTjidV+RcTeVpXrStEsjJNyqhIh5fsxNkyfvgYnep48At49",
+	"LrA7eHDD1JcOBkfrO9UmWIzd/0col+MxWUZutB+rMkOiejz3W5SCDxbEglnegkBDAluyi1C4qo8RY4PN",
+	"mTr7Dpg87J5x2LdyP4hZv05tvq4kEGhpMmZSkFWRujcBHADuno3VV27jRMjGE1PfxMFjHKuqaHIhnUN9",
+	"WsIOnJZ0iq6qtbGOXZcdcs4VBedYWxK9sqbJef+Oyadp+E2SW7j6TYOD3V8YCTtYfibNuylIMwYhJ7lH",
+	"GpNYtdbf2JGJoZ+tcb8pnTrVcTpW2oRvnRFbo/nomFnSbSTY6VEcaGJywc6vLkhkz+tugVGADE192caI",
+	"i4uI3+9tTfM+xxj0e2F2CBGDm2zBJwDCN+MR8CEi0haPofZjQDQEA7MyR/cSdZ8ibkGY95yRc2m1IEje",
+	"YFwgpLG8glNYdngKrHM4jjCyVORveD7MSDQ8ivxNDHjqG0+agscOdgXNE790iyen/IqchtLwMZDNRx1j",
+	"A3vOLjALwEoiz5A9qUYIRJsWObWpsDZ+d9M6KdKEdQrj6Cp8HLg2cUhahJs+h/TdKKnMrc8/ppUgbINF",
+	"/LQ6rMLXNJHr/JqyRiRl05rIW9ZJmHLXbzJVKmYbOzLnPuY+JaULityvIFUNRYs+Un81W391F4iQ750X",
+	"kHAprWZkBTUGgtAx3vfiEnjtz03jIdZHi0PWyDTZd31yq/4Cgv2gkhKp1vaAWmrv4KklJ7rLVuikOOpK",
+	"lYSCOiBr6aKS4wG8aZZvQRh7hrbF4JC2U7/z5eefeANOQkFsI75cW2dfR++pU6fa6BLcmAvkFcNponL/",
+	"rdAEr5CUTZ2yLOEBiqFZz9F6ycWHRh7feyP3Eb7qam1sDDJWYIBO37TWsCLjuQBLYBjYgV1swJd0vlUf",
+	"fIr5xBpc3tu57azLq1/qFTKX+hW5KEXnJAnAWFSUR2qz6/gAR28dlMYgtF+17t3bX5qwdh7i82S2yWWm",
+	"gKhqmAq1JQ9uAorxbl6KtU/IqeH7oa+sRudY+2RJS/eJvG6sL5Qi+uJqAbX9WwFJ+A+aMYIYC/6biNJV",
+	"v6NxY3B/8iX745DQF6zt2sX21lKh26Juj7/+keYfvEWSHjOVJl84IoativQ4HzY8SepZBNNHgGbiVzD2",
+	"JfN7/Cd1JWzPwv7G42fwUgAausLxrve2tmrXIeMBSCExLleqnRc1lPiQh2ssGVRNyBeiuQKiTkRqRZOn",
+	"Pzpm0ypPsnzuyV15uTas8DV+vWvsks6YlZwRAa0YZc/nITTLb2MZkFUtMpQFNUJlUG3PzfI9+MObpE2d",
+	"gv/jO9JKsyvcDxbhnUmlzrJF/qdPd55uqkgcNmpDw0eSRqzW0Bga3/xrXN3RVNCB5+NxQYUoYJQ7YKfr",
+	"uYHKh5DFvAHp5WlTXwn61nzPr6goSNLSkAILqzIjRWTEWLOrDBgRTXDSuHonolzn6fx+eSeGQmEKXkLK",
+	"h52yOG8N6DiYbARmu1gkvKSxGNJFQO3zKpsSbdIsp4UvRRVlQynPruL7kWRNrOrmwc2xYPFzjApNiIiT",
+	"s+WVEP4yZu+ESxzXuHSqICH/kSxpipz7NCz/8x+o97ycuYQ0fz2ivsQrmMWRgIOSDiWqEHuqvLLeLARz",
+	"R76MR0HlH9HiUljbhIq0dF9BJTp9iVQFQWDXn/A7HaXNw3QKWd/RLHhBt6wiet1U1Lr8wJqNMDabZ9sL",
+	"BaGoIlK8WMzbnWJpUiPphQ2iVVI/11wIUXZkr0TlNbvRD7oitho3q0oIkqjDo9Ejq7T8lAgCdY0+1Zge",
+	"45X9H25XBlAuJ4eZrU0SNXYwaHsMvDfRlhAyl8LiamFk688SUvoFgUsi3s5IAZbEm1y+6YTx4XYFErsh",
+	"2ALq5onRjRIWy26h8XAD+Bj9omI7QcqgaASVHtWnVhgExRPHISrr3gsS9mEpD7cmgRazRT5JlTLLBd/u",
+	"M8MbgO8ruXDK1XnHTYByflKQpf5mathD6jS8x8yTXHBctDA72ZIUMpfA1pP62Y7cuLLKXzcKQiucunGq",
+	"pBnSji2trqoaykcZe6GF4QQgOpzGbu/xNDWPPfN3c0RpFFLkERUDZvyNhv3Vv4CsvGFjmd9irRQlSZT6",
+	"aYyJ/ikXqAJygGd+GiuJ7uympWFhu6+OIYB/YmVzJTk3EcoJSYV0TTUISGsDxXyvJIi5sCDkS5D7o8Tm",
+	"bhx71OgG20htRFtHqqOztb2jNdX+RepsdyrVnUqdSqVS//9/ka3+xYbrQjGV6vgAWOgvnR2pGL5+mHMf",
+	"Wz7wN8vg8t3sNLqMy9kngdF/QFy6UsT+fqREMfbb6gxEVzQkZbn9Xoy/Bgb3Co1eT1hbrw6mfrGLy2mL",
+	"Hw7V8B0uMYdCciSha+hV6mi5ziGzn5xYSONDau9ItafbuzpTqdSpkHrwRnFx35KkZNDRUvhD/C30CEO+",
+	"2BpfNY1SU5qLnKYasbZTrljeYnFrm5nGj2Fldl8l84JUFDBl5SEdhkkq/nijoqSJubBTMY2J+qu7pv69",
+	"aQzvva6QCpZfkyvwdFg5NOFLEhCQGLpkcMhjl3+nIQdfISTX997bmatVxnFN+KwR+wizRUXgF3zYfi+U",
+	"0TvVcARDoPjvHIz8DOX1htOlwc7BYcufOzrzXafVo2X6fCmJKgvHEcCKhxU4wIIQZiZRzuXOphE0gZG5",
+	"zl9pV+yGMTOsyjeAPavGaPZuT3WdOf3nD+I3x4b1i9Z/GQuWIx6ZFvjFirTsIBQGu3zBHjjIDduxYztC",
+	"3x/XXGnUO0RH4XFakI5g0sTJa74XDgi3X/2RyaDxijKykiU2KbaTc0hDWccjcVzSyGqfrN2J6vAiyyEt",
+	"japCQWx+IpJwdqwCDniCp0Tgi/MIXYqoK043sPqsG6P1yXmSqIo+TrZqyz7UVdL2tL/7va8PsZkTlfv6",
+	"VKSFyXlM4AyY3B7U9o7OrlPxqtu4NqsHD976NAeILz//BMTaAm7YMB5BCHQKwqHP4EMcDk30/En7S08C",
+	"mg9HA60sXpsXArteKdzVmmpvTXVgKfwn7S9kUzyEOfH+GITjI13yqIPzlgCNEAwFaRe8zUxREbWr5/EK",
+	"dlIdCQpS8ETWUIeX1Q/6KunYIKj1zbc83K7gF8mK+J+g+LsTf4W3J7Bn05mB0aHwpx1EgY2CzQs/cxE1",
+	"oGkFUhgnXxIRHzg4DKGoDbTlcKE2Lp6tz77eXxixhbkx7Bul+hG8DMomoEHU/hexr5MqkhSxX5DSzKhT",
+	"m18L4v9BuNQKCnRIyi8jS5qQ0dwkVvK8/XziCyTkyTA6zixHJ1jAuOoJ51FTr3742bm9rana0h28vqjl",
+	"PK/+8LNzyZbkZaSo5KXtkCG8BuNLJaEgJruTnadSpzpBEGoDcL4+NOGPCvwMpX+G6bKvkB2Ha/EoyGe0",
+	"VnMGR254OIaItBedOM5jTNADIp0ls2bJuCD55s66gw8No1YZt27Nk4XIlFcyRhZPCRIkWbqal6GAdJn0",
+	"/NWGfoJicPxqKAslU11FWTqXTXaTev4kYSakan+Vs1fpUdqVcEKhkBMz8ETbP1RiuBJmjGJVT6/ANS/L",
+	"YvcOPiBCHk6lI5V6a2s705RhXf+heg+MHal8rSXZ9RbB8DZYcWDZ2xitrT3C+Qjsma3joDNJewMg7ccH",
+	"SNi03gC9jwC8M6ZuD+XxyNBk91cXW5JqMZ8XlKs+VGPmFXAX31cw6zp5ET/o4US7wSKEFb1MhRnHGVwT",
+	"wnLYx7b75G3m4tE/XjVAiV08WUD2YqfdPa1gcXDgNoM0QgMxTPsRVxh5SATbnmRqNe294yMhZO4yTFuB",
+	"vsyhTdcsoIyAx7HuTobj7V+R9hFJ0wOXvSdO9vHtMbJLbW6FIJ8cPZfg7eIECiOZMRR+/EyInXv8kZH2",
+	"vY0Snh3umWYUenj2Yu/w4PzDZnhCh0JPQMenePo4pa8n00rzff4T9cIYOEWyzeRFUk/Hy797RgT6m4HZ",
+	"mfrAdM4kFNsY6AGXtSdh6iMJrDXBWr9Jyw1XSSiCmYy8zjYyM2OPHNviw8/O1V+O1CfxEB86hHKda9Xi",
+	"mQL2bARCdHexpPUOv2aGWC1Zg2XsO+E6uluhFgfbdP+ODA9eX38s+6P9LRM/SU4F6Y6esh1gcQjgxJgf",
+	"2C+eW7FH0utVDtmWt4hgsy+VsCf2sCMRtvbLO9ate3BTg5O3HCJbPHt8W7TGR0z9jueaC++8a1eCjtRm",
+	"HuIy2rU7UK8FJaAnWiQROuIJI59SafuW/HEue40IKBwjChdVfrrEOoVcNAE+Rb26UB+/Ya2/2f9pwTuO",
+	"iJUzzGfz3rkY67XvHtdfMevgh96QUUdkNB6dl7eExYuHWd5AefNtjmD5GDblCJaCgEMAGmRLvmqc2gLf",
+	"14542Z4rRVfSLzRamIOO05B1MY5pyaKKMEjXcVKcywD7T4bt0ZDGEOHpk80ABHF8bcw1okIpXK/SyXbL",
+	"ROyRq1FMfcUpuSDyjDjtsU2sk0yJqWNSd77hgSeOwkOEKww2aWjvCXigURSNsRO82UHfmIps88sVm+QT",
+	"koizf+a7w8cmwgp7MwRYgKRRGmI+i3PuhVG4BZlUvdDEOCOHp5/BE9fpUljeUpvRkbc8K45M1/GN8T9Z",
+	"pP4O7ErugKHjDWw15jSWfLhc9z5sS0Jcf+i1Zgw75jav5sw7t6szLI7QSAXOlbBI8M5GxmWftOJpyaf3",
+	"wNDrgeqsnkRboocU8/YkmGwTmZjFm2yHK0LWaiO6bVTaYwntN2MPBKbcwXi6GcfwIxMzL0gYQvy++xAL",
+	"3CTeapyaUkIEpHaDWFyJnr99IfQD/J8Iqtb6qZwV+0SU7UmY5TtQUlMily/tbd3Z2/iOBBwvSD3n+lr/",
+	"ryyh1k+xGoDHz/U5D7eeF6UM6kk4M59r9xf2tl6RHFogkrdKXa2ZUInrWBTn3bbdEyJuWxqV4Qc7zwCU",
+	"r4tIuerCQvvL3IWdJqw/fxB9UUdEz0M8GAjx8oHo6uxoGgjPVQ6EHWjH6ChIgOcw/Z6E1Wednk0eaHSc",
+	"KBe29lQUaNGmH0y2b8Mz8r0iz0k994qSoPBGR11raao3HXoIhaxdxIYZL6Iy2pggd0DRaRqAN7upDP4X",
+	"hySeeTAToM+kh6+PVovecAW8RmeqK+rNI6Q/yxPDdbTR+wr8fA+qaAFE3O6J1NKd7wecQAMj0UUYLrY8",
+	"P+QqATcsFqbkwxgl3OVopPbpkMvGQWiO0m84LN9W8nZgjHEjnHDz3s6UaRisig6orvMYtj/cYfc2Xoze",
+	"Bkb679lIDtxxq69aiz/VpmbCuIj8rHlu0cIzbQ1ubOHfOVTesnPR3spN/DmMZyRFvWS4K3uzUTCQhEMA",
+	"G+vW/LxpXMdB0/I4iVJbU2MHCxAUWNmtzW8x8/4jI1DktqXfEd+RDUdRF3MMv5GAFAH2iNpBLrwl5cAk",
+	"AqhygGg+jS75ulnXrcrN2vQaG07i6Ae58Id6cKQPIPgP9RDBwJQOI9QD+VnT7II7uI8SQYG7FL1MMA+O",
+	"3jNsV7l3XkWJbFj/N8QM+WJOEwuCorVdac2LV1C2VUGFnED6ln+VO8nB6IM/+KCJWCIXgc3xg90zdUQN",
+	"4namOa1gQQ8Dd5wPjZL7v6AS3t8wZ+fdcM+cbupP7FfZsTimeYntcYMjKt+nm7ffBLblbX9bGgWEaSLE",
+	"t5Nbr5943+npUiTFBeH9jCGxPLsrk1aouO0bJ5bfO94acfs7Urnk3YBkbK+bbWw8geLgWEtcvOzmjrKk",
+	"PDJCxhixAYwTKrICsDOs16TM+kY9iv6G1R6S25BI31XIoBaq1fVVWszCpkQuSMEhLiAufLearQLpXMeh",
+	"wJLeg9mwOwGtKzhxUG0w6uVwu8IZMwN54KULkgO8XbKFMTpD3E/8HvzJTRLvw4xlj/iAwaZ07odZ0pm8",
+	"jzFB+x5vwf59kzKWmFc4KCBocV+oryfsDcLoih7oTfFeGOnkxXnvuyDx5jStx8GRdzYUQRJZEW46IoOK",
+	"SLzOWa410QN9c5DXIaOKeroT7OnZb4C6W2e0C66LgxQ6vMGebNTTnWg8L4cQJJlB7R9R5LzInWXkvA/P",
+	"BClvOXNvnNfETbaV9LAF8dwLvMzaVu3FVv3h6/0VfMFPD5510pOgxeHkwqcLEnc4Fj1zIXOpB3e38Y4a",
+	"jsIW6Ike6FcEurB25+prk97z9zLUOk6mzM3X71439VWXRfUqBtxDQSWdvm3EvjDB5lfHM2VXCbfJnUVO",
+	"rppuJwWsIYOm6L5XgX2IrfKMUuHQH4H/WIH/MCM7bJxXI6Xl3u3TH9In6tyOa+pLtM/YUVqursTZdlrJ",
+	"AxderMYoTyM3DzVBzFhev/zBNG7tv9k29d2QTCVD3L8yiWzqt50REXtbjw9mR2GyvrH3+gYtpmdQoK/G",
+	"AK5PkfPJFp4/2nDuRARk4LewpfZHgEyT3wJcRCnsbb0iLfq8dXJiXvSmuJmLTlMtnqTyr88q/wp+9d6L",
+	"xWVYdmY7bR85MUnUE2dce8RJAHeBqDIcQEBYtX0L/41VZxQYqr9Euuqdkf10vvUw3DHpv3rTY37GlWfx",
+	"a2N8tw5w1LS904ZauvlA2fEVWhy/QmeF3m8lTBbGFjSXESc/7+eVPNxOEsoYJCcYqMat8ucEGxPBESC4",
+	"SB0iT+QyL1Nfaq/9cJe5eAYroUYMBLbuwfTPuF+QbeBhaoNppM1O6pslg1yfwqnto9XDZMiUZzg1GA0O",
+	"TOGlbeRClyi2jbzj3HMDKQdtSw3uqgmvviIbD7FoIsdtH69MKD/EPdx4W+vvSw74KeBEmPcNinQ8XbQj",
+	"ETU7ejWI4uiUrDvdJ0RVsr7tsqfeH7oQTH2FjP2xm1RiaUQy0/NdtvhyZ4dyBbE72YPdxwmNQvqBDZyv",
+	"jVn3fN35NxnnIhn+SXummc0697YFhZXbJBJ90s70UPsam3eZuyYrcHHL38IJPWQ+sIGjdlDLPW33ilvu",
+	"aXtulueoI7+md27ADbrwh9sVchlKbdao42c3gGo2oDrjOenIBSOFjHegF174R7Qu0/r5HjKiDYJ5RGs6",
+	"6pwzPNY7DQLfhjW4DPWrnuZ1j0FhDJMGYTxMgk5DI4HhkJt3cYN7D/inJML4atDUceG8k2zDdyiRZ+1r",
+	"kaDz9P5NCGyv0it7cTCdBTY0rOcduRsZCYm6bzSItXCDwrmX712ESAgRUWvMk21gAxE+8K2d27WZx6Ze",
+	"TdVmjXDI32UE5WiAE6s5HOKTEVnxh1beZ2zFd293hLV0AmMrXcdpOXJHabMGI02f2IFZRurhBAivHPyP",
+	"4pXm1bNPWYREreJo67ZvnbGA1yJTxKFTIYlqr21U8HTSgCJ/bz1z4Qo/WgVGakB3lLX/IPDwOQervJ3g",
+	"fon7QTw5aUR+CI6d3xgehDuiSD+B+vx32Td3zPER/xh/b0Pa/2sFVmj9Iny6LDUu5xvPlf38Xz5KdHZ2",
+	"nmWmesan1mt/qNt/BnXL6xD759HBTWpfO4ra/W2yUAwfOu61+xsEfLnjM2o/LjCOBHmKfSF4jGOjtTsP",
+	"fLOKGs5BY9z4FXAw53FkvKQzs8+8kXDP4/44c/SItPOMZv6Exp7fxUCLwG2UxzzMgn/xfNRcCy86379/",
+	"whnceuLC7XE42/OewOyJOMwdHgnjWVMVNlvFTXIFQ+1k0g0VCzR870cBtjcbZHrIRdQ+szzCQraJNHkS",
+	"uCGEA5o+4yM5UZGpjMC6TDydRu6qJLDeZHT7GBIaMVIZjfZHtvVbUedBwOMSgXt9ayNud2vV/EvT6weW",
+	"+Zfih/4+dMatXSIa10w0S7ovWO0ZgxN5HYk3Hh7tX/87wVezIWZfyZ3/toooMN+6w/ouo5LuHSKxuI7s",
+	"80TPDW4EcpNCl/Bbm4rQpWZCVjSjFKZiHTZ04jORN6D47i0J4UTPT/R1Z529jVL9lwmvST0fcqEONl9q",
+	"Pxm0CAVHt+yv3GYguJoZkgd0oBPld3vFdTKl1FqcpnEw+s2qZ66o/Zpq/d4L2LMvM7We6OHGEkm1+phR",
+	"H1wKdBBwzHp0ySsRogQCQaN9evr1kNgCj8EFLWZ1uXs/yxftXd2dHd2p1P+EWwpj38p2xMAacw3Nb09O",
+	"eS4o4vkrlOBZRvjd51C8d3f9RoM65S26A3o9b8h9YL/ZMA/3gqpIhTWAhJw2EKqdPKDoVVIDia3O5zpO",
+	"akCHUbgX8Hd4+UcDCG5cfme8TZaJjVG4C8Xa2GBHI0VcBXIHWgs3zbJuGlBOaqwzOCXrY4R6X/Kt59Kn",
+	"ry5iocveUfXVxWsX6UXDPD1yMD1cn33Nwm5fiEVulepua8vJGSE3IKta95lU6mzy2kUHqMBB8vZwMP3w",
+	"oPQITHcyn420XcMNO+WKK8/tDQaVRtAXIUPG3Udt54/3KB31YHfyLXM7Nd032dV5vDfxWaH2dH6/vOO+",
+	"wCX94DvC6ondp0m1cPBJeufTsud+Iu+VLu5b4OCvXbz2XwMAcRvs7VLCAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,19 @@
+// Package imaging はJPEGフレームの縮小・再エンコードを提供します。
+//
+// 主な機能:
+// - JPEGフレームを指定サイズに収まるよう縮小
+// - JPEG品質を指定して再エンコード
+// - 画像を指定した範囲に拡大縮小して描画（fit / fill / stretch）
+//
+// 責務:
+// - 配信（WebSocket・スナップショット）向けのフレーム加工
+// - Scaler: タイムラプスの結合フレームのタイル等への拡大縮小
+//
+// 仕様:
+// - アスペクト比は維持し、拡大はしない（ResizeJPEG）
+// - 幅・高さのどちらかが0の場合はもう一方から計算する
+// - 縮小にはバイリニア補間を使用する
+// - Scaler の補間フィルタ: nearest / bilinear / catmull_rom（縮小時は縮小率に合わせてフィルタを広げる）
+// - Scaler はYCbCr（JPEG）の輝度・色差の平面とRGBAの画素の配列を直接処理し、行を CPU 数に分割して並列に処理する
+// - fit の余白は黒で塗りつぶし、fill は元画像の中央を切り取る
+package imaging
@@ -0,0 +1,90 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	"golang.org/x/image/draw"
+)
+
+// DefaultQuality は品質が指定されていない場合のJPEG品質
+const DefaultQuality = 85
+
+// FitSize は元のサイズを最大幅・最大高さに収まるよう縮小したサイズを返す
+// アスペクト比を維持し、拡大はしない。最大値が0以下の場合はその方向を制限しない
+func FitSize(srcW, srcH, maxW, maxH int) (int, int) {
+	if srcW <= 0 || srcH <= 0 {
+		return srcW, srcH
+	}
+
+	scale := 1.0
+	if maxW > 0 && srcW > maxW {
+		scale = float64(maxW) / float64(srcW)
+	}
+	if maxH > 0 && srcH > maxH {
+		if s := float64(maxH) / float64(srcH); s < scale {
+			scale = s
+		}
+	}
+	if scale == 1.0 {
+		return srcW, srcH
+	}
+
+	w := int(float64(srcW)*scale + 0.5)
+	h := int(float64(srcH)*scale + 0.5)
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return w, h
+}
+
+// ResizeJPEG はJPEGフレームを最大幅・最大高さに収まるよう縮小して再エンコードする
+// quality が0以下の場合は DefaultQuality を使用する
+// 縮小も品質指定も不要な場合は元のデータをそのまま返す
+func ResizeJPEG(data []byte, maxW, maxH, quality int) ([]byte, error) {
+	if maxW <= 0 && maxH <= 0 && quality <= 0 {
+		return data, nil
+	}
+
+	src, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("JPEGのデコードに失敗: %w", err)
+	}
+
+	bounds := src.Bounds()
+	w, h := FitSize(bounds.Dx(), bounds.Dy(), maxW, maxH)
+	if w == bounds.Dx() && h == bounds.Dy() && quality <= 0 {
+		return data, nil
+	}
+
+	img := src
+	if w != bounds.Dx() || h != bounds.Dy() {
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.ApproxBiLinear.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+		img = dst
+	}
+
+	if quality <= 0 {
+		quality = DefaultQuality
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("JPEGのエンコードに失敗: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// JPEGSize はJPEGフレームの幅と高さをヘッダーから取得する
+func JPEGSize(data []byte) (int, int, error) {
+	config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, fmt.Errorf("JPEGヘッダーの解析に失敗: %w", err)
+	}
+	return config.Width, config.Height, nil
+}
@@ -0,0 +1,68 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+)
+
+func encodeTestJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestFitSize(t *testing.T) {
+	testCases := []struct {
+		srcW, srcH, maxW, maxH int
+		wantW, wantH           int
+	}{
+		{srcW: 1920, srcH: 1080, maxW: 640, maxH: 0, wantW: 640, wantH: 360},
+		{srcW: 1920, srcH: 1080, maxW: 0, maxH: 540, wantW: 960, wantH: 540},
+		{srcW: 1920, srcH: 1080, maxW: 640, maxH: 640, wantW: 640, wantH: 360},
+		{srcW: 640, srcH: 480, maxW: 1280, maxH: 0, wantW: 640, wantH: 480}, // 拡大しない
+		{srcW: 640, srcH: 480, maxW: 0, maxH: 0, wantW: 640, wantH: 480},
+	}
+
+	for _, tc := range testCases {
+		w, h := FitSize(tc.srcW, tc.srcH, tc.maxW, tc.maxH)
+		if w != tc.wantW || h != tc.wantH {
+			t.Errorf("FitSize(%d, %d, %d, %d) = %dx%d, want %dx%d",
+				tc.srcW, tc.srcH, tc.maxW, tc.maxH, w, h, tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestResizeJPEG(t *testing.T) {
+	original := encodeTestJPEG(t, 320, 240)
+
+	resized, err := ResizeJPEG(original, 160, 0, 0)
+	if err != nil {
+		t.Fatalf("ResizeJPEG failed: %v", err)
+	}
+	w, h, err := JPEGSize(resized)
+	if err != nil {
+		t.Fatalf("JPEGSize failed: %v", err)
+	}
+	if w != 160 || h != 120 {
+		t.Errorf("Expected 160x120, got %dx%d", w, h)
+	}
+
+	// 加工が不要な場合は元のデータを返す
+	unchanged, err := ResizeJPEG(original, 640, 480, 0)
+	if err != nil {
+		t.Fatalf("ResizeJPEG failed: %v", err)
+	}
+	if !bytes.Equal(unchanged, original) {
+		t.Error("Expected original data when no resize is needed")
+	}
+
+	if _, err := ResizeJPEG([]byte("not a jpeg"), 100, 0, 0); err == nil {
+		t.Error("Expected error for invalid JPEG")
+	}
+}
@@ -0,0 +1,457 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"runtime"
+	"sync"
+
+	"golang.org/x/image/draw"
+)
+
+// 拡大縮小の方法（配置する範囲と元画像のアスペクト比が異なる場合）
+const (
+	ScaleFit     = "fit"     // アスペクト比を維持して範囲に収め、余白を黒で塗りつぶす（レターボックス）
+	ScaleFill    = "fill"    // アスペクト比を維持して範囲を埋め、はみ出す部分を切り取る
+	ScaleStretch = "stretch" // 範囲に合わせて引き伸ばす（アスペクト比を維持しない）
+)
+
+// 補間フィルタ
+const (
+	ResampleNearest    = "nearest"     // ニアレストネイバー（最も高速、ジャギーが目立つ）
+	ResampleBilinear   = "bilinear"    // バイリニア
+	ResampleCatmullRom = "catmull_rom" // Catmull-Rom（最も鮮明、バイリニアより低速）
+)
+
+const (
+	// weightBits は補間の重みの固定小数点の小数部のビット数
+	weightBits = 14
+
+	// maxCachedWeights は保持する重みの表の上限（超えた場合は全て破棄する）
+	maxCachedWeights = 64
+)
+
+// IsScaleMode は拡大縮小の方法がサポートされているか判定する（空文字は fit）
+func IsScaleMode(mode string) bool {
+	switch mode {
+	case "", ScaleFit, ScaleFill, ScaleStretch:
+		return true
+	}
+	return false
+}
+
+// IsResampling は補間フィルタがサポートされているか判定する（空文字は bilinear）
+func IsResampling(resampling string) bool {
+	switch resampling {
+	case "", ResampleNearest, ResampleBilinear, ResampleCatmullRom:
+		return true
+	}
+	return false
+}
+
+// ScaleRects は srcW×srcH の画像を dst に配置する場合の、描画先の範囲と使用する元画像の範囲を返す
+// fit は描画先を中央に縮め、fill は元画像の中央を切り取る
+func ScaleRects(srcW, srcH int, dst image.Rectangle, mode string) (image.Rectangle, image.Rectangle) {
+	src := image.Rect(0, 0, srcW, srcH)
+	dstW, dstH := dst.Dx(), dst.Dy()
+	if srcW <= 0 || srcH <= 0 || dstW <= 0 || dstH <= 0 {
+		return dst, src
+	}
+
+	switch mode {
+	case ScaleStretch:
+		return dst, src
+	case ScaleFill:
+		scale := math.Max(float64(dstW)/float64(srcW), float64(dstH)/float64(srcH))
+		cropW := min(max(int(float64(dstW)/scale+0.5), 1), srcW)
+		cropH := min(max(int(float64(dstH)/scale+0.5), 1), srcH)
+		x, y := (srcW-cropW)/2, (srcH-cropH)/2
+		return dst, image.Rect(x, y, x+cropW, y+cropH)
+	default:
+		scale := math.Min(float64(dstW)/float64(srcW), float64(dstH)/float64(srcH))
+		w := min(max(int(float64(srcW)*scale+0.5), 1), dstW)
+		h := min(max(int(float64(srcH)*scale+0.5), 1), dstH)
+		x, y := dst.Min.X+(dstW-w)/2, dst.Min.Y+(dstH-h)/2
+		return image.Rect(x, y, x+w, y+h), src
+	}
+}
+
+// Scaler は補間フィルタを使って画像を拡大縮小する
+// 出力・入力の大きさ毎の重みの表を保持するため、同じ大きさのフレームを繰り返し処理する場合は使い回す
+// 複数のゴルーチンから同時に使用できる
+type Scaler struct {
+	resampling string
+
+	mu      sync.Mutex
+	weights map[[2]int]*weightTable // [入力の長さ, 出力の長さ] 毎の重み
+	buffers sync.Pool               // RGBAへの変換・補間の中間バッファ（*[]uint8）
+}
+
+// weightTable は出力の各画素の補間に使用する入力の画素と重み
+// 出力のi番目の画素は入力の starts[i] から taps 個の画素に weights[i*taps:] の重みを掛けて足し合わせる
+type weightTable struct {
+	taps    int
+	starts  []int
+	weights []int32
+}
+
+// NewScaler は補間フィルタを指定して Scaler を作成する（空文字・不明なフィルタは bilinear）
+func NewScaler(resampling string) *Scaler {
+	if !IsResampling(resampling) || resampling == "" {
+		resampling = ResampleBilinear
+	}
+	return &Scaler{resampling: resampling, weights: make(map[[2]int]*weightTable)}
+}
+
+// Draw は画像を dst の範囲に拡大縮小の方法に従って描画する
+// fit の余白は黒で塗りつぶす
+func (s *Scaler) Draw(dst *image.RGBA, rect image.Rectangle, src image.Image, mode string) {
+	bounds := src.Bounds()
+	dr, sr := ScaleRects(bounds.Dx(), bounds.Dy(), rect, mode)
+	if dr != rect {
+		draw.Draw(dst, rect, image.Black, image.Point{}, draw.Src)
+	}
+	s.Scale(dst, dr, src, sr.Add(bounds.Min))
+}
+
+// Scale は元画像の sr の範囲を dst の dr の範囲に拡大縮小して描画する
+// *image.YCbCr（JPEGのデコード結果）は輝度・色差の平面毎に補間し、描画する画素のみRGBに変換する
+func (s *Scaler) Scale(dst *image.RGBA, dr image.Rectangle, src image.Image, sr image.Rectangle) {
+	sr = sr.Intersect(src.Bounds())
+	if dr.Empty() || sr.Empty() {
+		return
+	}
+
+	// 同じ大きさの場合は変換のみ
+	if dr.Dx() == sr.Dx() && dr.Dy() == sr.Dy() {
+		draw.Draw(dst, dr, src, sr.Min, draw.Src)
+		return
+	}
+
+	// 描画先の範囲外は描画しない（重みは範囲全体で計算し、範囲内の行・列のみ処理する）
+	clip := dr.Intersect(dst.Bounds())
+	if clip.Empty() {
+		return
+	}
+	target := window{
+		width:  dr.Dx(),
+		height: dr.Dy(),
+		rect:   clip.Sub(dr.Min),
+	}
+	out := plane{pix: dst.Pix[dst.PixOffset(clip.Min.X, clip.Min.Y):], stride: dst.Stride, step: 4}
+
+	switch img := src.(type) {
+	case *image.YCbCr:
+		s.scaleYCbCr(out, target, img, sr)
+	case *image.Gray:
+		gray := s.buffer(clip.Dx() * clip.Dy())
+		defer s.buffers.Put(gray)
+		grayPlane := plane{pix: *gray, stride: clip.Dx(), step: 1}
+		s.resample(grayPlane, target, plane{pix: img.Pix[img.PixOffset(sr.Min.X, sr.Min.Y):], stride: img.Stride, step: 1}, sr.Dx(), sr.Dy())
+		parallelRows(clip.Dy(), func(y0, y1 int) {
+			for y := y0; y < y1; y++ {
+				for x := range clip.Dx() {
+					v := (*gray)[y*clip.Dx()+x]
+					i := y*out.stride + x*4
+					out.pix[i], out.pix[i+1], out.pix[i+2], out.pix[i+3] = v, v, v, 0xff
+				}
+			}
+		})
+	default:
+		// RGBA（その他の形式はRGBAに変換して）をチャンネル毎に補間する
+		srcPix, srcStride, release := s.rgbaPixels(src, sr)
+		defer release()
+		for c := range 4 {
+			channel := plane{pix: out.pix[c:], stride: out.stride, step: 4}
+			s.resample(channel, target, plane{pix: srcPix[c:], stride: srcStride, step: 4}, sr.Dx(), sr.Dy())
+		}
+	}
+}
+
+// plane は1チャンネルの画素の配列（pix は範囲の左上の画素から始まる）
+type plane struct {
+	pix    []uint8
+	stride int // 行の間隔
+	step   int // 隣り合う画素の間隔（1チャンネルの平面は1、RGBAのチャンネルは4）
+}
+
+// window は拡大縮小後の画像全体の大きさと、そのうち描画する範囲
+type window struct {
+	width, height int
+	rect          image.Rectangle
+}
+
+// scaleYCbCr は輝度・色差の平面をそれぞれ描画する大きさに補間し、RGBに変換して描画する
+// 色差の平面は間引かれた大きさから補間するため、色差の拡大も同時に行う
+func (s *Scaler) scaleYCbCr(out plane, target window, src *image.YCbCr, sr image.Rectangle) {
+	cols, rows := target.rect.Dx(), target.rect.Dy()
+	buf := s.buffer(cols * rows * 3)
+	defer s.buffers.Put(buf)
+	planes := *buf
+	yPlane := plane{pix: planes[:cols*rows], stride: cols, step: 1}
+	cbPlane := plane{pix: planes[cols*rows : cols*rows*2], stride: cols, step: 1}
+	crPlane := plane{pix: planes[cols*rows*2:], stride: cols, step: 1}
+
+	s.resample(yPlane, target, plane{pix: src.Y[src.YOffset(sr.Min.X, sr.Min.Y):], stride: src.YStride, step: 1}, sr.Dx(), sr.Dy())
+
+	hdiv, vdiv := chromaDivisors(src.SubsampleRatio)
+	cw := (sr.Max.X+hdiv-1)/hdiv - sr.Min.X/hdiv
+	ch := (sr.Max.Y+vdiv-1)/vdiv - sr.Min.Y/vdiv
+	offset := src.COffset(sr.Min.X, sr.Min.Y)
+	s.resample(cbPlane, target, plane{pix: src.Cb[offset:], stride: src.CStride, step: 1}, cw, ch)
+	s.resample(crPlane, target, plane{pix: src.Cr[offset:], stride: src.CStride, step: 1}, cw, ch)
+
+	parallelRows(rows, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			for x := range cols {
+				i := y*cols + x
+				r, g, b := color.YCbCrToRGB(yPlane.pix[i], cbPlane.pix[i], crPlane.pix[i])
+				o := y*out.stride + x*4
+				out.pix[o], out.pix[o+1], out.pix[o+2], out.pix[o+3] = r, g, b, 0xff
+			}
+		}
+	})
+}
+
+// chromaDivisors は色差の平面の間引きの比率（横, 縦）を返す
+func chromaDivisors(ratio image.YCbCrSubsampleRatio) (int, int) {
+	switch ratio {
+	case image.YCbCrSubsampleRatio422:
+		return 2, 1
+	case image.YCbCrSubsampleRatio420:
+		return 2, 2
+	case image.YCbCrSubsampleRatio440:
+		return 1, 2
+	case image.YCbCrSubsampleRatio411:
+		return 4, 1
+	case image.YCbCrSubsampleRatio410:
+		return 4, 2
+	}
+	return 1, 1
+}
+
+// resample は srcW×srcH の平面を target の大きさに補間し、描画する範囲を dst に書き込む
+// 縦方向に補間（元の行毎に重みを掛けて足し合わせる）してから横方向に補間するため、横方向の補間は出力の行数分で済む
+// 大きさが変わらない方向は補間せずに写す
+func (s *Scaler) resample(dst plane, target window, src plane, srcW, srcH int) {
+	cols, rows := target.rect.Dx(), target.rect.Dy()
+
+	var xw, yw *weightTable
+	if srcH != target.height {
+		yw = s.weightTable(srcH, target.height)
+	}
+
+	// 横方向の補間に使う元の列（この列のみ縦方向に補間する）
+	first, last := target.rect.Min.X, target.rect.Max.X
+	if srcW != target.width {
+		xw = s.weightTable(srcW, target.width)
+		first = xw.starts[target.rect.Min.X]
+		last = xw.starts[target.rect.Max.X-1] + xw.taps
+	}
+	width := last - first
+
+	tmp := s.buffer(width * rows)
+	defer s.buffers.Put(tmp)
+	parallelRows(rows, func(y0, y1 int) {
+		sums := make([]int32, width)
+		for y := y0; y < y1; y++ {
+			i := target.rect.Min.Y + y
+			line := (*tmp)[y*width : (y+1)*width]
+
+			// 縦方向の補間
+			if yw == nil {
+				row := src.pix[i*src.stride+first*src.step:]
+				for x := range line {
+					line[x] = row[x*src.step]
+				}
+			} else {
+				clear(sums)
+				for t, w := range yw.weights[i*yw.taps : (i+1)*yw.taps] {
+					if w == 0 {
+						continue
+					}
+					row := src.pix[(yw.starts[i]+t)*src.stride+first*src.step:]
+					if src.step == 1 {
+						for x, v := range row[:width] {
+							sums[x] += int32(v) * w
+						}
+					} else {
+						for x := range sums {
+							sums[x] += int32(row[x*src.step]) * w
+						}
+					}
+				}
+				for x, sum := range sums {
+					line[x] = clampWeighted(sum)
+				}
+			}
+
+			// 横方向の補間
+			out := dst.pix[y*dst.stride:]
+			if xw == nil {
+				for x, v := range line {
+					out[x*dst.step] = v
+				}
+				continue
+			}
+			for x := range cols {
+				j := target.rect.Min.X + x
+				start := xw.starts[j] - first
+				var sum int32
+				for t, v := range line[start : start+xw.taps] {
+					sum += int32(v) * xw.weights[j*xw.taps+t]
+				}
+				out[x*dst.step] = clampWeighted(sum)
+			}
+		}
+	})
+}
+
+// rgbaPixels は元画像の範囲の画素をRGBAの配列で返す
+// *image.RGBA はそのまま参照し、それ以外は変換する
+func (s *Scaler) rgbaPixels(src image.Image, sr image.Rectangle) ([]uint8, int, func()) {
+	if rgba, ok := src.(*image.RGBA); ok {
+		return rgba.Pix[rgba.PixOffset(sr.Min.X, sr.Min.Y):], rgba.Stride, func() {}
+	}
+
+	buf := s.buffer(sr.Dx() * sr.Dy() * 4)
+	converted := &image.RGBA{Pix: *buf, Stride: sr.Dx() * 4, Rect: image.Rect(0, 0, sr.Dx(), sr.Dy())}
+	draw.Draw(converted, converted.Rect, src, sr.Min, draw.Src)
+	return converted.Pix, converted.Stride, func() { s.buffers.Put(buf) }
+}
+
+// buffer は指定した長さ以上の中間バッファを返す（利用後は s.buffers に戻す）
+func (s *Scaler) buffer(size int) *[]uint8 {
+	if buf, ok := s.buffers.Get().(*[]uint8); ok && cap(*buf) >= size {
+		*buf = (*buf)[:size]
+		return buf
+	}
+	buf := make([]uint8, size)
+	return &buf
+}
+
+// weightTable は入力の長さ・出力の長さに対応する重みの表を返す（保持していない場合は計算する）
+func (s *Scaler) weightTable(srcLen, dstLen int) *weightTable {
+	key := [2]int{srcLen, dstLen}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if table, ok := s.weights[key]; ok {
+		return table
+	}
+	if len(s.weights) >= maxCachedWeights {
+		clear(s.weights)
+	}
+	table := newWeightTable(srcLen, dstLen, s.resampling)
+	s.weights[key] = table
+	return table
+}
+
+// newWeightTable は補間フィルタの重みの表を計算する
+// 縮小する場合はフィルタを縮小率に合わせて広げ、間引いた画素も平均する（エイリアシングの抑制）
+func newWeightTable(srcLen, dstLen int, resampling string) *weightTable {
+	scale := float64(srcLen) / float64(dstLen)
+
+	if resampling == ResampleNearest {
+		table := &weightTable{taps: 1, starts: make([]int, dstLen), weights: make([]int32, dstLen)}
+		for i := range dstLen {
+			table.starts[i] = min(int((float64(i)+0.5)*scale), srcLen-1)
+			table.weights[i] = 1 << weightBits
+		}
+		return table
+	}
+
+	kernel, support := triangleKernel, 1.0
+	if resampling == ResampleCatmullRom {
+		kernel, support = catmullRomKernel, 2.0
+	}
+	filterScale := math.Max(scale, 1)
+	radius := support * filterScale
+	span := int(math.Ceil(radius * 2)) // 半径 radius の範囲に含まれる画素の最大数
+	taps := min(span, srcLen)
+
+	table := &weightTable{taps: taps, starts: make([]int, dstLen), weights: make([]int32, dstLen*taps)}
+	values := make([]float64, taps)
+	for i := range dstLen {
+		center := (float64(i)+0.5)*scale - 0.5
+		first := int(math.Floor(center - radius + 1))
+		start := min(max(first, 0), srcLen-taps)
+
+		// 範囲外の画素の重みは端の画素に加える
+		clear(values)
+		sum := 0.0
+		for j := first; j < first+span; j++ {
+			v := kernel((float64(j) - center) / filterScale)
+			values[min(max(j, 0), srcLen-1)-start] += v
+			sum += v
+		}
+
+		// 重みの合計が 1<<weightBits になるよう丸め、誤差は最も重い画素に加える
+		weights := table.weights[i*taps : (i+1)*taps]
+		total, heaviest := int32(0), 0
+		for t, v := range values {
+			weights[t] = int32(math.Round(v / sum * (1 << weightBits)))
+			total += weights[t]
+			if v > values[heaviest] {
+				heaviest = t
+			}
+		}
+		weights[heaviest] += 1<<weightBits - total
+		table.starts[i] = start
+	}
+	return table
+}
+
+// triangleKernel はバイリニア補間の重み
+func triangleKernel(x float64) float64 {
+	x = math.Abs(x)
+	if x < 1 {
+		return 1 - x
+	}
+	return 0
+}
+
+// catmullRomKernel は Catmull-Rom 補間の重み
+func catmullRomKernel(x float64) float64 {
+	x = math.Abs(x)
+	switch {
+	case x < 1:
+		return (1.5*x-2.5)*x*x + 1
+	case x < 2:
+		return ((-0.5*x+2.5)*x-4)*x + 2
+	}
+	return 0
+}
+
+// clampWeighted は重み付きの合計を 0-255 の画素の値に戻す（Catmull-Rom の行き過ぎを切り詰める）
+func clampWeighted(v int32) uint8 {
+	v = (v + 1<<(weightBits-1)) >> weightBits
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
+
+// parallelRows は行を CPU 数に分割して並列に処理する
+func parallelRows(rows int, process func(y0, y1 int)) {
+	workers := min(runtime.GOMAXPROCS(0), rows)
+	if workers <= 1 {
+		process(0, rows)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		y0, y1 := rows*w/workers, rows*(w+1)/workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			process(y0, y1)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,123 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestScaleRects(t *testing.T) {
+	tile := image.Rect(100, 0, 500, 300) // 4:3
+
+	testCases := []struct {
+		mode    string
+		wantDst image.Rectangle
+		wantSrc image.Rectangle
+	}{
+		// 16:9 の映像は上下に余白を空けて収める
+		{mode: ScaleFit, wantDst: image.Rect(100, 37, 500, 262), wantSrc: image.Rect(0, 0, 1920, 1080)},
+		{mode: "", wantDst: image.Rect(100, 37, 500, 262), wantSrc: image.Rect(0, 0, 1920, 1080)},
+		// 左右を切り取って範囲を埋める
+		{mode: ScaleFill, wantDst: tile, wantSrc: image.Rect(240, 0, 1680, 1080)},
+		{mode: ScaleStretch, wantDst: tile, wantSrc: image.Rect(0, 0, 1920, 1080)},
+	}
+
+	for _, tc := range testCases {
+		dst, src := ScaleRects(1920, 1080, tile, tc.mode)
+		if dst != tc.wantDst || src != tc.wantSrc {
+			t.Errorf("ScaleRects(%q) = %v, %v, want %v, %v", tc.mode, dst, src, tc.wantDst, tc.wantSrc)
+		}
+	}
+}
+
+// splitImage は左半分が赤、右半分が青の画像を返す
+func splitImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, image.Rect(0, 0, width/2, height), image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(width/2, 0, width, height), image.NewUniform(color.RGBA{B: 255, A: 255}), image.Point{}, draw.Src)
+	return img
+}
+
+// toYCbCr は画像を4:2:0のYCbCr画像（JPEGのデコード結果と同じ形式）に変換する
+func toYCbCr(src image.Image) *image.YCbCr {
+	bounds := src.Bounds()
+	img := image.NewYCbCr(bounds, image.YCbCrSubsampleRatio420)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := src.At(x, y).RGBA()
+			yy, cb, cr := color.RGBToYCbCr(uint8(r>>8), uint8(g>>8), uint8(b>>8))
+			img.Y[img.YOffset(x, y)] = yy
+			img.Cb[img.COffset(x, y)] = cb
+			img.Cr[img.COffset(x, y)] = cr
+		}
+	}
+	return img
+}
+
+func TestScaler_Scale(t *testing.T) {
+	rgba := splitImage(320, 240)
+	sources := map[string]image.Image{
+		"rgba":      rgba,
+		"ycbcr":     toYCbCr(rgba),
+		"ycbcr_sub": toYCbCr(rgba).SubImage(image.Rect(80, 40, 240, 200)), // 原点が (0, 0) でない画像
+		"nrgba":     image.NewNRGBA(rgba.Bounds()),
+	}
+	draw.Draw(sources["nrgba"].(*image.NRGBA), rgba.Bounds(), rgba, image.Point{}, draw.Src)
+
+	for _, resampling := range []string{ResampleNearest, ResampleBilinear, ResampleCatmullRom} {
+		scaler := NewScaler(resampling)
+		for name, src := range sources {
+			for _, size := range []image.Point{{100, 50}, {640, 480}} {
+				dst := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+				scaler.Scale(dst, dst.Bounds(), src, src.Bounds())
+
+				// 境界から離れた画素は元の色のまま
+				left := dst.RGBAAt(size.X/8, size.Y/2)
+				right := dst.RGBAAt(size.X*7/8, size.Y/2)
+				if left.R < 240 || left.B > 15 || left.A != 255 {
+					t.Errorf("%s/%s %v: unexpected left pixel %v", resampling, name, size, left)
+				}
+				if right.B < 240 || right.R > 15 || right.A != 255 {
+					t.Errorf("%s/%s %v: unexpected right pixel %v", resampling, name, size, right)
+				}
+			}
+		}
+	}
+}
+
+func TestScaler_ScaleGray(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 64, 48))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.Gray{Y: 128}), image.Point{}, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	NewScaler(ResampleCatmullRom).Scale(dst, dst.Bounds(), src, src.Bounds())
+	if c := dst.RGBAAt(20, 15); c != (color.RGBA{128, 128, 128, 255}) {
+		t.Errorf("Expected gray pixel, got %v", c)
+	}
+}
+
+func TestScaler_Draw(t *testing.T) {
+	scaler := NewScaler("")
+	dst := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+
+	// 横長の画像を正方形の範囲に収めると上下は黒で塗りつぶす
+	scaler.Draw(dst, image.Rect(0, 0, 100, 100), splitImage(200, 100), ScaleFit)
+	if c := dst.RGBAAt(50, 10); c != (color.RGBA{A: 255}) {
+		t.Errorf("Expected black letterbox, got %v", c)
+	}
+	if c := dst.RGBAAt(10, 50); c.R < 240 {
+		t.Errorf("Expected red image, got %v", c)
+	}
+	// 範囲の外は描画しない
+	if c := dst.RGBAAt(150, 50); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("Expected untouched pixel outside the tile, got %v", c)
+	}
+
+	// 描画先の画像からはみ出す範囲は切り取る
+	scaler.Draw(dst, image.Rect(150, 150, 250, 250), splitImage(100, 100), ScaleStretch)
+	if c := dst.RGBAAt(175, 175); c.R < 240 {
+		t.Errorf("Expected red image in the clipped tile, got %v", c)
+	}
+}
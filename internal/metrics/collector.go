@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"log"
+	"path/filepath"
+
+	"senrigan/internal/camera"
+	"senrigan/internal/timelapse"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CameraSource はメトリクスの収集に使うカメラマネージャーの機能
+type CameraSource interface {
+	GetVideoSources() []camera.VideoSource
+	GetSourceHealth(id string) (camera.SourceHealth, bool)
+}
+
+// TimelapseSource はメトリクスの収集に使うタイムラプスマネージャーの機能
+type TimelapseSource interface {
+	GetTimelapseStatus() (timelapse.StatusInfo, error)
+	GetTimelapseVideos(filter timelapse.VideoFilter) ([]timelapse.Video, error)
+}
+
+// cameraStatuses は状態メトリクスに出力するカメラの状態
+var cameraStatuses = []camera.Status{camera.StatusActive, camera.StatusInactive, camera.StatusError}
+
+// Collector は収集時にカメラ・タイムラプスの状態を読み取るprometheus.Collector
+type Collector struct {
+	cameras            CameraSource
+	timelapse          TimelapseSource
+	timelapseOutputDir string
+
+	cameraStatus          *prometheus.Desc
+	cameraFramesCaptured  *prometheus.Desc
+	cameraFramesDropped   *prometheus.Desc
+	cameraFrameRate       *prometheus.Desc
+	cameraBitrate         *prometheus.Desc
+	cameraSubscribers     *prometheus.Desc
+	cameraRestarts        *prometheus.Desc
+	timelapseEnabled      *prometheus.Desc
+	timelapseFrameBuffer  *prometheus.Desc
+	timelapseEncode       *prometheus.Desc
+	timelapseEncodeErrors *prometheus.Desc
+	timelapseVideoSize    *prometheus.Desc
+	timelapseDiskUsage    *prometheus.Desc
+}
+
+// NewCollector は新しいCollectorを作成する
+// timelapseOutputDir はディスク使用量を計測するタイムラプスの出力先
+func NewCollector(cameras CameraSource, timelapse TimelapseSource, timelapseOutputDir string) *Collector {
+	cameraLabels := []string{"camera"}
+	return &Collector{
+		cameras:            cameras,
+		timelapse:          timelapse,
+		timelapseOutputDir: timelapseOutputDir,
+
+		cameraStatus: prometheus.NewDesc("senrigan_camera_status",
+			"カメラの状態（現在の状態のみ1）", []string{"camera", "status"}, nil),
+		cameraFramesCaptured: prometheus.NewDesc("senrigan_camera_frames_captured_total",
+			"カメラから取得して配信したフレームの総数", cameraLabels, nil),
+		cameraFramesDropped: prometheus.NewDesc("senrigan_camera_frames_dropped_total",
+			"配信先の受信が追いつかずに破棄したフレームの総数", cameraLabels, nil),
+		cameraFrameRate: prometheus.NewDesc("senrigan_camera_fps",
+			"直近の実測フレームレート", cameraLabels, nil),
+		cameraBitrate: prometheus.NewDesc("senrigan_camera_bitrate_bits_per_second",
+			"直近の実測ビットレート", cameraLabels, nil),
+		cameraSubscribers: prometheus.NewDesc("senrigan_camera_subscribers",
+			"フレームの配信先（ストリーム・録画・動体検知等）の数", cameraLabels, nil),
+		cameraRestarts: prometheus.NewDesc("senrigan_camera_restarts_total",
+			"障害検出による自動再起動の回数", cameraLabels, nil),
+		timelapseEnabled: prometheus.NewDesc("senrigan_timelapse_enabled",
+			"タイムラプスが有効か（有効なら1）", nil, nil),
+		timelapseFrameBuffer: prometheus.NewDesc("senrigan_timelapse_frame_buffer_size",
+			"動画に書き出す前のバッファ内のフレーム数", nil, nil),
+		timelapseEncode: prometheus.NewDesc("senrigan_timelapse_encode_duration_seconds",
+			"ffmpegによるタイムラプス動画の生成・延長にかかった時間", nil, nil),
+		timelapseEncodeErrors: prometheus.NewDesc("senrigan_timelapse_encode_failures_total",
+			"タイムラプス動画の生成・延長に失敗した回数", nil, nil),
+		timelapseVideoSize: prometheus.NewDesc("senrigan_timelapse_video_size_bytes",
+			"タイムラプス動画ファイルのサイズ", []string{"file"}, nil),
+		timelapseDiskUsage: prometheus.NewDesc("senrigan_timelapse_disk_usage_bytes",
+			"タイムラプスの出力先ディレクトリ（動画・保存したフレーム）の使用量", nil, nil),
+	}
+}
+
+// Describe はメトリクスの定義を送信する
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.cameraStatus
+	ch <- c.cameraFramesCaptured
+	ch <- c.cameraFramesDropped
+	ch <- c.cameraFrameRate
+	ch <- c.cameraBitrate
+	ch <- c.cameraSubscribers
+	ch <- c.cameraRestarts
+	ch <- c.timelapseEnabled
+	ch <- c.timelapseFrameBuffer
+	ch <- c.timelapseEncode
+	ch <- c.timelapseEncodeErrors
+	ch <- c.timelapseVideoSize
+	ch <- c.timelapseDiskUsage
+}
+
+// Collect は現在の状態を読み取ってメトリクスを送信する
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.collectCameras(ch)
+	c.collectTimelapse(ch)
+}
+
+// collectCameras はカメラ毎のメトリクスを送信する
+func (c *Collector) collectCameras(ch chan<- prometheus.Metric) {
+	for _, source := range c.cameras.GetVideoSources() {
+		id := source.GetInfo().ID
+		current := source.GetStatus()
+		for _, status := range cameraStatuses {
+			ch <- prometheus.MustNewConstMetric(c.cameraStatus, prometheus.GaugeValue, boolValue(status == current), id, string(status))
+		}
+
+		stats := source.GetStats()
+		ch <- prometheus.MustNewConstMetric(c.cameraFramesCaptured, prometheus.CounterValue, float64(stats.FramesPublished), id)
+		ch <- prometheus.MustNewConstMetric(c.cameraFramesDropped, prometheus.CounterValue, float64(stats.FramesDropped), id)
+		ch <- prometheus.MustNewConstMetric(c.cameraFrameRate, prometheus.GaugeValue, stats.FrameRate, id)
+		ch <- prometheus.MustNewConstMetric(c.cameraBitrate, prometheus.GaugeValue, stats.Bitrate, id)
+		ch <- prometheus.MustNewConstMetric(c.cameraSubscribers, prometheus.GaugeValue, float64(stats.Subscribers), id)
+
+		health, _ := c.cameras.GetSourceHealth(id)
+		ch <- prometheus.MustNewConstMetric(c.cameraRestarts, prometheus.CounterValue, float64(health.RestartCount), id)
+	}
+}
+
+// collectTimelapse はタイムラプスのメトリクスを送信する
+func (c *Collector) collectTimelapse(ch chan<- prometheus.Metric) {
+	status, err := c.timelapse.GetTimelapseStatus()
+	if err != nil {
+		log.Printf("メトリクス用のタイムラプス状態の取得に失敗: %v", err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.timelapseEnabled, prometheus.GaugeValue, boolValue(status.Enabled))
+		ch <- prometheus.MustNewConstMetric(c.timelapseFrameBuffer, prometheus.GaugeValue, float64(status.FrameBufferSize))
+		ch <- prometheus.MustNewConstSummary(c.timelapseEncode,
+			uint64(status.Encodes.Count), status.Encodes.TotalDuration.Seconds(), nil)
+		ch <- prometheus.MustNewConstMetric(c.timelapseEncodeErrors, prometheus.CounterValue, float64(status.Encodes.Failures))
+	}
+
+	videos, err := c.timelapse.GetTimelapseVideos(timelapse.VideoFilter{})
+	if err != nil {
+		log.Printf("メトリクス用のタイムラプス動画一覧の取得に失敗: %v", err)
+	}
+	for _, video := range videos {
+		ch <- prometheus.MustNewConstMetric(c.timelapseVideoSize, prometheus.GaugeValue, float64(video.FileSize), filepath.Base(video.FilePath))
+	}
+
+	// 動画と保存したフレームの合計
+	var usage int64
+	for _, dir := range []string{c.timelapseOutputDir, timelapse.FramesDir(c.timelapseOutputDir)} {
+		size, err := timelapse.DirectorySize(dir)
+		if err != nil {
+			log.Printf("タイムラプス出力先の使用量の計測に失敗: %v", err)
+			return
+		}
+		usage += size
+	}
+	ch <- prometheus.MustNewConstMetric(c.timelapseDiskUsage, prometheus.GaugeValue, float64(usage))
+}
+
+// boolValue は真偽値をメトリクスの値（1か0）に変換する
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
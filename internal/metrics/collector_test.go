@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"senrigan/internal/camera"
+	"senrigan/internal/timelapse"
+)
+
+// fakeCameras はテスト用のカメラマネージャー
+type fakeCameras struct {
+	sources []camera.VideoSource
+	health  map[string]camera.SourceHealth
+}
+
+func (f *fakeCameras) GetVideoSources() []camera.VideoSource { return f.sources }
+
+func (f *fakeCameras) GetSourceHealth(id string) (camera.SourceHealth, bool) {
+	health, exists := f.health[id]
+	return health, exists
+}
+
+// fakeTimelapse はテスト用のタイムラプスマネージャー
+type fakeTimelapse struct {
+	status timelapse.StatusInfo
+	videos []timelapse.Video
+	err    error
+}
+
+func (f *fakeTimelapse) GetTimelapseStatus() (timelapse.StatusInfo, error) { return f.status, f.err }
+
+func (f *fakeTimelapse) GetTimelapseVideos(timelapse.VideoFilter) ([]timelapse.Video, error) {
+	return f.videos, f.err
+}
+
+// scrape は /metrics の出力を取得する
+func scrape(t *testing.T, registry *Registry) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if recorder.Code != 200 {
+		t.Fatalf("Expected status 200, got %d", recorder.Code)
+	}
+	return recorder.Body.String()
+}
+
+func TestRegistry_ExportsMetrics(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "timelapse_2024-01-01.mp4"), make([]byte, 1000), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// 保存したフレームも使用量に含める
+	if err := os.MkdirAll(timelapse.FramesDir(dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(timelapse.FramesDir(dir), "frame.jpg"), make([]byte, 24), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	source := camera.NewDirectUSBCameraSource(
+		camera.VideoSourceInfo{ID: "entrance", Device: "/dev/video-missing"},
+		camera.VideoCapabilities{},
+		camera.VideoSettings{Width: 640, Height: 480, FrameRate: 30, Quality: 3},
+		camera.CaptureBackendFFmpeg,
+	)
+	cameras := &fakeCameras{
+		sources: []camera.VideoSource{source},
+		health:  map[string]camera.SourceHealth{"entrance": {RestartCount: 3}},
+	}
+	timelapseManager := &fakeTimelapse{
+		status: timelapse.StatusInfo{
+			Enabled:         true,
+			FrameBufferSize: 42,
+			Encodes:         timelapse.EncodeStats{Count: 2, Failures: 1, TotalDuration: 3 * time.Second},
+		},
+		videos: []timelapse.Video{{FilePath: filepath.Join(dir, "timelapse_2024-01-01.mp4"), FileSize: 1000}},
+	}
+
+	registry := NewRegistry(NewCollector(cameras, timelapseManager, dir))
+	disconnected := registry.StreamClientConnected("entrance", ProtocolMJPEG)
+	body := scrape(t, registry)
+
+	expected := []string{
+		`senrigan_camera_status{camera="entrance",status="inactive"} 1`,
+		`senrigan_camera_status{camera="entrance",status="active"} 0`,
+		`senrigan_camera_frames_captured_total{camera="entrance"} 0`,
+		`senrigan_camera_frames_dropped_total{camera="entrance"} 0`,
+		`senrigan_camera_restarts_total{camera="entrance"} 3`,
+		`senrigan_stream_clients{camera="entrance",protocol="mjpeg"} 1`,
+		`senrigan_timelapse_frame_buffer_size 42`,
+		`senrigan_timelapse_encode_duration_seconds_count 2`,
+		`senrigan_timelapse_encode_duration_seconds_sum 3`,
+		`senrigan_timelapse_encode_failures_total 1`,
+		`senrigan_timelapse_video_size_bytes{file="timelapse_2024-01-01.mp4"} 1000`,
+		`senrigan_timelapse_disk_usage_bytes 1024`,
+		`go_goroutines`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q", line)
+		}
+	}
+
+	disconnected()
+	if body := scrape(t, registry); !strings.Contains(body, `senrigan_stream_clients{camera="entrance",protocol="mjpeg"} 0`) {
+		t.Error("Expected stream client gauge to be decremented after disconnect")
+	}
+}
+
+func TestRegistry_TimelapseUnavailable(t *testing.T) {
+	registry := NewRegistry(NewCollector(&fakeCameras{}, &fakeTimelapse{err: errors.New("unavailable")}, filepath.Join(t.TempDir(), "missing")))
+	body := scrape(t, registry)
+
+	if strings.Contains(body, "senrigan_timelapse_frame_buffer_size") {
+		t.Error("Expected timelapse status metrics to be omitted when status is unavailable")
+	}
+	if !strings.Contains(body, "senrigan_timelapse_disk_usage_bytes 0") {
+		t.Error("Expected disk usage 0 for missing output directory")
+	}
+}
@@ -0,0 +1,17 @@
+// Package metrics はPrometheus形式のメトリクスを提供します。
+//
+// 主な機能:
+// - カメラ毎の状態・取得フレーム数・破棄フレーム数・実測フレームレート・自動再起動回数
+// - ストリーム（MJPEG・WebSocket）の接続中クライアント数
+// - タイムラプスのフレームバッファ数・動画エンコード時間・動画ファイルサイズ・出力先の使用量
+// - Goランタイム・プロセスの標準メトリクス
+//
+// 責務:
+// - Registry: メトリクスの登録と /metrics ハンドラーの提供
+// - Collector: 収集時に各マネージャーから状態を読み取ってメトリクスに変換
+//
+// 仕様:
+// - メトリクス名は senrigan_ で始める
+// - カメラ・タイムラプスの値は収集（スクレイプ）時に取得するため、キャプチャ処理に負荷をかけない
+// - ストリームのクライアント数のみ接続・切断時に更新する
+package metrics
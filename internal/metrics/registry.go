@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ストリームのプロトコル（stream_clients の protocol ラベル）
+const (
+	ProtocolMJPEG     = "mjpeg"
+	ProtocolWebSocket = "websocket"
+)
+
+// Registry はsenriganのメトリクスを登録・公開する
+type Registry struct {
+	registry      *prometheus.Registry
+	streamClients *prometheus.GaugeVec
+}
+
+// NewRegistry は新しいRegistryを作成する
+func NewRegistry(collector *Collector) *Registry {
+	registry := prometheus.NewRegistry()
+	streamClients := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "senrigan_stream_clients",
+		Help: "接続中のストリームのクライアント数",
+	}, []string{"camera", "protocol"})
+
+	registry.MustRegister(
+		collector,
+		streamClients,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return &Registry{
+		registry:      registry,
+		streamClients: streamClients,
+	}
+}
+
+// Handler は /metrics のHTTPハンドラーを返す
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// StreamClientConnected はストリームのクライアントの接続を記録し、切断時に呼び出す関数を返す
+func (r *Registry) StreamClientConnected(cameraID, protocol string) (disconnected func()) {
+	gauge := r.streamClients.WithLabelValues(cameraID, protocol)
+	gauge.Inc()
+	return gauge.Dec
+}
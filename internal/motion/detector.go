@@ -0,0 +1,179 @@
+package motion
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+)
+
+// Detector はフレーム間差分で動きの大きさを計算する
+// 直前に解析したフレームを保持するため、1つのソース専用に使用する
+type Detector struct {
+	threshold int
+	minScore  float64
+	width     int
+	masks     []Region
+
+	// 直前のフレーム（縮小済みグレースケール）
+	previous []uint8
+	prevW    int
+	prevH    int
+
+	// 解析対象の画素（マスク領域はfalse）
+	included      []bool
+	includedCount int
+}
+
+// newDetector は新しいDetectorを作成する
+func newDetector(s settings) *Detector {
+	return &Detector{
+		threshold: s.Threshold,
+		minScore:  minScore(s.Sensitivity),
+		width:     s.AnalysisWidth,
+		masks:     s.Masks,
+	}
+}
+
+// Analyze はJPEGフレームを解析し、直前のフレームとの差分スコア (0-1) を返す
+// 最初のフレームや解像度が変わった直後は比較対象がないため0を返す
+func (d *Detector) Analyze(data []byte) (float64, error) {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, fmt.Errorf("JPEGのデコードに失敗: %w", err)
+	}
+
+	pixels, w, h := downscaleGray(img, d.width)
+	if w == 0 || h == 0 {
+		return 0, fmt.Errorf("画像サイズが不正です")
+	}
+
+	if w != d.prevW || h != d.prevH {
+		d.previous, d.prevW, d.prevH = pixels, w, h
+		d.buildMask(w, h)
+		return 0, nil
+	}
+
+	changed := 0
+	for i, value := range pixels {
+		if !d.included[i] {
+			continue
+		}
+		diff := int(value) - int(d.previous[i])
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > d.threshold {
+			changed++
+		}
+	}
+	d.previous = pixels
+
+	if d.includedCount == 0 {
+		return 0, nil
+	}
+	return float64(changed) / float64(d.includedCount), nil
+}
+
+// Triggered はスコアが動きありと判定される値か返す
+func (d *Detector) Triggered(score float64) bool {
+	return score >= d.minScore
+}
+
+// Reset は直前のフレームを破棄する
+// ソースが停止・再開した場合に、無関係なフレーム同士を比較しないようにする
+func (d *Detector) Reset() {
+	d.previous, d.prevW, d.prevH = nil, 0, 0
+}
+
+// buildMask はマスク領域を除いた解析対象の画素を計算する
+func (d *Detector) buildMask(w, h int) {
+	d.included = make([]bool, w*h)
+	d.includedCount = 0
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			fx := (float64(x) + 0.5) / float64(w)
+			fy := (float64(y) + 0.5) / float64(h)
+			if d.masked(fx, fy) {
+				continue
+			}
+			d.included[y*w+x] = true
+			d.includedCount++
+		}
+	}
+}
+
+// masked は比率で表した座標がマスク領域内か判定する
+func (d *Detector) masked(fx, fy float64) bool {
+	for _, region := range d.masks {
+		if fx >= region.X && fx < region.X+region.Width &&
+			fy >= region.Y && fy < region.Y+region.Height {
+			return true
+		}
+	}
+	return false
+}
+
+// downscaleGray は画像を指定幅のグレースケールに縮小する
+// 縮小によるノイズを抑えるため、縮小後の1画素に対応する領域の輝度を平均する
+func downscaleGray(img image.Image, width int) ([]uint8, int, int) {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW == 0 || srcH == 0 {
+		return nil, 0, 0
+	}
+
+	if width <= 0 || width > srcW {
+		width = srcW
+	}
+	height := srcH * width / srcW
+	if height == 0 {
+		height = 1
+	}
+
+	luma := lumaFunc(img)
+	pixels := make([]uint8, width*height)
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := bounds.Min.Y + (y+1)*srcH/height
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := bounds.Min.X + (x+1)*srcW/width
+
+			sum, count := 0, 0
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sum += int(luma(sx, sy))
+					count++
+				}
+			}
+			if count > 0 {
+				pixels[y*width+x] = uint8(sum / count)
+			}
+		}
+	}
+
+	return pixels, width, height
+}
+
+// lumaFunc は画像の輝度を取得する関数を返す
+// JPEGのデコード結果（YCbCr / Gray）は画素配列を直接参照する
+func lumaFunc(img image.Image) func(x, y int) uint8 {
+	switch src := img.(type) {
+	case *image.YCbCr:
+		return func(x, y int) uint8 {
+			return src.Y[src.YOffset(x, y)]
+		}
+	case *image.Gray:
+		return func(x, y int) uint8 {
+			return src.Pix[src.PixOffset(x, y)]
+		}
+	default:
+		return func(x, y int) uint8 {
+			return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+		}
+	}
+}
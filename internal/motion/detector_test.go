@@ -0,0 +1,112 @@
+package motion
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// encodeFrame はテスト用に指定領域を白く塗ったJPEGを作成する
+func encodeFrame(t *testing.T, width, height int, white image.Rectangle) []byte {
+	t.Helper()
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if (image.Point{X: x, Y: y}).In(white) {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testSettings() settings {
+	return SourceOptions{}.resolve(Config{AnalysisWidth: 32})
+}
+
+func TestDetector_FrameDifference(t *testing.T) {
+	detector := newDetector(testSettings())
+
+	blank := encodeFrame(t, 320, 240, image.Rectangle{})
+	moved := encodeFrame(t, 320, 240, image.Rect(0, 0, 160, 240))
+
+	// 最初のフレームは比較対象がない
+	score, err := detector.Analyze(blank)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if score != 0 {
+		t.Errorf("Expected score 0 for the first frame, got %f", score)
+	}
+
+	// 同じフレームでは動きなし
+	score, err = detector.Analyze(blank)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if detector.Triggered(score) {
+		t.Errorf("Expected no motion for identical frames, got score %f", score)
+	}
+
+	// 画面の半分が変化した
+	score, err = detector.Analyze(moved)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if score < 0.45 || score > 0.55 {
+		t.Errorf("Expected score around 0.5, got %f", score)
+	}
+	if !detector.Triggered(score) {
+		t.Error("Expected motion to be triggered")
+	}
+
+	// Reset後は比較対象がない
+	detector.Reset()
+	if score, _ := detector.Analyze(blank); score != 0 {
+		t.Errorf("Expected score 0 after reset, got %f", score)
+	}
+
+	if _, err := detector.Analyze([]byte("not a jpeg")); err == nil {
+		t.Error("Expected error for invalid JPEG")
+	}
+}
+
+func TestDetector_Masks(t *testing.T) {
+	s := testSettings()
+	s.Masks = []Region{{X: 0, Y: 0, Width: 0.5, Height: 1}}
+	detector := newDetector(s)
+
+	blank := encodeFrame(t, 320, 240, image.Rectangle{})
+	maskedMotion := encodeFrame(t, 320, 240, image.Rect(0, 0, 160, 240))
+
+	if _, err := detector.Analyze(blank); err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	// マスク領域内の変化は無視される
+	score, err := detector.Analyze(maskedMotion)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if detector.Triggered(score) {
+		t.Errorf("Expected masked motion to be ignored, got score %f", score)
+	}
+}
+
+func TestMinScore(t *testing.T) {
+	// 感度が高いほど小さな変化で検知する
+	if minScore(100) >= minScore(50) {
+		t.Errorf("Expected higher sensitivity to lower the minimum score: %f, %f", minScore(100), minScore(50))
+	}
+	if got := minScore(80); got < 0.0209 || got > 0.0211 {
+		t.Errorf("Expected minScore(80) = 0.021, got %f", got)
+	}
+}
@@ -0,0 +1,21 @@
+// Package motion は映像ソースの動体検知とイベント記録を提供します。
+//
+// 主な機能:
+// - 映像ソースのフレーム配信を購読してJPEGを解析
+// - 縮小したグレースケール画像のフレーム間差分による動体検知
+// - 動きの開始から終了までを1つのイベントとして記録
+// - イベント一覧とスナップショットのローカル保存
+//
+// 責務:
+// - Manager: 設定に基づく検知対象ソースの管理とイベントの提供
+// - Monitor: 単一ソースのフレーム解析とイベントの開始・終了判定
+// - Detector: フレーム間差分によるスコア計算
+// - Store: イベントとスナップショットの永続化
+//
+// 仕様:
+// - スコア: マスク領域を除いた画素のうち、輝度差が閾値を超えた画素の割合 (0-1)
+// - 感度 (1-100): スコアが (101-感度)/1000 以上で動きありと判定する
+// - 動きが EventGap の間検知されなければイベントを終了する
+// - スナップショット: イベント中で最もスコアが高かったフレーム
+// - 保存先: <出力ディレクトリ>/events.json, <出力ディレクトリ>/snapshots/<イベントID>.jpg
+package motion
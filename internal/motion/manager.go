@@ -0,0 +1,224 @@
+package motion
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"senrigan/internal/camera"
+)
+
+// syncInterval は検知対象ソースを見直す間隔
+const syncInterval = 10 * time.Second
+
+// Manager は動体検知全体を管理するインターフェース
+type Manager interface {
+	// システム制御
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+
+	// データ取得
+	ListEvents(filter Filter) ([]Event, error)
+	GetEvent(id string) (Event, error)
+	GetEventSnapshot(id string) ([]byte, error)
+}
+
+// DefaultManager はManagerのデフォルト実装
+type DefaultManager struct {
+	cameraManager camera.Manager
+	config        Config
+	options       map[string]SourceOptions // デバイスパスをキーとするソース毎の設定
+	monitors      map[string]*Monitor      // ソースIDをキーとする検知中のMonitor
+	store         *Store
+	mu            sync.RWMutex
+
+	// イベント通知先（Monitor停止時にも通知するため mu とは別のロックで保護する）
+	listeners  []func(Event)
+	listenerMu sync.RWMutex
+
+	// 制御用
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDefaultManager は新しいDefaultManagerを作成する
+// options はデバイスパスをキーとしたソース毎の動体検知設定
+func NewDefaultManager(cameraManager camera.Manager, config Config, options map[string]SourceOptions) *DefaultManager {
+	if config.OutputDir == "" {
+		config.OutputDir = DefaultConfig().OutputDir
+	}
+
+	return &DefaultManager{
+		cameraManager: cameraManager,
+		config:        config,
+		options:       options,
+		monitors:      make(map[string]*Monitor),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start は動体検知を開始する
+// 検知が有効なソースがなくても、保存済みのイベントを参照できるよう保存先は開く
+func (m *DefaultManager) Start(ctx context.Context) error {
+	store, err := OpenStore(m.config.OutputDir, m.config.RetentionDays)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.store = store
+	m.mu.Unlock()
+
+	if !m.hasEnabledSource() {
+		log.Println("動体検知が有効なカメラはありません")
+		return nil
+	}
+
+	m.syncMonitors(ctx)
+
+	m.wg.Add(1)
+	go m.syncLoop(ctx)
+
+	return nil
+}
+
+// Stop は全ての動体検知を停止する
+func (m *DefaultManager) Stop(_ context.Context) error {
+	close(m.stopCh)
+	m.wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, monitor := range m.monitors {
+		monitor.Stop()
+		delete(m.monitors, id)
+	}
+
+	return nil
+}
+
+// OnEvent はイベントの開始・継続中・終了時に呼び出される関数を登録する
+// 継続中のイベントは約1秒毎に通知される
+func (m *DefaultManager) OnEvent(listener func(Event)) {
+	m.listenerMu.Lock()
+	defer m.listenerMu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// notify は登録された関数にイベントを通知する
+func (m *DefaultManager) notify(event Event) {
+	m.listenerMu.RLock()
+	listeners := m.listeners
+	m.listenerMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
+
+// ListEvents は条件に一致するイベントを新しい順に返す
+func (m *DefaultManager) ListEvents(filter Filter) ([]Event, error) {
+	store := m.getStore()
+	if store == nil {
+		return []Event{}, nil
+	}
+	return store.List(filter), nil
+}
+
+// GetEvent は指定IDのイベントを返す
+func (m *DefaultManager) GetEvent(id string) (Event, error) {
+	store := m.getStore()
+	if store == nil {
+		return Event{}, ErrEventNotFound
+	}
+	event, exists := store.Get(id)
+	if !exists {
+		return Event{}, ErrEventNotFound
+	}
+	return event, nil
+}
+
+// GetEventSnapshot はイベントのスナップショットを返す
+func (m *DefaultManager) GetEventSnapshot(id string) ([]byte, error) {
+	store := m.getStore()
+	if store == nil {
+		return nil, ErrEventNotFound
+	}
+	return store.Snapshot(id)
+}
+
+// getStore はイベントの保存先を返す（未開始の場合はnil）
+func (m *DefaultManager) getStore() *Store {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.store
+}
+
+// hasEnabledSource は動体検知が有効なソース設定があるか確認する
+func (m *DefaultManager) hasEnabledSource() bool {
+	for _, options := range m.options {
+		if options.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// syncLoop は定期的に検知対象を見直す
+func (m *DefaultManager) syncLoop(ctx context.Context) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.syncMonitors(ctx)
+		}
+	}
+}
+
+// syncMonitors は現在の映像ソースに合わせてMonitorを開始・停止する
+func (m *DefaultManager) syncMonitors(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := make(map[string]camera.VideoSource)
+	for _, source := range m.cameraManager.GetVideoSources() {
+		info := source.GetInfo()
+		options, exists := m.options[info.Device]
+		if !exists || !options.Enabled {
+			continue
+		}
+		current[info.ID] = source
+
+		// 同じIDでもソースが作り直された場合は購読し直す
+		if monitor, exists := m.monitors[info.ID]; exists {
+			if monitor.source == FrameSource(source) {
+				continue
+			}
+			monitor.Stop()
+			delete(m.monitors, info.ID)
+		}
+
+		monitor := newMonitor(source, m.store, options.resolve(m.config), m.notify)
+		monitor.Start(ctx)
+		m.monitors[info.ID] = monitor
+	}
+
+	// 削除されたソースの検知を停止
+	for id, monitor := range m.monitors {
+		if _, exists := current[id]; exists {
+			continue
+		}
+		monitor.Stop()
+		delete(m.monitors, id)
+	}
+}
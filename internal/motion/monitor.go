@@ -0,0 +1,215 @@
+package motion
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"senrigan/internal/camera"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// monitorBufferSize は動体検知用購読のフレームバッファサイズ
+	// 解析が追いつかない場合は古いフレームを捨てて最新のフレームを解析する
+	monitorBufferSize = 2
+
+	// resubscribeInterval はソース停止後に再購読するまでの待機時間
+	resubscribeInterval = time.Second
+)
+
+// FrameSource は動体検知対象のフレーム供給元
+type FrameSource interface {
+	GetInfo() camera.VideoSourceInfo
+	Subscribe(bufferSize int, policy camera.DropPolicy) *camera.FrameSubscription
+}
+
+// Monitor は単一の映像ソースの動きを監視してイベントを記録する
+type Monitor struct {
+	source   FrameSource
+	sourceID string
+	settings settings
+	store    *Store
+	detector *Detector
+	notify   func(Event)
+
+	// 継続中のイベント（runゴルーチンのみが操作する）
+	active       *Event
+	lastMotion   time.Time
+	lastAnalyzed time.Time
+
+	// 制御用
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// newMonitor は新しいMonitorを作成する
+// notify はイベントの開始・継続中・終了時に呼び出される
+func newMonitor(source FrameSource, store *Store, s settings, notify func(Event)) *Monitor {
+	return &Monitor{
+		source:   source,
+		sourceID: source.GetInfo().ID,
+		settings: s,
+		store:    store,
+		detector: newDetector(s),
+		notify:   notify,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start は動体検知を開始する
+func (m *Monitor) Start(ctx context.Context) {
+	m.wg.Add(1)
+	go m.run(ctx)
+
+	log.Printf("映像ソース %s の動体検知を開始", m.sourceID)
+}
+
+// Stop は動体検知を停止し、継続中のイベントを終了する
+func (m *Monitor) Stop() {
+	close(m.stopCh)
+	m.wg.Wait()
+
+	log.Printf("映像ソース %s の動体検知を停止", m.sourceID)
+}
+
+// run はソースを購読してフレームを解析する
+func (m *Monitor) run(ctx context.Context) {
+	defer m.wg.Done()
+	defer m.finishEvent()
+
+	for {
+		subscription := m.source.Subscribe(monitorBufferSize, camera.DropOldest)
+		stopped := m.consume(ctx, subscription)
+		subscription.Unsubscribe()
+
+		// ソースが停止した場合は継続中のイベントを終了し、比較対象のフレームを破棄する
+		m.finishEvent()
+		m.detector.Reset()
+		if stopped {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+// consume は購読が終了するまでフレームを解析する
+// 動体検知の停止が要求された場合はtrueを返す
+func (m *Monitor) consume(ctx context.Context, subscription *camera.FrameSubscription) bool {
+	// フレームが途絶えてもイベントを終了させるためのティッカー
+	// 継続中のイベントの終了時刻・スコアもこの間隔で反映・通知する
+	// （ファイルへの書き込みはイベントの開始・終了時のみ）
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-m.stopCh:
+			return true
+		case frame, ok := <-subscription.Frames():
+			if !ok {
+				return false // ソースが停止した
+			}
+			m.processFrame(frame)
+		case now := <-ticker.C:
+			if m.active == nil {
+				continue
+			}
+			if now.Sub(m.lastMotion) >= m.settings.EventGap {
+				m.finishEvent()
+				continue
+			}
+			m.store.Update(*m.active)
+			m.notify(*m.active)
+		}
+	}
+}
+
+// processFrame はフレームを解析し、イベントを開始・更新・終了する
+func (m *Monitor) processFrame(frame camera.Frame) {
+	if frame.Timestamp.Sub(m.lastAnalyzed) < m.settings.Interval {
+		return
+	}
+	m.lastAnalyzed = frame.Timestamp
+
+	score, err := m.detector.Analyze(frame.Data)
+	if err != nil {
+		log.Printf("映像ソース %s のフレーム解析に失敗: %v", m.sourceID, err)
+		return
+	}
+
+	if !m.detector.Triggered(score) {
+		if m.active != nil && frame.Timestamp.Sub(m.lastMotion) >= m.settings.EventGap {
+			m.finishEvent()
+		}
+		return
+	}
+
+	m.lastMotion = frame.Timestamp
+
+	if m.active == nil {
+		m.startEvent(frame, score)
+		return
+	}
+
+	m.active.EndTime = frame.Timestamp
+	if score > m.active.PeakScore {
+		m.active.PeakScore = score
+		m.saveSnapshot(frame.Data)
+	}
+}
+
+// startEvent は新しいイベントを開始する
+func (m *Monitor) startEvent(frame camera.Frame, score float64) {
+	info := m.source.GetInfo()
+	m.active = &Event{
+		ID:         uuid.NewString(),
+		SourceID:   m.sourceID,
+		SourceName: info.Name,
+		StartTime:  frame.Timestamp,
+		EndTime:    frame.Timestamp,
+		PeakScore:  score,
+		InProgress: true,
+	}
+
+	m.saveSnapshot(frame.Data)
+	if err := m.store.Save(*m.active); err != nil {
+		log.Printf("映像ソース %s のイベント保存に失敗: %v", m.sourceID, err)
+	}
+	log.Printf("映像ソース %s で動きを検知しました (イベント %s)", m.sourceID, m.active.ID)
+	m.notify(*m.active)
+}
+
+// finishEvent は継続中のイベントを終了して保存する
+func (m *Monitor) finishEvent() {
+	if m.active == nil {
+		return
+	}
+
+	event := *m.active
+	m.active = nil
+
+	event.InProgress = false
+	if err := m.store.Save(event); err != nil {
+		log.Printf("映像ソース %s のイベント保存に失敗: %v", m.sourceID, err)
+	}
+	m.notify(event)
+}
+
+// saveSnapshot は継続中のイベントのスナップショットを保存する
+func (m *Monitor) saveSnapshot(data []byte) {
+	if err := m.store.SaveSnapshot(m.active.ID, data); err != nil {
+		log.Printf("映像ソース %s のスナップショット保存に失敗: %v", m.sourceID, err)
+	}
+}
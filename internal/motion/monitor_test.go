@@ -0,0 +1,227 @@
+package motion
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"senrigan/internal/camera"
+)
+
+// fakeSource はテスト用のフレーム供給元
+type fakeSource struct {
+	info        camera.VideoSourceInfo
+	broadcaster *camera.FrameBroadcaster
+}
+
+func newFakeSource(id string) *fakeSource {
+	return &fakeSource{
+		info:        camera.VideoSourceInfo{ID: id, Name: "テストカメラ", Device: "/dev/video0"},
+		broadcaster: camera.NewFrameBroadcaster(),
+	}
+}
+
+func (s *fakeSource) GetInfo() camera.VideoSourceInfo {
+	return s.info
+}
+
+func (s *fakeSource) Subscribe(bufferSize int, policy camera.DropPolicy) *camera.FrameSubscription {
+	return s.broadcaster.Subscribe(bufferSize, policy)
+}
+
+// waitFor は条件を満たすまで待機する
+func waitFor(t *testing.T, message string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMonitor_EventLifecycle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	source := newFakeSource("camera_test")
+
+	store, err := OpenStore(dir, 0)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+
+	s := testSettings()
+	s.Interval = time.Millisecond
+	s.EventGap = time.Hour // テスト中はイベントを自然終了させない
+	var notified []Event
+	var mu sync.Mutex
+	monitor := newMonitor(source, store, s, func(event Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		notified = append(notified, event)
+	})
+	monitor.Start(ctx)
+	waitFor(t, "Monitor did not subscribe to the source", func() bool {
+		return source.broadcaster.SubscriberCount() > 0
+	})
+
+	blank := encodeFrame(t, 320, 240, image.Rectangle{})
+	small := encodeFrame(t, 320, 240, image.Rect(0, 0, 80, 240))
+	large := encodeFrame(t, 320, 240, image.Rect(0, 0, 320, 240))
+
+	// 配信毎に解析されるのを待つ（バッファ溢れでフレームを落とさないため）
+	publish := func(frame []byte) {
+		source.broadcaster.Publish(frame)
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	publish(blank)
+	publish(blank)
+	publish(small) // 動き開始（1/4が変化）
+	publish(blank)
+	publish(large) // 最大スコア（全面が変化）
+	publish(blank) // 同じスコアではスナップショットを更新しない
+
+	waitFor(t, "Expected an event in progress", func() bool {
+		return len(store.List(Filter{})) == 1
+	})
+	if event := store.List(Filter{})[0]; !event.InProgress {
+		t.Error("Expected event to be in progress")
+	}
+
+	// 停止すると継続中のイベントが終了する
+	monitor.Stop()
+
+	events := store.List(Filter{})
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+	if event.InProgress {
+		t.Error("Expected event to be finished after stop")
+	}
+	if event.SourceID != "camera_test" || event.SourceName != "テストカメラ" {
+		t.Errorf("Unexpected source: %s (%s)", event.SourceID, event.SourceName)
+	}
+	if event.PeakScore < 0.9 {
+		t.Errorf("Expected peak score near 1, got %f", event.PeakScore)
+	}
+	if event.EndTime.Before(event.StartTime) {
+		t.Error("Expected end time after start time")
+	}
+
+	// 開始と終了が通知される
+	mu.Lock()
+	if len(notified) < 2 || !notified[0].InProgress || notified[len(notified)-1].InProgress {
+		t.Errorf("Expected start and finish notifications, got %+v", notified)
+	}
+	mu.Unlock()
+
+	// スナップショットはスコアが最大のフレーム
+	snapshot, err := store.Snapshot(event.ID)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if !bytes.Equal(snapshot, large) {
+		t.Error("Expected snapshot of the peak frame")
+	}
+}
+
+func TestStore_ListAndPersist(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour)
+
+	store, err := OpenStore(dir, 30)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+
+	events := []Event{
+		{ID: "a", SourceID: "cam1", StartTime: base, EndTime: base.Add(time.Minute)},
+		{ID: "b", SourceID: "cam2", StartTime: base.Add(10 * time.Minute), EndTime: base.Add(11 * time.Minute)},
+		{ID: "c", SourceID: "cam1", StartTime: base.Add(20 * time.Minute), EndTime: base.Add(21 * time.Minute), InProgress: true},
+		{ID: "old", SourceID: "cam1", StartTime: base.AddDate(0, 0, -31), EndTime: base.AddDate(0, 0, -31)},
+	}
+	for _, event := range events {
+		if err := store.Save(event); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	// 保持期間を過ぎたイベントは削除され、新しい順に並ぶ
+	all := store.List(Filter{})
+	if len(all) != 3 || all[0].ID != "c" || all[2].ID != "a" {
+		t.Fatalf("Unexpected events: %+v", all)
+	}
+
+	if got := store.List(Filter{SourceID: "cam1"}); len(got) != 2 {
+		t.Errorf("Expected 2 events for cam1, got %d", len(got))
+	}
+	if got := store.List(Filter{From: base.Add(5 * time.Minute)}); len(got) != 2 {
+		t.Errorf("Expected 2 events after from, got %d", len(got))
+	}
+	if got := store.List(Filter{To: base.Add(5 * time.Minute)}); len(got) != 1 || got[0].ID != "a" {
+		t.Errorf("Expected only event a before to, got %+v", got)
+	}
+	if got := store.List(Filter{Limit: 1}); len(got) != 1 || got[0].ID != "c" {
+		t.Errorf("Expected only the latest event, got %+v", got)
+	}
+	visible := func(sourceID string) bool { return sourceID == "cam2" }
+	if got := store.List(Filter{Visible: visible, Limit: 1}); len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("Expected only the visible event b, got %+v", got)
+	}
+
+	if _, err := store.Snapshot("a"); err != ErrEventNotFound {
+		t.Errorf("Expected ErrEventNotFound for missing snapshot, got %v", err)
+	}
+	if _, err := store.Snapshot("missing"); err != ErrEventNotFound {
+		t.Errorf("Expected ErrEventNotFound for unknown event, got %v", err)
+	}
+
+	// 開き直しても保存されており、継続中だったイベントは終了扱いになる
+	reopened, err := OpenStore(dir, 30)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	event, ok := reopened.Get("c")
+	if !ok {
+		t.Fatal("Expected event c to be persisted")
+	}
+	if event.InProgress {
+		t.Error("Expected in-progress event to be finished on reopen")
+	}
+}
+
+func TestStore_Update(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now()
+
+	store, err := OpenStore(dir, 30)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	event := Event{ID: "a", SourceID: "cam1", StartTime: start, EndTime: start, InProgress: true}
+	if err := store.Save(event); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// 継続中の更新はメモリ上にのみ反映され、ファイルには書き込まない
+	event.EndTime = start.Add(time.Minute)
+	store.Update(event)
+	if got, _ := store.Get("a"); !got.EndTime.Equal(event.EndTime) {
+		t.Errorf("Expected updated end time, got %v", got.EndTime)
+	}
+
+	reopened, err := OpenStore(dir, 30)
+	if err != nil {
+		t.Fatalf("OpenStore failed: %v", err)
+	}
+	if got, _ := reopened.Get("a"); !got.EndTime.Equal(start) {
+		t.Errorf("Expected update not to be written, got end time %v", got.EndTime)
+	}
+}
@@ -0,0 +1,218 @@
+package motion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// eventsFileName はイベント一覧のファイル名
+	eventsFileName = "events.json"
+
+	// snapshotsDirName はスナップショットを保存するディレクトリ名
+	snapshotsDirName = "snapshots"
+)
+
+// ErrEventNotFound は指定されたイベントが存在しない場合のエラー
+var ErrEventNotFound = errors.New("イベントが見つかりません")
+
+// Store はイベントとスナップショットをローカルに保存する
+type Store struct {
+	dir           string
+	retentionDays int
+
+	mu     sync.RWMutex
+	events []Event
+}
+
+// eventIndex はイベント一覧ファイルの内容
+type eventIndex struct {
+	Events []Event `json:"events"`
+}
+
+// OpenStore はイベントの保存先を開き、保存済みのイベントを読み込む
+// 前回の終了時に継続中だったイベントは終了扱いにする
+func OpenStore(dir string, retentionDays int) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(dir, snapshotsDirName), 0755); err != nil {
+		return nil, fmt.Errorf("イベントディレクトリの作成に失敗: %w", err)
+	}
+
+	store := &Store{
+		dir:           dir,
+		retentionDays: retentionDays,
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, eventsFileName))
+	switch {
+	case err == nil:
+		var index eventIndex
+		if err := json.Unmarshal(data, &index); err != nil {
+			return nil, fmt.Errorf("イベント一覧の解析に失敗: %w", err)
+		}
+		store.events = index.Events
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("イベント一覧の読み込みに失敗: %w", err)
+	}
+
+	for i := range store.events {
+		store.events[i].InProgress = false
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.prune(time.Now())
+	return store, store.save()
+}
+
+// Save はイベントを追加または更新してイベント一覧を書き込む
+func (s *Store) Save(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.upsert(event)
+	s.prune(time.Now())
+	return s.save()
+}
+
+// Update はイベントを追加または更新する（イベント一覧は書き込まない）
+// 継続中のイベントの終了時刻・スコアのように頻繁に変わる値に使用し、
+// ファイルにはイベントの開始・終了時に Save で書き込む
+func (s *Store) Update(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.upsert(event)
+}
+
+// upsert はイベントを追加または更新する（ロック済み前提）
+func (s *Store) upsert(event Event) {
+	for i := range s.events {
+		if s.events[i].ID == event.ID {
+			s.events[i] = event
+			return
+		}
+	}
+	s.events = append(s.events, event)
+}
+
+// SaveSnapshot はイベントのスナップショットを保存する
+func (s *Store) SaveSnapshot(id string, data []byte) error {
+	path := s.snapshotPath(id)
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("スナップショットの書き込みに失敗: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("スナップショットの置き換えに失敗: %w", err)
+	}
+	return nil
+}
+
+// Get は指定IDのイベントを返す
+func (s *Store) Get(id string) (Event, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, event := range s.events {
+		if event.ID == id {
+			return event, true
+		}
+	}
+	return Event{}, false
+}
+
+// Snapshot は指定IDのイベントのスナップショットを返す
+func (s *Store) Snapshot(id string) ([]byte, error) {
+	if _, exists := s.Get(id); !exists {
+		return nil, ErrEventNotFound
+	}
+
+	data, err := os.ReadFile(s.snapshotPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("スナップショットの読み込みに失敗: %w", err)
+	}
+	return data, nil
+}
+
+// List は条件に一致するイベントを新しい順に返す
+func (s *Store) List(filter Filter) []Event {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]Event, 0)
+	for _, event := range s.events {
+		if filter.SourceID != "" && event.SourceID != filter.SourceID {
+			continue
+		}
+		if filter.Visible != nil && !filter.Visible(event.SourceID) {
+			continue
+		}
+		if !filter.From.IsZero() && event.EndTime.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && event.StartTime.After(filter.To) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].StartTime.After(events[j].StartTime)
+	})
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events
+}
+
+// snapshotPath はスナップショットのファイルパスを返す
+func (s *Store) snapshotPath(id string) string {
+	return filepath.Join(s.dir, snapshotsDirName, id+".jpg")
+}
+
+// prune は保持期間を過ぎたイベントとスナップショットを削除する（ロック済み前提）
+func (s *Store) prune(now time.Time) {
+	if s.retentionDays <= 0 {
+		return
+	}
+
+	cutoff := now.AddDate(0, 0, -s.retentionDays)
+	kept := s.events[:0]
+	for _, event := range s.events {
+		if !event.InProgress && event.EndTime.Before(cutoff) {
+			_ = os.Remove(s.snapshotPath(event.ID))
+			continue
+		}
+		kept = append(kept, event)
+	}
+	s.events = kept
+}
+
+// save はイベント一覧を書き込む（ロック済み前提）
+// 書き込み途中のファイルを読まれないよう一時ファイル経由で置き換える
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(eventIndex{Events: s.events}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("イベント一覧のエンコードに失敗: %w", err)
+	}
+
+	path := filepath.Join(s.dir, eventsFileName)
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("イベント一覧の書き込みに失敗: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("イベント一覧の置き換えに失敗: %w", err)
+	}
+	return nil
+}
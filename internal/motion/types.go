@@ -0,0 +1,98 @@
+package motion
+
+import (
+	"time"
+
+	"senrigan/internal/numeric"
+)
+
+// Config は動体検知の全体設定
+type Config struct {
+	OutputDir     string        `yaml:"output_dir"`     // イベントの保存先ディレクトリ
+	Threshold     int           `yaml:"threshold"`      // 画素毎の輝度差の閾値 (1-255)
+	Sensitivity   int           `yaml:"sensitivity"`    // 感度 (1-100)
+	AnalysisWidth int           `yaml:"analysis_width"` // 解析時に縮小する画像幅
+	Interval      time.Duration `yaml:"interval"`       // 解析間隔
+	EventGap      time.Duration `yaml:"event_gap"`      // 動きが途絶えてからイベントを終了するまでの時間
+	RetentionDays int           `yaml:"retention_days"` // 保持期間（日数、0は無期限）
+}
+
+// SourceOptions は映像ソース毎の動体検知設定
+// ゼロ値の項目は全体設定の値を使用する
+type SourceOptions struct {
+	Enabled     bool     `yaml:"enabled"`     // 動体検知の有効/無効
+	Threshold   int      `yaml:"threshold"`   // 画素毎の輝度差の閾値
+	Sensitivity int      `yaml:"sensitivity"` // 感度
+	Masks       []Region `yaml:"masks"`       // 検知対象外の領域
+}
+
+// Region は画像内の矩形領域
+// 解像度に依存しないよう、画像全体を1とした比率で指定する
+type Region struct {
+	X      float64 `yaml:"x"`
+	Y      float64 `yaml:"y"`
+	Width  float64 `yaml:"width"`
+	Height float64 `yaml:"height"`
+}
+
+// Event は動体検知イベント
+type Event struct {
+	ID         string    `json:"id"`          // イベントID
+	SourceID   string    `json:"source_id"`   // 映像ソースID
+	SourceName string    `json:"source_name"` // 映像ソース名
+	StartTime  time.Time `json:"start_time"`  // 最初に動きを検知した時刻
+	EndTime    time.Time `json:"end_time"`    // 最後に動きを検知した時刻
+	PeakScore  float64   `json:"peak_score"`  // イベント中の最大スコア
+	InProgress bool      `json:"in_progress"` // 動きが継続中か
+}
+
+// Filter はイベント一覧の絞り込み条件
+// ゼロ値の項目は条件に含めない
+type Filter struct {
+	SourceID string                     // 映像ソースID
+	From     time.Time                  // この時刻以降に終了したイベント
+	To       time.Time                  // この時刻以前に開始したイベント
+	Limit    int                        // 最大件数
+	Visible  func(sourceID string) bool // 含める映像ソースの判定（閲覧できるカメラへの制限用）
+}
+
+// settings は全体設定で補完済みのソース毎の検知設定
+type settings struct {
+	Threshold     int
+	Sensitivity   int
+	AnalysisWidth int
+	Interval      time.Duration
+	EventGap      time.Duration
+	Masks         []Region
+}
+
+// DefaultConfig はデフォルトの動体検知設定を返す
+func DefaultConfig() Config {
+	return Config{
+		OutputDir:     "./data/events",
+		Threshold:     25,
+		Sensitivity:   80,
+		AnalysisWidth: 160,
+		Interval:      200 * time.Millisecond,
+		EventGap:      5 * time.Second,
+		RetentionDays: 30,
+	}
+}
+
+// resolve はソース毎の設定に全体設定とデフォルト値を補完した検知設定を返す
+func (o SourceOptions) resolve(config Config) settings {
+	defaults := DefaultConfig()
+	return settings{
+		Threshold:     numeric.FirstPositive(o.Threshold, config.Threshold, defaults.Threshold),
+		Sensitivity:   numeric.FirstPositive(o.Sensitivity, config.Sensitivity, defaults.Sensitivity),
+		AnalysisWidth: numeric.FirstPositive(config.AnalysisWidth, defaults.AnalysisWidth),
+		Interval:      numeric.FirstPositive(config.Interval, defaults.Interval),
+		EventGap:      numeric.FirstPositive(config.EventGap, defaults.EventGap),
+		Masks:         o.Masks,
+	}
+}
+
+// minScore は感度から動きありと判定するスコアの下限を返す
+func minScore(sensitivity int) float64 {
+	return float64(101-sensitivity) / 1000
+}
@@ -0,0 +1,11 @@
+// Package numeric は各パッケージで共通に使う数値の小さな補助関数を提供します。
+//
+// 主な機能:
+// - 複数の候補から最初の正の値を選択
+//
+// 責務:
+// - ソース毎の設定・全体設定・デフォルト値の優先順位に従った補完（動体検知・録画）
+//
+// 仕様:
+// - 0 以下の値は未指定として扱う
+package numeric
@@ -0,0 +1,13 @@
+package numeric
+
+import "time"
+
+// FirstPositive は最初の正の値を返す（正の値がない場合は0）
+func FirstPositive[T int | time.Duration](values ...T) T {
+	for _, value := range values {
+		if value > 0 {
+			return value
+		}
+	}
+	return 0
+}
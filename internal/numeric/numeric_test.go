@@ -0,0 +1,18 @@
+package numeric
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFirstPositive(t *testing.T) {
+	if got := FirstPositive(0, -1, 3, 5); got != 3 {
+		t.Errorf("Expected 3, got %d", got)
+	}
+	if got := FirstPositive(0, 2*time.Second); got != 2*time.Second {
+		t.Errorf("Expected 2s, got %v", got)
+	}
+	if got := FirstPositive[int](); got != 0 {
+		t.Errorf("Expected 0 without positive values, got %d", got)
+	}
+}
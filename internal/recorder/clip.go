@@ -0,0 +1,430 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"senrigan/internal/camera"
+)
+
+// defaultClipFrameRate はソースのフレームレートが不明な場合のクリップのフレームレート
+const defaultClipFrameRate = 15
+
+// ClipRecorder は単一の映像ソースの直近のフレームをメモリに保持し、
+// トリガーを受けるとプリロールを含むクリップを録画する
+type ClipRecorder struct {
+	source    FrameSource
+	sourceID  string
+	dir       string
+	format    string
+	frameRate int
+	options   ClipOptions
+	newWriter writerFactory
+
+	// リングバッファ・インデックス・録画中のクリップ
+	mu      sync.Mutex
+	ring    []camera.Frame
+	index   clipIndex
+	current *activeClip
+
+	// 制御用
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// activeClip は書き込み中のクリップ
+// ffmpegの起動・書き込み・終了は ClipRecorder.mu を解放して writeMu の下で行う
+// （ClipRecorder.mu を保持したまま writeMu を取得してよいのは公開前のクリップのみ）
+type activeClip struct {
+	writeMu sync.Mutex
+	writer  *pacedWriter // 起動に失敗した場合はnil
+	failed  bool         // 書き込みに失敗した（以降のフレームは書き込まない）
+	closed  bool         // 確定済み（以降のフレームは書き込まない）
+
+	// 以下は ClipRecorder.mu で保護する
+	path     string
+	deadline time.Time // ポストロールの終了時刻
+	limit    time.Time // 最大長による終了時刻
+	clip     Clip
+}
+
+// NewClipRecorder は新しいClipRecorderを作成する
+// options は全体設定で補完済みであること
+// frameRate はクリップの出力フレームレート（0以下の場合はデフォルト値）
+func NewClipRecorder(source FrameSource, outputDir, format string, frameRate int, options ClipOptions) *ClipRecorder {
+	if frameRate <= 0 {
+		frameRate = defaultClipFrameRate
+	}
+
+	sourceID := source.GetInfo().ID
+	return &ClipRecorder{
+		source:    source,
+		sourceID:  sourceID,
+		dir:       filepath.Join(outputDir, sourceID),
+		format:    format,
+		frameRate: frameRate,
+		options:   options,
+		newWriter: newFFmpegSegmentWriter,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start はフレームのバッファリングを開始する
+func (r *ClipRecorder) Start(ctx context.Context) error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("クリップディレクトリの作成に失敗: %w", err)
+	}
+
+	index, err := loadClipIndex(r.dir)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.index = index
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go r.run(ctx)
+
+	log.Printf("映像ソース %s のイベント録画を開始 (プリロール %s, ポストロール %s)", r.sourceID, r.options.PreRoll, r.options.PostRoll)
+	return nil
+}
+
+// Stop はバッファリングを停止し、録画中のクリップを確定する
+func (r *ClipRecorder) Stop(_ context.Context) error {
+	close(r.stopCh)
+	r.wg.Wait()
+
+	log.Printf("映像ソース %s のイベント録画を停止", r.sourceID)
+	return nil
+}
+
+// Trigger はクリップの録画を開始する
+// 録画中の場合は新しいクリップを作らず、ポストロールの終了時刻を延長する
+func (r *ClipRecorder) Trigger(reason string) (TriggerResult, error) {
+	if reason == TriggerMotion && !r.options.OnMotion {
+		return TriggerResult{}, ErrTriggerDisabled
+	}
+
+	now := time.Now()
+
+	r.mu.Lock()
+
+	// 最大長に達したクリップは確定して新しいクリップにする
+	var expired *activeClip
+	if r.current != nil && !now.Before(r.current.limit) {
+		expired = r.current
+		r.current = nil
+	}
+
+	if r.current != nil {
+		r.current.deadline = r.clampDeadline(now.Add(r.options.PostRoll), r.current)
+		r.current.clip.Triggers = appendTrigger(r.current.clip.Triggers, reason)
+		result := TriggerResult{Clip: r.current.clip, Until: r.current.deadline, Extended: true}
+		r.mu.Unlock()
+		return result, nil
+	}
+
+	current, preRoll := r.newClipLocked(now, reason)
+	result := TriggerResult{Clip: current.clip, Until: current.deadline}
+	r.mu.Unlock()
+
+	r.closeClip(expired)
+	if err := r.openClip(current, preRoll); err != nil {
+		return TriggerResult{}, err
+	}
+
+	log.Printf("映像ソース %s のクリップ録画を開始 (%s)", r.sourceID, reason)
+	return result, nil
+}
+
+// Clips は録画済みクリップと録画中のクリップを開始時刻順に返す
+func (r *ClipRecorder) Clips() []Clip {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clips := make([]Clip, 0, len(r.index.Clips)+1)
+	clips = append(clips, r.index.Clips...)
+	if r.current != nil && r.current.clip.FrameCount > 0 {
+		clips = append(clips, r.current.clip)
+	}
+	return clips
+}
+
+// Dir はクリップファイルの出力ディレクトリを返す
+func (r *ClipRecorder) Dir() string {
+	return r.dir
+}
+
+// run はソースを購読してフレームをバッファリング・書き込みする
+func (r *ClipRecorder) run(ctx context.Context) {
+	defer r.wg.Done()
+	defer r.reset()
+
+	for {
+		subscription := r.source.Subscribe(recorderBufferSize, camera.DropOldest)
+		stopped := r.consume(ctx, subscription)
+		subscription.Unsubscribe()
+
+		// ソースが停止した場合は録画中のクリップを確定し、古いフレームを破棄する
+		r.reset()
+		if stopped {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopCh:
+			return
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+// consume は購読が終了するまでフレームを処理する
+// 録画の停止が要求された場合はtrueを返す
+func (r *ClipRecorder) consume(ctx context.Context, subscription *camera.FrameSubscription) bool {
+	// フレームが途絶えてもクリップを期限で閉じ、時間帯トリガーを確認するためのティッカー
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-r.stopCh:
+			return true
+		case frame, ok := <-subscription.Frames():
+			if !ok {
+				return false // ソースが停止した
+			}
+			r.handleFrame(frame)
+		case now := <-ticker.C:
+			r.checkDeadline(now)
+			if r.inSchedule(now) {
+				if _, err := r.Trigger(TriggerSchedule); err != nil {
+					log.Printf("映像ソース %s の時間帯録画に失敗: %v", r.sourceID, err)
+				}
+			}
+		}
+	}
+}
+
+// handleFrame は録画中であればフレームを書き込み、そうでなければリングバッファに入れる
+func (r *ClipRecorder) handleFrame(frame camera.Frame) {
+	r.mu.Lock()
+	var expired *activeClip
+	if r.current != nil && frame.Timestamp.After(r.current.deadline) {
+		expired = r.current
+		r.current = nil
+	}
+	current := r.current
+	if current == nil {
+		r.bufferFrameLocked(frame)
+	}
+	r.mu.Unlock()
+
+	r.closeClip(expired)
+	if current != nil {
+		current.writeMu.Lock()
+		r.writeFrame(current, frame)
+		current.writeMu.Unlock()
+	}
+}
+
+// bufferFrameLocked はフレームをリングバッファに入れ、プリロールより古いフレームを破棄する（ロック済み前提）
+func (r *ClipRecorder) bufferFrameLocked(frame camera.Frame) {
+	r.ring = append(r.ring, frame)
+
+	// プリロールより古いフレームを破棄する
+	cutoff := frame.Timestamp.Add(-r.options.PreRoll)
+	drop := 0
+	for drop < len(r.ring) && r.ring[drop].Timestamp.Before(cutoff) {
+		drop++
+	}
+	if drop > 0 {
+		r.ring = append(r.ring[:0], r.ring[drop:]...)
+	}
+}
+
+// checkDeadline はポストロールを過ぎたクリップを確定する
+func (r *ClipRecorder) checkDeadline(now time.Time) {
+	r.mu.Lock()
+	var expired *activeClip
+	if r.current != nil && now.After(r.current.deadline) {
+		expired = r.current
+		r.current = nil
+	}
+	r.mu.Unlock()
+
+	r.closeClip(expired)
+}
+
+// inSchedule は時刻が録画時間帯に含まれるか判定する
+func (r *ClipRecorder) inSchedule(now time.Time) bool {
+	for _, window := range r.options.Schedule {
+		if window.Contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// reset は録画中のクリップを確定し、リングバッファを破棄する
+func (r *ClipRecorder) reset() {
+	r.mu.Lock()
+	current := r.current
+	r.current = nil
+	r.ring = nil
+	r.mu.Unlock()
+
+	r.closeClip(current)
+}
+
+// newClipLocked は録画中のクリップを作成し、プリロールとしてリングバッファのフレームを引き取る（ロック済み前提）
+// 返すクリップは writeMu を取得済みで、openClip で書き込みを開始するまで他のフレームは書き込まれない
+func (r *ClipRecorder) newClipLocked(now time.Time, reason string) (*activeClip, []camera.Frame) {
+	start := now
+	if len(r.ring) > 0 {
+		start = r.ring[0].Timestamp
+	}
+
+	fileName := fmt.Sprintf("clip_%s.%s", start.Format("20060102_150405"), r.format)
+
+	current := &activeClip{
+		path:  filepath.Join(r.dir, fileName),
+		limit: start.Add(r.options.MaxDuration),
+		clip: Clip{
+			SourceID:  r.sourceID,
+			FileName:  fileName,
+			StartTime: start,
+			EndTime:   start,
+			Triggers:  []string{reason},
+		},
+	}
+	current.deadline = r.clampDeadline(now.Add(r.options.PostRoll), current)
+	current.writeMu.Lock()
+
+	preRoll := r.ring
+	r.ring = nil
+	r.current = current
+
+	return current, preRoll
+}
+
+// openClip はクリップファイルを作成し、プリロールを書き込んで writeMu を解放する
+// 作成に失敗した場合は録画中のクリップを取り消し、プリロールをリングバッファに戻す
+func (r *ClipRecorder) openClip(current *activeClip, preRoll []camera.Frame) error {
+	defer current.writeMu.Unlock()
+
+	writer, err := r.newWriter(current.path, r.format, r.frameRate)
+	if err != nil {
+		current.failed = true
+
+		r.mu.Lock()
+		if r.current == current {
+			r.current = nil
+			r.ring = append(preRoll, r.ring...)
+		}
+		r.mu.Unlock()
+		return err
+	}
+	current.writer = newPacedWriter(writer, r.frameRate, current.clip.StartTime)
+
+	for _, frame := range preRoll {
+		r.writeFrame(current, frame)
+	}
+	return nil
+}
+
+// writeFrame はフレームをクリップに書き込む（writeMu を取得済み前提）
+func (r *ClipRecorder) writeFrame(current *activeClip, frame camera.Frame) {
+	if current.failed || current.closed {
+		return
+	}
+	written, err := current.writer.WriteFrame(frame.Data, frame.Timestamp)
+	if err != nil {
+		// ffmpegが終了した場合等は以降の書き込みも失敗するため、最初のエラーのみ記録する
+		log.Printf("映像ソース %s のフレーム書き込みに失敗（クリップの残りは書き込みません）: %v", r.sourceID, err)
+		current.failed = true
+		return
+	}
+	if !written {
+		return
+	}
+
+	r.mu.Lock()
+	current.clip.EndTime = frame.Timestamp
+	current.clip.FrameCount++
+	r.mu.Unlock()
+}
+
+// clampDeadline はクリップの最大長を超えないよう終了時刻を調整する
+func (r *ClipRecorder) clampDeadline(deadline time.Time, current *activeClip) time.Time {
+	if deadline.After(current.limit) {
+		return current.limit
+	}
+	if deadline.Before(current.deadline) {
+		return current.deadline // 延長で短くはしない
+	}
+	return deadline
+}
+
+// closeClip は録画中から外したクリップを確定してインデックスに追加する（ロックは取得しない）
+// ffmpegの終了を待つ間も他のトリガーやクリップ一覧の取得を止めないよう、mu を解放して呼び出す
+func (r *ClipRecorder) closeClip(current *activeClip) {
+	if current == nil {
+		return
+	}
+
+	// 起動・書き込み中であれば完了を待ち、以降の書き込みを止める
+	current.writeMu.Lock()
+	current.closed = true
+	writer := current.writer
+	current.writeMu.Unlock()
+
+	if writer != nil {
+		if err := writer.Close(); err != nil {
+			log.Printf("映像ソース %s のクリップ確定に失敗: %v", r.sourceID, err)
+		}
+	}
+
+	r.mu.Lock()
+	clip := current.clip
+	r.mu.Unlock()
+
+	if clip.FrameCount == 0 {
+		_ = os.Remove(current.path) // 空のクリップは残さない
+		return
+	}
+
+	if info, err := os.Stat(current.path); err == nil {
+		clip.FileSize = info.Size()
+	}
+
+	r.mu.Lock()
+	r.index.Clips = append(r.index.Clips, clip)
+	err := saveClipIndex(r.dir, r.index)
+	r.mu.Unlock()
+	if err != nil {
+		log.Printf("映像ソース %s のクリップインデックス保存に失敗: %v", r.sourceID, err)
+	}
+	log.Printf("映像ソース %s のクリップを保存しました: %s", r.sourceID, clip.FileName)
+}
+
+// appendTrigger は未登録のトリガーの種類を追加する
+func appendTrigger(triggers []string, reason string) []string {
+	for _, trigger := range triggers {
+		if trigger == reason {
+			return triggers
+		}
+	}
+	return append(triggers, reason)
+}
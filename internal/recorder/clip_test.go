@@ -0,0 +1,213 @@
+package recorder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryWriter は書き込まれたフレームを記録する
+type memoryWriter struct {
+	frames []string
+}
+
+func (w *memoryWriter) WriteFrame(frame []byte) error {
+	w.frames = append(w.frames, string(frame))
+	return nil
+}
+
+func (w *memoryWriter) Close() error {
+	return nil
+}
+
+func TestPacedWriter(t *testing.T) {
+	start := time.Now()
+	output := &memoryWriter{}
+	writer := newPacedWriter(output, 10, start)
+
+	frames := []struct {
+		data   string
+		offset time.Duration
+	}{
+		{data: "a", offset: 0},
+		{data: "b", offset: 50 * time.Millisecond}, // 10fpsの1フレーム未満なので間引かれる
+		{data: "c", offset: 300 * time.Millisecond},
+	}
+	for _, frame := range frames {
+		if _, err := writer.WriteFrame([]byte(frame.data), start.Add(frame.offset)); err != nil {
+			t.Fatalf("WriteFrame failed: %v", err)
+		}
+	}
+
+	// 間隔が空いた部分は直前のフレームで埋められる
+	if got := strings.Join(output.frames, ""); got != "aaac" {
+		t.Errorf("Expected frames aaac, got %s", got)
+	}
+}
+
+func TestClipRecorder_PreRollAndExtend(t *testing.T) {
+	ctx := context.Background()
+	outputDir := t.TempDir()
+	source := newFakeSource("camera_test")
+
+	recorder := NewClipRecorder(source, outputDir, FormatMKV, 100, ClipOptions{
+		Enabled:     true,
+		PreRoll:     time.Minute,
+		PostRoll:    300 * time.Millisecond,
+		MaxDuration: time.Minute,
+	})
+	recorder.newWriter = newFileWriter
+
+	if err := recorder.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() { _ = recorder.Stop(ctx) }()
+	waitForSubscriber(t, source)
+
+	// トリガー前のフレームはリングバッファに保持される
+	source.broadcaster.Publish([]byte("pre1"))
+	time.Sleep(20 * time.Millisecond)
+	source.broadcaster.Publish([]byte("pre2"))
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := recorder.Trigger(TriggerMotion); err != ErrTriggerDisabled {
+		t.Errorf("Expected ErrTriggerDisabled for motion trigger, got %v", err)
+	}
+
+	result, err := recorder.Trigger(TriggerManual)
+	if err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+	if result.Extended {
+		t.Error("Expected a new clip")
+	}
+
+	source.broadcaster.Publish([]byte("post1"))
+	time.Sleep(100 * time.Millisecond)
+
+	// 録画中のトリガーは同じクリップを延長する
+	extended, err := recorder.Trigger(TriggerSchedule)
+	if err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+	if !extended.Extended || extended.Clip.FileName != result.Clip.FileName {
+		t.Errorf("Expected the same clip to be extended, got %+v", extended)
+	}
+	if !extended.Until.After(result.Until) {
+		t.Error("Expected the deadline to be extended")
+	}
+
+	// ポストロールを過ぎるとクリップが確定される
+	time.Sleep(1500 * time.Millisecond)
+
+	clips := recorder.Clips()
+	if len(clips) != 1 {
+		t.Fatalf("Expected 1 clip, got %d", len(clips))
+	}
+	clip := clips[0]
+	if clip.FrameCount != 3 {
+		t.Errorf("Expected 3 frames including pre-roll, got %d", clip.FrameCount)
+	}
+	if strings.Join(clip.Triggers, ",") != "manual,schedule" {
+		t.Errorf("Unexpected triggers: %v", clip.Triggers)
+	}
+
+	data, err := os.ReadFile(filepath.Join(recorder.Dir(), clip.FileName))
+	if err != nil {
+		t.Fatalf("Clip file not found: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "pre1") || !strings.HasSuffix(string(data), "post1") {
+		t.Errorf("Expected clip to start with pre-roll and end with post-roll frame, got %q", data)
+	}
+
+	index, err := loadClipIndex(recorder.Dir())
+	if err != nil {
+		t.Fatalf("loadClipIndex failed: %v", err)
+	}
+	if len(index.Clips) != 1 {
+		t.Errorf("Expected 1 clip in index, got %d", len(index.Clips))
+	}
+}
+
+func TestClipRecorder_SlowWriterDoesNotBlockTrigger(t *testing.T) {
+	ctx := context.Background()
+	source := newFakeSource("camera_test")
+
+	recorder := NewClipRecorder(source, t.TempDir(), FormatMKV, 100, ClipOptions{
+		Enabled:     true,
+		PreRoll:     time.Minute,
+		PostRoll:    time.Minute,
+		MaxDuration: time.Minute,
+	})
+	writer := &blockingWriter{writing: make(chan struct{}), release: make(chan struct{})}
+	recorder.newWriter = func(_, _ string, _ int) (segmentWriter, error) {
+		return writer, nil
+	}
+
+	if err := recorder.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	waitForSubscriber(t, source)
+	source.broadcaster.Publish([]byte("pre"))
+	time.Sleep(20 * time.Millisecond)
+
+	// プリロールの書き込みが終わらないトリガー
+	go func() { _, _ = recorder.Trigger(TriggerManual) }()
+	<-writer.writing
+
+	// 書き込み中でも他のトリガーとクリップの一覧は止まらない
+	done := make(chan struct{})
+	go func() {
+		if result, err := recorder.Trigger(TriggerSchedule); err != nil || !result.Extended {
+			t.Errorf("Expected the clip to be extended, got %+v, %v", result, err)
+		}
+		recorder.Clips()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Trigger blocked while the writer was busy")
+	}
+
+	close(writer.release)
+	if err := recorder.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+}
+
+func TestScheduleWindow_Contains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	daytime := ScheduleWindow{Start: "08:00", End: "09:30"}
+	overnight := ScheduleWindow{Start: "22:00", End: "06:00"}
+
+	testCases := []struct {
+		window   ScheduleWindow
+		time     time.Time
+		expected bool
+	}{
+		{window: daytime, time: at(8, 0), expected: true},
+		{window: daytime, time: at(9, 29), expected: true},
+		{window: daytime, time: at(9, 30), expected: false},
+		{window: daytime, time: at(7, 59), expected: false},
+		{window: overnight, time: at(23, 0), expected: true},
+		{window: overnight, time: at(5, 59), expected: true},
+		{window: overnight, time: at(12, 0), expected: false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.window.Contains(tc.time); got != tc.expected {
+			t.Errorf("%+v.Contains(%s) = %v, want %v", tc.window, tc.time.Format("15:04"), got, tc.expected)
+		}
+	}
+
+	if err := (ScheduleWindow{Start: "8:00", End: "24:00"}).Validate(); err == nil {
+		t.Error("Expected validation error for invalid time")
+	}
+}
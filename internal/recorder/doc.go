@@ -0,0 +1,24 @@
+// Package recorder は映像ソース毎の連続録画（DVRモード）を提供します。
+//
+// 主な機能:
+// - 映像ソースのフレーム配信を購読してリアルタイム映像を録画
+// - 固定長（デフォルト5分）のセグメントファイルに分割して保存
+// - セグメントの開始・終了時刻をカメラ毎のインデックスに記録
+// - 直近のフレームをメモリに保持し、トリガー時にプリロールを含むクリップを録画
+//
+// 責務:
+// - Manager: 設定に基づく録画対象ソースの管理
+// - Recorder: 単一ソースのセグメント録画とインデックス管理
+// - ClipRecorder: 単一ソースのリングバッファとトリガーによるクリップ録画
+//
+// 仕様:
+// - 出力先: <出力ディレクトリ>/<ソースID>/segment_YYYYMMDD_HHMMSS.<形式>
+// - インデックス: <出力ディレクトリ>/<ソースID>/index.json
+// - 保持: retention_days を過ぎたセグメントと、全映像ソースの合計が max_size_mb を超える分の古いセグメントを1分毎に削除（録画を停止した映像ソースも対象）
+// - セグメント境界は時計の区切り（5分毎なら 00分, 05分, ...）に揃える
+// - MKV（デフォルト）はMJPEGをそのまま格納、MP4 はH.264に変換する（異常終了しても再生できるフラグメント化MP4）
+// - ソースが停止した場合はセグメントを閉じ、再開後に新しいセグメントを開始する
+// - クリップ: <クリップ出力ディレクトリ>/<ソースID>/clip_YYYYMMDD_HHMMSS.<形式>
+// - トリガー: 手動（API）・動体検知・時間帯指定。録画中のトリガーは同じクリップを延長する
+// - クリップはキャプチャ時刻に合わせた固定フレームレートで書き出す（プリロールも元の間隔で再生される）
+package recorder
@@ -0,0 +1,83 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// indexFileName はセグメントインデックスのファイル名
+const indexFileName = "index.json"
+
+// segmentIndex はカメラ毎のセグメント一覧
+type segmentIndex struct {
+	Segments []Segment `json:"segments"`
+}
+
+// clipIndex はカメラ毎のクリップ一覧
+type clipIndex struct {
+	Clips []Clip `json:"clips"`
+}
+
+// loadIndex はセグメントのインデックスファイルを読み込む
+// ファイルが存在しない場合は空のインデックスを返す
+func loadIndex(dir string) (segmentIndex, error) {
+	var index segmentIndex
+	err := readIndexFile(dir, &index)
+	return index, err
+}
+
+// saveIndex はセグメントのインデックスファイルを書き込む
+func saveIndex(dir string, index segmentIndex) error {
+	return writeIndexFile(dir, index)
+}
+
+// loadClipIndex はクリップのインデックスファイルを読み込む
+// ファイルが存在しない場合は空のインデックスを返す
+func loadClipIndex(dir string) (clipIndex, error) {
+	var index clipIndex
+	err := readIndexFile(dir, &index)
+	return index, err
+}
+
+// saveClipIndex はクリップのインデックスファイルを書き込む
+func saveClipIndex(dir string, index clipIndex) error {
+	return writeIndexFile(dir, index)
+}
+
+// readIndexFile はインデックスファイルを読み込む
+// ファイルが存在しない場合は index を変更しない
+func readIndexFile(dir string, index interface{}) error {
+	data, err := os.ReadFile(filepath.Join(dir, indexFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("インデックスの読み込みに失敗: %w", err)
+	}
+
+	if err := json.Unmarshal(data, index); err != nil {
+		return fmt.Errorf("インデックスの解析に失敗: %w", err)
+	}
+	return nil
+}
+
+// writeIndexFile はインデックスファイルを書き込む
+// 書き込み途中のファイルを読まれないよう一時ファイル経由で置き換える
+func writeIndexFile(dir string, index interface{}) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("インデックスのエンコードに失敗: %w", err)
+	}
+
+	path := filepath.Join(dir, indexFileName)
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("インデックスの書き込みに失敗: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("インデックスの置き換えに失敗: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"senrigan/internal/camera"
+)
+
+const (
+	// syncInterval は録画対象ソースを見直す間隔
+	syncInterval = 10 * time.Second
+
+	// pruneInterval は保持期間・合計サイズの上限を超えたセグメントを削除する間隔
+	pruneInterval = time.Minute
+)
+
+// Manager は連続録画全体を管理するインターフェース
+type Manager interface {
+	// システム制御
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+
+	// イベント録画
+	Trigger(sourceID, reason string) (TriggerResult, error)
+
+	// データ取得
+	GetSegments(sourceID string) ([]Segment, error)
+	GetClips(sourceID string) ([]Clip, error)
+}
+
+// DefaultManager はManagerのデフォルト実装
+type DefaultManager struct {
+	cameraManager camera.Manager
+	config        Config
+	options       map[string]SourceOptions // デバイスパスをキーとするソース毎の設定
+	recorders     map[string]*Recorder     // ソースIDをキーとする録画中のRecorder
+	clipRecorders map[string]*ClipRecorder // ソースIDをキーとするイベント録画中のClipRecorder
+	mu            sync.RWMutex
+
+	// 制御用
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDefaultManager は新しいDefaultManagerを作成する
+// options はデバイスパスをキーとしたソース毎の録画設定
+func NewDefaultManager(cameraManager camera.Manager, config Config, options map[string]SourceOptions) *DefaultManager {
+	if config.OutputDir == "" {
+		config.OutputDir = DefaultConfig().OutputDir
+	}
+	if config.Clips.OutputDir == "" {
+		config.Clips.OutputDir = DefaultConfig().Clips.OutputDir
+	}
+
+	return &DefaultManager{
+		cameraManager: cameraManager,
+		config:        config,
+		options:       options,
+		recorders:     make(map[string]*Recorder),
+		clipRecorders: make(map[string]*ClipRecorder),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start は録画を開始する
+// ソースの追加・削除に追従するため、定期的に録画対象を見直す
+func (m *DefaultManager) Start(ctx context.Context) error {
+	if !m.hasEnabledSource() {
+		log.Println("録画が有効なカメラはありません")
+		return nil
+	}
+
+	m.syncRecorders(ctx)
+	m.pruneSegments(time.Now())
+
+	m.wg.Add(1)
+	go m.syncLoop(ctx)
+
+	return nil
+}
+
+// Stop は全ての録画を停止する
+func (m *DefaultManager) Stop(ctx context.Context) error {
+	close(m.stopCh)
+	m.wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, recorder := range m.recorders {
+		if err := recorder.Stop(ctx); err != nil {
+			log.Printf("映像ソース %s の録画停止に失敗: %v", id, err)
+		}
+		delete(m.recorders, id)
+	}
+	for id, recorder := range m.clipRecorders {
+		if err := recorder.Stop(ctx); err != nil {
+			log.Printf("映像ソース %s のイベント録画停止に失敗: %v", id, err)
+		}
+		delete(m.clipRecorders, id)
+	}
+
+	return nil
+}
+
+// Trigger は指定ソースのクリップ録画を開始・延長する
+func (m *DefaultManager) Trigger(sourceID, reason string) (TriggerResult, error) {
+	m.mu.RLock()
+	recorder, exists := m.clipRecorders[sourceID]
+	m.mu.RUnlock()
+
+	if !exists {
+		return TriggerResult{}, ErrTriggerDisabled
+	}
+	return recorder.Trigger(reason)
+}
+
+// GetSegments は指定ソースの録画セグメント一覧を取得する
+func (m *DefaultManager) GetSegments(sourceID string) ([]Segment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recorder, exists := m.recorders[sourceID]
+	if !exists {
+		return nil, fmt.Errorf("映像ソース %s は録画されていません", sourceID)
+	}
+	return recorder.Segments(), nil
+}
+
+// GetClips は指定ソースのクリップ一覧を取得する
+func (m *DefaultManager) GetClips(sourceID string) ([]Clip, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recorder, exists := m.clipRecorders[sourceID]
+	if !exists {
+		return nil, fmt.Errorf("映像ソース %s はイベント録画されていません", sourceID)
+	}
+	return recorder.Clips(), nil
+}
+
+// hasEnabledSource は録画が有効なソース設定があるか確認する
+func (m *DefaultManager) hasEnabledSource() bool {
+	for _, options := range m.options {
+		if options.Enabled || options.Clips.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// syncLoop は定期的に録画対象を見直す
+func (m *DefaultManager) syncLoop(ctx context.Context) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.syncRecorders(ctx)
+		case now := <-pruneTicker.C:
+			m.pruneSegments(now)
+		}
+	}
+}
+
+// syncRecorders は現在の映像ソースに合わせてRecorder・ClipRecorderを開始・停止する
+func (m *DefaultManager) syncRecorders(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := make(map[string]camera.VideoSource)
+	currentClips := make(map[string]camera.VideoSource)
+	for _, source := range m.cameraManager.GetVideoSources() {
+		info := source.GetInfo()
+		options, exists := m.options[info.Device]
+		if !exists {
+			continue
+		}
+		resolved := options.resolve(m.config)
+
+		if options.Enabled {
+			current[info.ID] = source
+			m.syncRecorder(ctx, source, resolved)
+		}
+		if options.Clips.Enabled {
+			currentClips[info.ID] = source
+			m.syncClipRecorder(ctx, source, resolved)
+		}
+	}
+
+	// 削除されたソースの録画を停止
+	for id, recorder := range m.recorders {
+		if _, exists := current[id]; exists {
+			continue
+		}
+		if err := recorder.Stop(ctx); err != nil {
+			log.Printf("映像ソース %s の録画停止に失敗: %v", id, err)
+		}
+		delete(m.recorders, id)
+	}
+	for id, recorder := range m.clipRecorders {
+		if _, exists := currentClips[id]; exists {
+			continue
+		}
+		if err := recorder.Stop(ctx); err != nil {
+			log.Printf("映像ソース %s のイベント録画停止に失敗: %v", id, err)
+		}
+		delete(m.clipRecorders, id)
+	}
+}
+
+// syncRecorder はソースの連続録画を開始する（ロック済み前提）
+func (m *DefaultManager) syncRecorder(ctx context.Context, source camera.VideoSource, options SourceOptions) {
+	id := source.GetInfo().ID
+
+	// 同じIDでもソースが作り直された場合は購読し直す
+	if recorder, exists := m.recorders[id]; exists {
+		if recorder.source == FrameSource(source) {
+			return
+		}
+		_ = recorder.Stop(ctx)
+		delete(m.recorders, id)
+	}
+
+	recorder := NewRecorder(source, m.config.OutputDir, options)
+	if err := recorder.Start(ctx); err != nil {
+		log.Printf("映像ソース %s の録画開始に失敗: %v", id, err)
+		return
+	}
+	m.recorders[id] = recorder
+}
+
+// syncClipRecorder はソースのイベント録画を開始する（ロック済み前提）
+func (m *DefaultManager) syncClipRecorder(ctx context.Context, source camera.VideoSource, options SourceOptions) {
+	id := source.GetInfo().ID
+
+	// 同じIDでもソースが作り直された場合は購読し直す
+	if recorder, exists := m.clipRecorders[id]; exists {
+		if recorder.source == FrameSource(source) {
+			return
+		}
+		_ = recorder.Stop(ctx)
+		delete(m.clipRecorders, id)
+	}
+
+	frameRate := source.GetCurrentSettings().FrameRate
+	clipOptions := options.Clips.resolve(m.config.Clips)
+	recorder := NewClipRecorder(source, m.config.Clips.OutputDir, options.Format, frameRate, clipOptions)
+	if err := recorder.Start(ctx); err != nil {
+		log.Printf("映像ソース %s のイベント録画開始に失敗: %v", id, err)
+		return
+	}
+	m.clipRecorders[id] = recorder
+}
@@ -0,0 +1,295 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"senrigan/internal/camera"
+)
+
+const (
+	// recorderBufferSize は録画用購読のフレームバッファサイズ
+	// ffmpegの一時的な遅延を吸収できるよう配信用より大きめにする
+	recorderBufferSize = 30
+
+	// resubscribeInterval はソース停止後に再購読するまでの待機時間
+	resubscribeInterval = time.Second
+
+	// segmentRetryInterval は書き込みに失敗した後、新しいセグメントを作成するまでの待機時間
+	segmentRetryInterval = 5 * time.Second
+)
+
+// FrameSource は録画対象のフレーム供給元
+type FrameSource interface {
+	GetInfo() camera.VideoSourceInfo
+	Subscribe(bufferSize int, policy camera.DropPolicy) *camera.FrameSubscription
+}
+
+// Recorder は単一の映像ソースをセグメント単位で連続録画する
+type Recorder struct {
+	source    FrameSource
+	sourceID  string
+	dir       string
+	options   SourceOptions
+	newWriter writerFactory
+
+	// インデックスと録画中のセグメント
+	mu      sync.RWMutex
+	index   segmentIndex
+	current *activeSegment
+	retryAt time.Time // 書き込みに失敗した場合の次のセグメント作成時刻
+
+	// 制御用
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// activeSegment は書き込み中のセグメント
+type activeSegment struct {
+	writer   segmentWriter
+	path     string
+	deadline time.Time
+	segment  Segment
+}
+
+// NewRecorder は新しいRecorderを作成する
+// options は全体設定で補完済みであること
+func NewRecorder(source FrameSource, outputDir string, options SourceOptions) *Recorder {
+	sourceID := source.GetInfo().ID
+	return &Recorder{
+		source:    source,
+		sourceID:  sourceID,
+		dir:       filepath.Join(outputDir, sourceID),
+		options:   options,
+		newWriter: newFFmpegSegmentWriter,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Start は録画を開始する
+func (r *Recorder) Start(ctx context.Context) error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("録画ディレクトリの作成に失敗: %w", err)
+	}
+
+	index, err := loadIndex(r.dir)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.index = index
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go r.run(ctx)
+
+	log.Printf("映像ソース %s の連続録画を開始 (%s, %s毎)", r.sourceID, r.options.Format, r.options.SegmentDuration)
+	return nil
+}
+
+// Stop は録画を停止し、書き込み中のセグメントを確定する
+func (r *Recorder) Stop(_ context.Context) error {
+	close(r.stopCh)
+	r.wg.Wait()
+
+	log.Printf("映像ソース %s の連続録画を停止", r.sourceID)
+	return nil
+}
+
+// Segments は録画済みセグメントと録画中のセグメントを開始時刻順に返す
+func (r *Recorder) Segments() []Segment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	segments := make([]Segment, 0, len(r.index.Segments)+1)
+	segments = append(segments, r.index.Segments...)
+	if r.current != nil && r.current.segment.FrameCount > 0 {
+		segments = append(segments, r.current.segment)
+	}
+	return segments
+}
+
+// Dir は録画ファイルの出力ディレクトリを返す
+func (r *Recorder) Dir() string {
+	return r.dir
+}
+
+// run はソースを購読してフレームを書き込む
+func (r *Recorder) run(ctx context.Context) {
+	defer r.wg.Done()
+	defer r.closeSegment()
+
+	for {
+		subscription := r.source.Subscribe(recorderBufferSize, camera.DropOldest)
+		stopped := r.consume(ctx, subscription)
+		subscription.Unsubscribe()
+
+		// ソースが停止した場合も含め、書き込み中のセグメントを確定する
+		r.closeSegment()
+		if stopped {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopCh:
+			return
+		case <-time.After(resubscribeInterval):
+		}
+	}
+}
+
+// consume は購読が終了するまでフレームを書き込む
+// 録画の停止が要求された場合はtrueを返す
+func (r *Recorder) consume(ctx context.Context, subscription *camera.FrameSubscription) bool {
+	// フレームが途絶えてもセグメントを期限で閉じるためのティッカー
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-r.stopCh:
+			return true
+		case frame, ok := <-subscription.Frames():
+			if !ok {
+				return false // ソースが停止した
+			}
+			r.writeFrame(frame)
+		case now := <-ticker.C:
+			if r.segmentExpired(now) {
+				r.closeSegment()
+			}
+		}
+	}
+}
+
+// writeFrame はフレームを現在のセグメントに書き込む
+// セグメントの期限を過ぎている場合は新しいセグメントに切り替える
+// ffmpegの起動・書き込み・終了待ちは時間がかかる場合があるため、ロックを外して行う
+// （セグメントの書き込み先は run ゴルーチンのみが操作する）
+func (r *Recorder) writeFrame(frame camera.Frame) {
+	if r.segmentExpired(frame.Timestamp) {
+		r.closeSegment()
+	}
+
+	r.mu.RLock()
+	current := r.current
+	r.mu.RUnlock()
+
+	if current == nil {
+		if frame.Timestamp.Before(r.retryAt) {
+			return // 失敗直後はフレームを破棄し、ログが溢れないようにする
+		}
+		var err error
+		current, err = r.openSegment(frame.Timestamp)
+		if err != nil {
+			log.Printf("映像ソース %s のセグメント作成に失敗（%s後に再試行）: %v", r.sourceID, segmentRetryInterval, err)
+			r.retryAt = frame.Timestamp.Add(segmentRetryInterval)
+			return
+		}
+		r.mu.Lock()
+		r.current = current
+		r.mu.Unlock()
+	}
+
+	if err := current.writer.WriteFrame(frame.Data); err != nil {
+		// ffmpegが終了した場合等は以降の書き込みも失敗するため、
+		// 書き込めた分でセグメントを確定し、待機後に新しいセグメントを作成する
+		log.Printf("映像ソース %s のフレーム書き込みに失敗（%s後に新しいセグメントを作成）: %v", r.sourceID, segmentRetryInterval, err)
+		r.retryAt = frame.Timestamp.Add(segmentRetryInterval)
+		r.closeSegment()
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if current.segment.FrameCount == 0 {
+		current.segment.StartTime = frame.Timestamp
+	}
+	current.segment.EndTime = frame.Timestamp
+	current.segment.FrameCount++
+}
+
+// segmentExpired は現在のセグメントが期限を過ぎているか判定する
+func (r *Recorder) segmentExpired(now time.Time) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current != nil && !now.Before(r.current.deadline)
+}
+
+// openSegment は新しいセグメントファイルを作成する
+func (r *Recorder) openSegment(start time.Time) (*activeSegment, error) {
+	fileName := fmt.Sprintf("segment_%s.%s", start.Format("20060102_150405"), r.options.Format)
+	path := filepath.Join(r.dir, fileName)
+
+	writer, err := r.newWriter(path, r.options.Format, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// セグメント境界を時計の区切りに揃える
+	deadline := start.Truncate(r.options.SegmentDuration).Add(r.options.SegmentDuration)
+
+	return &activeSegment{
+		writer:   writer,
+		path:     path,
+		deadline: deadline,
+		segment: Segment{
+			SourceID:  r.sourceID,
+			FileName:  fileName,
+			StartTime: start,
+			EndTime:   start,
+		},
+	}, nil
+}
+
+// closeSegment は書き込み中のセグメントを確定してインデックスに追加する
+// 確定を待つ間も一覧に表示されるよう、先にインデックスに追加してからffmpegの終了を待つ
+func (r *Recorder) closeSegment() {
+	r.mu.Lock()
+	current := r.current
+	r.current = nil
+	if current != nil && current.segment.FrameCount > 0 {
+		r.index.Segments = append(r.index.Segments, current.segment)
+	}
+	r.mu.Unlock()
+
+	if current == nil {
+		return
+	}
+
+	if err := current.writer.Close(); err != nil {
+		log.Printf("映像ソース %s のセグメント確定に失敗: %v", r.sourceID, err)
+	}
+
+	if current.segment.FrameCount == 0 {
+		_ = os.Remove(current.path) // 空のセグメントは残さない
+		return
+	}
+
+	var fileSize int64
+	if info, err := os.Stat(current.path); err == nil {
+		fileSize = info.Size()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.index.Segments {
+		segment := &r.index.Segments[i]
+		if segment.FileName == current.segment.FileName && segment.StartTime.Equal(current.segment.StartTime) {
+			segment.FileSize = fileSize
+		}
+	}
+	if err := saveIndex(r.dir, r.index); err != nil {
+		log.Printf("映像ソース %s のインデックス保存に失敗: %v", r.sourceID, err)
+	}
+}
@@ -0,0 +1,304 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"senrigan/internal/camera"
+)
+
+// fakeSource はテスト用のフレーム供給元
+type fakeSource struct {
+	info        camera.VideoSourceInfo
+	broadcaster *camera.FrameBroadcaster
+}
+
+func newFakeSource(id string) *fakeSource {
+	return &fakeSource{
+		info:        camera.VideoSourceInfo{ID: id, Device: "/dev/video0"},
+		broadcaster: camera.NewFrameBroadcaster(),
+	}
+}
+
+func (s *fakeSource) GetInfo() camera.VideoSourceInfo {
+	return s.info
+}
+
+func (s *fakeSource) Subscribe(bufferSize int, policy camera.DropPolicy) *camera.FrameSubscription {
+	return s.broadcaster.Subscribe(bufferSize, policy)
+}
+
+// fileWriter はffmpegを使わずにフレームをそのままファイルへ書き込む
+type fileWriter struct {
+	file *os.File
+}
+
+func newFileWriter(path, _ string, _ int) (segmentWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &fileWriter{file: file}, nil
+}
+
+func (w *fileWriter) WriteFrame(frame []byte) error {
+	_, err := w.file.Write(frame)
+	return err
+}
+
+func (w *fileWriter) Close() error {
+	return w.file.Close()
+}
+
+// waitForSubscriber は録画側の購読が始まるまで待機する
+func waitForSubscriber(t *testing.T, source *fakeSource) {
+	t.Helper()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for source.broadcaster.SubscriberCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Recorder did not subscribe to the source")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRecorder_SegmentRotation(t *testing.T) {
+	ctx := context.Background()
+	outputDir := t.TempDir()
+	source := newFakeSource("camera_test")
+
+	recorder := NewRecorder(source, outputDir, SourceOptions{
+		Enabled:         true,
+		SegmentDuration: 200 * time.Millisecond,
+		Format:          FormatMKV,
+	})
+	recorder.newWriter = newFileWriter
+
+	if err := recorder.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	waitForSubscriber(t, source)
+
+	// 1つ目のセグメント
+	source.broadcaster.Publish([]byte("frame1"))
+	source.broadcaster.Publish([]byte("frame2"))
+	time.Sleep(300 * time.Millisecond)
+
+	// セグメント長を過ぎたので2つ目のセグメントになる
+	source.broadcaster.Publish([]byte("frame3"))
+	time.Sleep(50 * time.Millisecond)
+
+	if err := recorder.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	segments := recorder.Segments()
+	if len(segments) != 2 {
+		t.Fatalf("Expected 2 segments, got %d", len(segments))
+	}
+	if segments[0].FrameCount != 2 || segments[1].FrameCount != 1 {
+		t.Errorf("Unexpected frame counts: %d, %d", segments[0].FrameCount, segments[1].FrameCount)
+	}
+	if segments[0].FileSize != int64(len("frame1frame2")) {
+		t.Errorf("Unexpected file size: %d", segments[0].FileSize)
+	}
+	if !segments[0].EndTime.Before(segments[1].StartTime) {
+		t.Error("Expected segments to be ordered by time")
+	}
+
+	// インデックスがカメラ毎のディレクトリに保存される
+	index, err := loadIndex(filepath.Join(outputDir, "camera_test"))
+	if err != nil {
+		t.Fatalf("loadIndex failed: %v", err)
+	}
+	if len(index.Segments) != 2 {
+		t.Fatalf("Expected 2 segments in index, got %d", len(index.Segments))
+	}
+	for _, segment := range index.Segments {
+		if _, err := os.Stat(filepath.Join(outputDir, "camera_test", segment.FileName)); err != nil {
+			t.Errorf("Segment file not found: %v", err)
+		}
+	}
+}
+
+func TestRecorder_SourceRestart(t *testing.T) {
+	ctx := context.Background()
+	outputDir := t.TempDir()
+	source := newFakeSource("camera_test")
+
+	recorder := NewRecorder(source, outputDir, SourceOptions{
+		Enabled:         true,
+		SegmentDuration: time.Hour,
+		Format:          FormatMKV,
+	})
+	recorder.newWriter = newFileWriter
+
+	if err := recorder.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() { _ = recorder.Stop(ctx) }()
+	waitForSubscriber(t, source)
+
+	source.broadcaster.Publish([]byte("frame1"))
+	time.Sleep(50 * time.Millisecond)
+
+	// ソース停止でセグメントが確定される
+	source.broadcaster.Reset()
+	time.Sleep(50 * time.Millisecond)
+
+	if segments := recorder.Segments(); len(segments) != 1 {
+		t.Fatalf("Expected 1 segment after source stop, got %d", len(segments))
+	}
+
+	// ソース再開後は新しいセグメントに録画される
+	waitForSubscriber(t, source)
+	source.broadcaster.Publish([]byte("frame2"))
+	time.Sleep(50 * time.Millisecond)
+
+	segments := recorder.Segments()
+	if len(segments) != 2 {
+		t.Fatalf("Expected 2 segments after restart, got %d", len(segments))
+	}
+	if segments[1].FrameCount != 1 {
+		t.Errorf("Expected 1 frame in current segment, got %d", segments[1].FrameCount)
+	}
+}
+
+// brokenWriter は最初のフレームの後に書き込みが失敗する（終了したffmpegの代わり）
+type brokenWriter struct {
+	written int
+}
+
+func (w *brokenWriter) WriteFrame(_ []byte) error {
+	if w.written > 0 {
+		return errors.New("broken pipe")
+	}
+	w.written++
+	return nil
+}
+
+func (w *brokenWriter) Close() error {
+	return nil
+}
+
+func TestRecorder_WriteFailure(t *testing.T) {
+	ctx := context.Background()
+	outputDir := t.TempDir()
+	source := newFakeSource("camera_test")
+
+	recorder := NewRecorder(source, outputDir, SourceOptions{
+		Enabled:         true,
+		SegmentDuration: time.Hour,
+		Format:          FormatMKV,
+	})
+	opened := 0
+	recorder.newWriter = func(path, _ string, _ int) (segmentWriter, error) {
+		opened++
+		if err := os.WriteFile(path, []byte("frame"), 0644); err != nil {
+			return nil, err
+		}
+		return &brokenWriter{}, nil
+	}
+
+	if err := recorder.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	waitForSubscriber(t, source)
+
+	for i := 0; i < 5; i++ {
+		source.broadcaster.Publish([]byte("frame"))
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if err := recorder.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	// 書き込めた分でセグメントを確定し、待機中は新しいセグメントを作成しない
+	if opened != 1 {
+		t.Errorf("Expected 1 segment writer, got %d", opened)
+	}
+	segments := recorder.Segments()
+	if len(segments) != 1 || segments[0].FrameCount != 1 {
+		t.Errorf("Expected 1 segment with 1 frame, got %+v", segments)
+	}
+}
+
+// blockingWriter は解放されるまで書き込みが終わらない（応答しないffmpegの代わり）
+type blockingWriter struct {
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) WriteFrame(_ []byte) error {
+	w.writing <- struct{}{}
+	<-w.release
+	return nil
+}
+
+func (w *blockingWriter) Close() error {
+	return nil
+}
+
+func TestRecorder_SlowWriterDoesNotBlockSegments(t *testing.T) {
+	ctx := context.Background()
+	source := newFakeSource("camera_test")
+
+	recorder := NewRecorder(source, t.TempDir(), SourceOptions{
+		Enabled:         true,
+		SegmentDuration: time.Hour,
+		Format:          FormatMKV,
+	})
+	writer := &blockingWriter{writing: make(chan struct{}), release: make(chan struct{})}
+	recorder.newWriter = func(_, _ string, _ int) (segmentWriter, error) {
+		return writer, nil
+	}
+
+	if err := recorder.Start(ctx); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	waitForSubscriber(t, source)
+	source.broadcaster.Publish([]byte("frame"))
+	<-writer.writing
+
+	// 書き込み中でもセグメントの一覧は取得できる
+	done := make(chan struct{})
+	go func() {
+		recorder.Segments()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Segments blocked while the writer was busy")
+	}
+
+	close(writer.release)
+	if err := recorder.Stop(ctx); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+}
+
+func TestSourceOptions_Resolve(t *testing.T) {
+	config := Config{SegmentDuration: 10 * time.Minute, Format: FormatMKV}
+
+	resolved := SourceOptions{Enabled: true}.resolve(config)
+	if resolved.SegmentDuration != 10*time.Minute || resolved.Format != FormatMKV {
+		t.Errorf("Expected global settings to be applied, got %+v", resolved)
+	}
+
+	resolved = SourceOptions{Enabled: true, SegmentDuration: time.Minute, Format: FormatMP4}.resolve(config)
+	if resolved.SegmentDuration != time.Minute || resolved.Format != FormatMP4 {
+		t.Errorf("Expected source settings to take precedence, got %+v", resolved)
+	}
+
+	resolved = SourceOptions{}.resolve(Config{})
+	if resolved.SegmentDuration != 5*time.Minute || resolved.Format != FormatMKV {
+		t.Errorf("Expected default settings, got %+v", resolved)
+	}
+}
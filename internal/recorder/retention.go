@@ -0,0 +1,158 @@
+package recorder
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// segmentKey はセグメントを識別するキー（同じ秒に開始したセグメントはファイル名が同じになるため開始時刻も含める）
+type segmentKey struct {
+	sourceID  string
+	fileName  string
+	startTime int64
+}
+
+// keyOf はセグメントのキーを返す
+func keyOf(segment Segment) segmentKey {
+	return segmentKey{sourceID: segment.SourceID, fileName: segment.FileName, startTime: segment.StartTime.UnixNano()}
+}
+
+// expiredSegments は保持期間を過ぎたセグメントと、合計サイズが上限を超える分の古いセグメントを返す
+// サイズが0のセグメント（確定中のセグメント等）は上限による削除の対象にしない
+func expiredSegments(segments []Segment, now time.Time, retentionDays int, maxSize int64) map[segmentKey]bool {
+	expired := make(map[segmentKey]bool)
+
+	sorted := slices.Clone(segments)
+	slices.SortFunc(sorted, func(a, b Segment) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	var total int64
+	for _, segment := range sorted {
+		if retentionDays > 0 && segment.EndTime.Before(now.AddDate(0, 0, -retentionDays)) {
+			expired[keyOf(segment)] = true
+			continue
+		}
+		total += segment.FileSize
+	}
+
+	if maxSize <= 0 {
+		return expired
+	}
+	for _, segment := range sorted {
+		if total <= maxSize {
+			break
+		}
+		if expired[keyOf(segment)] || segment.FileSize == 0 {
+			continue
+		}
+		expired[keyOf(segment)] = true
+		total -= segment.FileSize
+	}
+	return expired
+}
+
+// pruneSegments は保持期間・合計サイズの上限を超えたセグメントを削除する
+// 録画を停止した映像ソースのセグメントも出力ディレクトリのインデックスから削除する
+func (m *DefaultManager) pruneSegments(now time.Time) {
+	if m.config.RetentionDays <= 0 && m.config.MaxSize <= 0 {
+		return
+	}
+
+	// 削除中に録画対象が変わらないようにする
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries, err := os.ReadDir(m.config.OutputDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("録画ディレクトリの読み取りに失敗: %v", err)
+		}
+		return
+	}
+
+	running := make(map[string]*Recorder, len(m.recorders))
+	for _, recorder := range m.recorders {
+		running[recorder.Dir()] = recorder
+	}
+
+	// ディレクトリ毎の確定済みのセグメント
+	segmentsOf := make(map[string][]Segment)
+	var all []Segment
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(m.config.OutputDir, entry.Name())
+
+		var segments []Segment
+		if recorder, exists := running[dir]; exists {
+			segments = recorder.closedSegments()
+		} else {
+			index, err := loadIndex(dir)
+			if err != nil {
+				log.Printf("録画インデックス %s の読み込みに失敗: %v", dir, err)
+				continue
+			}
+			segments = index.Segments
+		}
+		segmentsOf[dir] = segments
+		all = append(all, segments...)
+	}
+
+	expired := expiredSegments(all, now, m.config.RetentionDays, m.config.MaxSizeBytes())
+	if len(expired) == 0 {
+		return
+	}
+
+	for dir, segments := range segmentsOf {
+		removed := slices.DeleteFunc(slices.Clone(segments), func(segment Segment) bool {
+			return !expired[keyOf(segment)]
+		})
+		if len(removed) == 0 {
+			continue
+		}
+
+		if recorder, exists := running[dir]; exists {
+			recorder.removeSegments(expired)
+		} else {
+			kept := slices.DeleteFunc(segments, func(segment Segment) bool {
+				return expired[keyOf(segment)]
+			})
+			if err := saveIndex(dir, segmentIndex{Segments: kept}); err != nil {
+				log.Printf("録画インデックス %s の保存に失敗: %v", dir, err)
+				continue
+			}
+		}
+
+		for _, segment := range removed {
+			if err := os.Remove(filepath.Join(dir, segment.FileName)); err != nil && !os.IsNotExist(err) {
+				log.Printf("セグメント %s の削除に失敗: %v", segment.FileName, err)
+			}
+		}
+		log.Printf("保持期間・合計サイズの上限を超えた %s のセグメントを %d 個削除しました", filepath.Base(dir), len(removed))
+	}
+}
+
+// closedSegments は確定済み（インデックスに追加済み）のセグメントを返す
+func (r *Recorder) closedSegments() []Segment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.index.Segments)
+}
+
+// removeSegments は指定したセグメントをインデックスから削除して保存する（ファイルは削除しない）
+func (r *Recorder) removeSegments(expired map[segmentKey]bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.index.Segments = slices.DeleteFunc(r.index.Segments, func(segment Segment) bool {
+		return expired[keyOf(segment)]
+	})
+	if err := saveIndex(r.dir, r.index); err != nil {
+		log.Printf("映像ソース %s のインデックス保存に失敗: %v", r.sourceID, err)
+	}
+}
@@ -0,0 +1,83 @@
+package recorder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExpiredSegments(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.Local)
+	segment := func(sourceID string, age time.Duration, size int64) Segment {
+		start := now.Add(-age)
+		return Segment{SourceID: sourceID, FileName: start.Format("150405"), StartTime: start, EndTime: start.Add(time.Minute), FileSize: size}
+	}
+	old := segment("cam1", 48*time.Hour, 100)
+	a := segment("cam1", 3*time.Hour, 100)
+	b := segment("cam2", 2*time.Hour, 100)
+	c := segment("cam1", time.Hour, 100)
+	closing := segment("cam2", 4*time.Hour, 0)
+
+	// 保持期間を過ぎたセグメントと、上限を超える分の古いセグメント（映像ソースをまたいで古い順）を削除する
+	expired := expiredSegments([]Segment{c, b, old, a, closing}, now, 1, 200)
+	for _, tc := range []struct {
+		name    string
+		segment Segment
+		want    bool
+	}{
+		{"old", old, true},
+		{"a", a, true},
+		{"b", b, false},
+		{"c", c, false},
+		{"closing", closing, false},
+	} {
+		if expired[keyOf(tc.segment)] != tc.want {
+			t.Errorf("%s: expired = %v, want %v", tc.name, !tc.want, tc.want)
+		}
+	}
+
+	if expired := expiredSegments([]Segment{old, a}, now, 0, 0); len(expired) != 0 {
+		t.Errorf("Expected nothing to expire without limits, got %v", expired)
+	}
+}
+
+func TestDefaultManager_PruneSegments(t *testing.T) {
+	outputDir := t.TempDir()
+	now := time.Now()
+
+	// 録画を停止した映像ソースのセグメントもインデックスとファイルを削除する
+	dir := filepath.Join(outputDir, "camera_old")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	index := segmentIndex{}
+	for i, age := range []time.Duration{40 * 24 * time.Hour, time.Hour} {
+		start := now.Add(-age)
+		fileName := []string{"segment_old.mkv", "segment_new.mkv"}[i]
+		if err := os.WriteFile(filepath.Join(dir, fileName), []byte("frame"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		index.Segments = append(index.Segments, Segment{SourceID: "camera_old", FileName: fileName, StartTime: start, EndTime: start, FileSize: 5})
+	}
+	if err := saveIndex(dir, index); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewDefaultManager(nil, Config{OutputDir: outputDir, RetentionDays: 30}, nil)
+	manager.pruneSegments(now)
+
+	loaded, err := loadIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Segments) != 1 || loaded.Segments[0].FileName != "segment_new.mkv" {
+		t.Errorf("Expected only the new segment in index, got %+v", loaded.Segments)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "segment_old.mkv")); !os.IsNotExist(err) {
+		t.Errorf("Expected old segment file to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "segment_new.mkv")); err != nil {
+		t.Errorf("Expected new segment file to be kept: %v", err)
+	}
+}
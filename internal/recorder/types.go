@@ -0,0 +1,181 @@
+package recorder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"senrigan/internal/numeric"
+)
+
+// 出力フォーマット
+const (
+	FormatMP4 = "mp4"
+	FormatMKV = "mkv"
+)
+
+// Config は連続録画の全体設定
+type Config struct {
+	OutputDir       string        `yaml:"output_dir"`       // 出力ディレクトリ
+	SegmentDuration time.Duration `yaml:"segment_duration"` // セグメント長（デフォルト: 5分）
+	Format          string        `yaml:"format"`           // 出力フォーマット ("mkv" / "mp4"、デフォルト: mkv)
+	RetentionDays   int           `yaml:"retention_days"`   // セグメントの保持期間（日数、0は無期限）
+	MaxSize         int           `yaml:"max_size_mb"`      // 全映像ソースのセグメントの合計サイズの上限（MB、0は無制限、超えると古いセグメントから削除）
+	Clips           ClipConfig    `yaml:"clips"`            // イベント録画の設定
+}
+
+// MaxSizeBytes はセグメントの合計サイズの上限をバイト数で返す（0は無制限）
+func (c Config) MaxSizeBytes() int64 {
+	return int64(c.MaxSize) * 1024 * 1024
+}
+
+// ClipConfig はイベント録画（クリップ）の全体設定
+type ClipConfig struct {
+	OutputDir   string        `yaml:"output_dir"`   // 出力ディレクトリ
+	PreRoll     time.Duration `yaml:"pre_roll"`     // トリガー前に遡って記録する時間
+	PostRoll    time.Duration `yaml:"post_roll"`    // 最後のトリガー後に記録を続ける時間
+	MaxDuration time.Duration `yaml:"max_duration"` // 1クリップの最大長
+}
+
+// SourceOptions は映像ソース毎の録画設定
+// ゼロ値の項目は全体設定の値を使用する
+type SourceOptions struct {
+	Enabled         bool          `yaml:"enabled"`          // 録画の有効/無効
+	SegmentDuration time.Duration `yaml:"segment_duration"` // セグメント長
+	Format          string        `yaml:"format"`           // 出力フォーマット
+	Clips           ClipOptions   `yaml:"clips"`            // イベント録画の設定
+}
+
+// ClipOptions は映像ソース毎のイベント録画設定
+// ゼロ値の項目は全体設定の値を使用する
+type ClipOptions struct {
+	Enabled     bool             `yaml:"enabled"`      // イベント録画の有効/無効
+	OnMotion    bool             `yaml:"on_motion"`    // 動体検知をトリガーにする
+	Schedule    []ScheduleWindow `yaml:"schedule"`     // 毎日決まった時間帯をトリガーにする
+	PreRoll     time.Duration    `yaml:"pre_roll"`     // トリガー前に遡って記録する時間
+	PostRoll    time.Duration    `yaml:"post_roll"`    // 最後のトリガー後に記録を続ける時間
+	MaxDuration time.Duration    `yaml:"max_duration"` // 1クリップの最大長
+}
+
+// ScheduleWindow は毎日の録画時間帯（"15:04" 形式）
+// End が Start より前の場合は日付をまたぐ時間帯として扱う
+type ScheduleWindow struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// Segment は録画済みセグメントの情報
+type Segment struct {
+	SourceID   string    `json:"source_id"`   // 映像ソースID
+	FileName   string    `json:"file_name"`   // ソースディレクトリ内のファイル名
+	StartTime  time.Time `json:"start_time"`  // 最初のフレームの時刻
+	EndTime    time.Time `json:"end_time"`    // 最後のフレームの時刻
+	FrameCount int       `json:"frame_count"` // フレーム数
+	FileSize   int64     `json:"file_size"`   // ファイルサイズ
+}
+
+// Clip はイベント録画で記録したクリップの情報
+type Clip struct {
+	SourceID   string    `json:"source_id"`   // 映像ソースID
+	FileName   string    `json:"file_name"`   // ソースディレクトリ内のファイル名
+	StartTime  time.Time `json:"start_time"`  // 最初のフレーム（プリロールを含む）の時刻
+	EndTime    time.Time `json:"end_time"`    // 最後のフレームの時刻
+	FrameCount int       `json:"frame_count"` // 記録したフレーム数
+	FileSize   int64     `json:"file_size"`   // ファイルサイズ
+	Triggers   []string  `json:"triggers"`    // クリップを開始・延長したトリガーの種類
+}
+
+// TriggerResult はトリガーによって開始・延長されたクリップの情報
+type TriggerResult struct {
+	Clip     Clip      // 録画中のクリップ
+	Until    time.Time // 録画を続ける予定の時刻
+	Extended bool      // 既存のクリップを延長した場合はtrue
+}
+
+// トリガーの種類
+const (
+	TriggerManual   = "manual"   // APIからの手動トリガー
+	TriggerMotion   = "motion"   // 動体検知
+	TriggerSchedule = "schedule" // 時間帯指定
+)
+
+// ErrTriggerDisabled は映像ソースでそのトリガーによる録画が有効でない場合のエラー
+var ErrTriggerDisabled = errors.New("イベント録画が有効ではありません")
+
+// DefaultConfig はデフォルトの録画設定を返す
+func DefaultConfig() Config {
+	return Config{
+		OutputDir:       "./data/recordings",
+		SegmentDuration: 5 * time.Minute,
+		Format:          FormatMKV, // 常時録画のため再エンコードしない
+		RetentionDays:   30,
+		MaxSize:         100 * 1024, // 100GB
+		Clips: ClipConfig{
+			OutputDir:   "./data/clips",
+			PreRoll:     10 * time.Second,
+			PostRoll:    10 * time.Second,
+			MaxDuration: 10 * time.Minute,
+		},
+	}
+}
+
+// resolve はソース毎の設定に全体設定を補完した設定を返す
+func (o SourceOptions) resolve(config Config) SourceOptions {
+	resolved := o
+	if resolved.SegmentDuration <= 0 {
+		resolved.SegmentDuration = config.SegmentDuration
+	}
+	if resolved.SegmentDuration <= 0 {
+		resolved.SegmentDuration = DefaultConfig().SegmentDuration
+	}
+	if resolved.Format == "" {
+		resolved.Format = config.Format
+	}
+	if resolved.Format == "" {
+		resolved.Format = DefaultConfig().Format
+	}
+	return resolved
+}
+
+// resolve はソース毎のイベント録画設定に全体設定を補完した設定を返す
+func (o ClipOptions) resolve(config ClipConfig) ClipOptions {
+	defaults := DefaultConfig().Clips
+	resolved := o
+	resolved.PreRoll = numeric.FirstPositive(o.PreRoll, config.PreRoll, defaults.PreRoll)
+	resolved.PostRoll = numeric.FirstPositive(o.PostRoll, config.PostRoll, defaults.PostRoll)
+	resolved.MaxDuration = numeric.FirstPositive(o.MaxDuration, config.MaxDuration, defaults.MaxDuration)
+	return resolved
+}
+
+// Validate は時間帯の書式を検証する
+func (w ScheduleWindow) Validate() error {
+	if _, err := time.Parse("15:04", w.Start); err != nil {
+		return fmt.Errorf("無効な開始時刻: %s", w.Start)
+	}
+	if _, err := time.Parse("15:04", w.End); err != nil {
+		return fmt.Errorf("無効な終了時刻: %s", w.End)
+	}
+	return nil
+}
+
+// Contains は時刻が時間帯に含まれるか判定する
+func (w ScheduleWindow) Contains(t time.Time) bool {
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", w.End)
+	if err != nil {
+		return false
+	}
+
+	minutes := t.Hour()*60 + t.Minute()
+	startMinutes := start.Hour()*60 + start.Minute()
+	endMinutes := end.Hour()*60 + end.Minute()
+
+	if startMinutes <= endMinutes {
+		return minutes >= startMinutes && minutes < endMinutes
+	}
+	// 日付をまたぐ時間帯
+	return minutes >= startMinutes || minutes < endMinutes
+}
@@ -0,0 +1,148 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// segmentWriter は1つのセグメントファイルへフレームを書き込む
+type segmentWriter interface {
+	// WriteFrame はJPEGフレームを1枚書き込む
+	WriteFrame(frame []byte) error
+	// Close は書き込みを終了してファイルを確定する
+	Close() error
+}
+
+// writerFactory はセグメントファイルの書き込み先を作成する関数の型
+// frameRate が0の場合は受信時刻をタイムスタンプとし、正の場合は固定フレームレートとして扱う
+type writerFactory func(path, format string, frameRate int) (segmentWriter, error)
+
+// ffmpegSegmentWriter はffmpegの標準入力にMJPEGを流してファイルに書き出す
+type ffmpegSegmentWriter struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr bytes.Buffer // Wait の完了後のみ読み取る
+}
+
+// newFFmpegSegmentWriter はffmpegプロセスを起動してセグメントの書き込みを開始する
+func newFFmpegSegmentWriter(path, format string, frameRate int) (segmentWriter, error) {
+	args := []string{
+		"-hide_banner",
+		"-loglevel", "error",
+		"-f", "mjpeg",
+	}
+	if frameRate > 0 {
+		args = append(args, "-framerate", strconv.Itoa(frameRate))
+	} else {
+		args = append(args, "-use_wallclock_as_timestamps", "1") // 受信時刻をタイムスタンプとして使用（可変フレームレート）
+	}
+	args = append(args, "-i", "pipe:0")
+
+	switch format {
+	case FormatMKV:
+		// MJPEGをそのまま格納（再エンコードなし）
+		args = append(args, "-c:v", "copy")
+	case FormatMP4:
+		// 異常終了・停電で書き込み途中になっても再生できるよう、moov を先頭に置いたフラグメント化MP4で書き出す
+		args = append(args,
+			"-c:v", "libx264",
+			"-preset", "veryfast",
+			"-pix_fmt", "yuv420p",
+			"-movflags", "frag_keyframe+empty_moov+default_base_moof",
+		)
+	default:
+		return nil, fmt.Errorf("サポートされていない録画フォーマット: %s", format)
+	}
+	args = append(args, "-y", path)
+
+	w := &ffmpegSegmentWriter{}
+	w.cmd = exec.Command("ffmpeg", args...)
+	w.cmd.Stderr = &w.stderr
+
+	stdin, err := w.cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("stdinパイプの作成に失敗: %w", err)
+	}
+	w.stdin = stdin
+
+	if err := w.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("ffmpegの起動に失敗: %w", err)
+	}
+
+	return w, nil
+}
+
+// WriteFrame はJPEGフレームをffmpegに渡す
+// 標準エラー出力はffmpegの実行中に書き込まれるため、終了を待つ Close のエラーに含める
+func (w *ffmpegSegmentWriter) WriteFrame(frame []byte) error {
+	if _, err := w.stdin.Write(frame); err != nil {
+		return fmt.Errorf("ffmpegへの書き込みに失敗: %w", err)
+	}
+	return nil
+}
+
+// Close は標準入力を閉じてffmpegの終了を待つ
+// 標準エラー出力は Wait でコピーが完了した後に読み取る
+func (w *ffmpegSegmentWriter) Close() error {
+	_ = w.stdin.Close() // Wait でエラーを確認するため無視
+	if err := w.cmd.Wait(); err != nil {
+		return fmt.Errorf("ffmpegの終了に失敗: %w (stderr: %s)", err, w.stderr.String())
+	}
+	return nil
+}
+
+// pacedWriter はフレームのキャプチャ時刻に合わせて固定フレームレートで書き込む
+// プリロールのようにまとめて書き込むフレームも元の時間間隔で再生されるよう、
+// フレームの間隔が空いた場合は直前のフレームを繰り返し、詰まっている場合は間引く
+type pacedWriter struct {
+	writer    segmentWriter
+	frameRate int
+	start     time.Time
+	written   int64  // 書き込んだフレーム数（出力上の位置）
+	last      []byte // 直前に書き込んだフレーム
+}
+
+// newPacedWriter は start を先頭とするpacedWriterを作成する
+func newPacedWriter(writer segmentWriter, frameRate int, start time.Time) *pacedWriter {
+	return &pacedWriter{
+		writer:    writer,
+		frameRate: frameRate,
+		start:     start,
+	}
+}
+
+// WriteFrame はキャプチャ時刻に対応する位置までフレームを書き込む
+// フレームを書き込んだ場合はtrueを返す
+func (w *pacedWriter) WriteFrame(frame []byte, timestamp time.Time) (bool, error) {
+	position := int64(timestamp.Sub(w.start).Seconds() * float64(w.frameRate))
+	if position < w.written {
+		return false, nil // 出力フレームレートより速く届いたフレームは間引く
+	}
+
+	fill := w.last
+	if fill == nil {
+		fill = frame
+	}
+	for w.written < position {
+		if err := w.writer.WriteFrame(fill); err != nil {
+			return false, err
+		}
+		w.written++
+	}
+
+	if err := w.writer.WriteFrame(frame); err != nil {
+		return false, err
+	}
+	w.written++
+	w.last = frame
+	return true, nil
+}
+
+// Close は書き込みを終了してファイルを確定する
+func (w *pacedWriter) Close() error {
+	return w.writer.Close()
+}
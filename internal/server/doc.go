@@ -12,7 +12,18 @@
 //
 // 仕様:
 //   - 標準ライブラリのnet/httpを使用
-//   - WebSocketはgorilla/websocketを使用（将来的に）
+//   - WebSocketはgorilla/websocketを使用
+//   - WebSocketではフレーム毎にメタデータ（JSON）とJPEG（バイナリ）を続けて送信
+//   - WebSocketクライアントは一時停止・フレームレート・解像度を制御メッセージで変更可能
+//   - /metrics でPrometheus形式のメトリクスを公開
+//   - 認証が有効な場合、/health とログイン・ログアウト以外のAPIと /metrics は認証が必要
+//   - カメラの開始・停止・設定変更・録画トリガーとタイムラプスのレイアウト変更は operator、追加・削除は admin 以上の権限が必要
+//   - カメラが制限された利用者には閲覧できないカメラを存在しないものとして扱い、一覧・イベント・モザイク画像から除く
+//   - 全てのカメラを結合したタイムラプス動画・フレームとメトリクスは、カメラが制限されていない利用者のみ利用可能
+//   - 映像ソース毎のタイムラプス動画・フレームは、そのカメラを閲覧できる利用者が利用可能
+//   - CORS・WebSocketは設定で許可したオリジン（未指定で認証が有効な場合は同一オリジン）のみ受け付ける
+//   - 設定によりHTTPSで配信（証明書ファイルまたは自己署名証明書）し、HTTPからHTTPSへのリダイレクトも可能
 //   - グレースフルシャットダウンに対応
+//   - カメラ情報には監視による再起動回数・最後のエラーを含める
 //   - 複数クライアントの同時接続をサポート
 package server
@@ -1,12 +1,27 @@
 package server
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"senrigan/internal/auth"
 	"senrigan/internal/camera"
 	"senrigan/internal/config"
 	"senrigan/internal/generated"
+	"senrigan/internal/imaging"
+	"senrigan/internal/metrics"
+	"senrigan/internal/motion"
+	"senrigan/internal/recorder"
 	"senrigan/internal/timelapse"
 
 	"github.com/gin-gonic/gin"
@@ -17,8 +32,35 @@ type SenriganHandler struct {
 	config           *config.Config
 	cameraManager    camera.Manager
 	timelapseManager timelapse.Manager
+	motionManager    motion.Manager
+	recorderManager  recorder.Manager
+	metrics          *metrics.Registry
+	authManager      auth.Manager
+	origins          *originPolicy
 }
 
+// イベント一覧の取得件数
+const (
+	defaultEventLimit = 100
+	maxEventLimit     = 1000
+)
+
+// スナップショットのパラメータ上限
+const (
+	maxSnapshotWidth  = 7680
+	maxSnapshotHeight = 4320
+)
+
+// タイムラプスのフレーム一覧
+const (
+	defaultFrameLimit   = 1000
+	maxFrameLimit       = 10000
+	frameThumbnailWidth = 320 // サムネイルURLの最大幅
+
+	// frameTimestampLayout はフレームのURL・ヘッダーに使う撮影時刻の形式（保存時と同じミリ秒単位）
+	frameTimestampLayout = "2006-01-02T15:04:05.000Z07:00"
+)
+
 // HealthCheck はヘルスチェックエンドポイントの実装
 func (h *SenriganHandler) HealthCheck(c *gin.Context) {
 	response := generated.HealthResponse{
@@ -36,42 +78,81 @@ func (h *SenriganHandler) GetStatus(c *gin.Context) {
 			Host: h.config.Server.Host,
 			Port: h.config.Server.Port,
 		},
-		Cameras: len(h.cameraManager.GetVideoSources()),
+		Cameras: len(visibleSources(c, h.cameraManager.GetVideoSources())),
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// Login はログインエンドポイントの実装
+func (h *SenriganHandler) Login(c *gin.Context) {
+	var request generated.LoginJSONRequestBody
+	if err := c.ShouldBindJSON(&request); err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストボディが不正です",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	// 認証が無効の場合はセッションを作成せずに Anonymous を返す
+	if !h.authManager.Enabled() {
+		c.JSON(http.StatusOK, convertPrincipal(auth.Anonymous))
+		return
+	}
+
+	sessionID, principal, err := h.authManager.Login(request.Username, request.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, generated.ErrorResponse{
+				Error:   "invalid_credentials",
+				Message: "ユーザー名またはパスワードが正しくありません",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "login_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	http.SetCookie(c.Writer, h.authManager.SessionCookie(sessionID, principal))
+	c.JSON(http.StatusOK, convertPrincipal(principal))
+}
+
+// Logout はログアウトエンドポイントの実装
+func (h *SenriganHandler) Logout(c *gin.Context) {
+	if cookie, err := c.Request.Cookie(auth.SessionCookieName); err == nil {
+		h.authManager.Logout(cookie.Value)
+	}
+	http.SetCookie(c.Writer, h.authManager.ExpiredSessionCookie())
+	c.Status(http.StatusNoContent)
+}
+
+// GetCurrentUser はログイン中の利用者取得エンドポイントの実装
+func (h *SenriganHandler) GetCurrentUser(c *gin.Context) {
+	principal, ok := principalFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, generated.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "ログインが必要です",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, convertPrincipal(principal))
+}
+
 // GetCameras はカメラ一覧取得エンドポイントの実装
 func (h *SenriganHandler) GetCameras(c *gin.Context) {
 	// VideoSourceマネージャーから現在のVideoSource一覧を取得
-	videoSources := h.cameraManager.GetVideoSources()
+	videoSources := visibleSources(c, h.cameraManager.GetVideoSources())
 	cameras := make([]generated.CameraInfo, 0, len(videoSources))
 
 	for _, source := range videoSources {
-		info := source.GetInfo()
-		settings := source.GetCurrentSettings()
-
-		// カメラ設定を生成されたスキーマに変換
-		cameraSettings := generated.CameraSettings{
-			Fps:    settings.FrameRate,
-			Width:  settings.Width,
-			Height: settings.Height,
-		}
-
-		// カメラ情報を作成
-		cameraInfo := generated.CameraInfo{
-			Id:       info.ID,
-			Name:     info.Name,
-			Device:   info.Device,
-			Settings: cameraSettings,
-		}
-
-		// カメラの状態を変換
-		status := convertCameraStatus(source.GetStatus())
-		cameraInfo.Status = &status
-
-		cameras = append(cameras, cameraInfo)
+		cameras = append(cameras, h.cameraInfo(source))
 	}
 
 	// カメラを名前順でソート
@@ -86,6 +167,232 @@ func (h *SenriganHandler) GetCameras(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// CreateCamera はカメラ追加エンドポイントの実装
+func (h *SenriganHandler) CreateCamera(c *gin.Context) {
+	var request generated.CreateCameraJSONRequestBody
+	if err := c.ShouldBindJSON(&request); err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストボディが不正です",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	sourceType := camera.VideoSourceType(request.Type)
+	if !slices.Contains(h.cameraManager.GetSupportedSourceTypes(), sourceType) {
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "unsupported_source_type",
+			Message: fmt.Sprintf("サポートされていないソースタイプです: %s", request.Type),
+		})
+		return
+	}
+
+	config := camera.SourceConfig{}
+	if request.Id != nil {
+		config.ID = *request.Id
+	}
+	if request.Name != nil {
+		config.Name = *request.Name
+	}
+	if request.Device != nil {
+		config.Device = *request.Device
+	}
+	if request.Settings != nil {
+		config.Settings = convertSettingsUpdate(*request.Settings)
+	}
+
+	source, err := h.cameraManager.AddVideoSource(c.Request.Context(), sourceType, config)
+	if err != nil {
+		cameraErrorResponse(c, err, "カメラの追加に失敗しました")
+		return
+	}
+
+	if request.Start == nil || *request.Start {
+		// 開始に失敗してもカメラは追加済みのため、状態は error として返す
+		if err := h.cameraManager.StartVideoSource(source.GetInfo().ID); err != nil {
+			log.Printf("カメラ %s の開始に失敗: %v", source.GetInfo().ID, err)
+		}
+	}
+
+	c.JSON(http.StatusCreated, h.cameraInfo(source))
+}
+
+// GetCamera はカメラ詳細取得エンドポイントの実装
+func (h *SenriganHandler) GetCamera(c *gin.Context, cameraID string) {
+	source, exists := h.cameraManager.GetVideoSource(cameraID)
+	if !exists {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "camera_not_found",
+			Message: "指定されたカメラが見つかりません",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.cameraInfo(source))
+}
+
+// UpdateCameraSettings はカメラ設定変更エンドポイントの実装
+func (h *SenriganHandler) UpdateCameraSettings(c *gin.Context, cameraID string) {
+	var request generated.UpdateCameraSettingsJSONRequestBody
+	if err := c.ShouldBindJSON(&request); err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストボディが不正です",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	if _, err := h.cameraManager.UpdateVideoSourceSettings(cameraID, convertSettingsUpdate(request)); err != nil {
+		cameraErrorResponse(c, err, "カメラ設定の変更に失敗しました")
+		return
+	}
+
+	h.GetCamera(c, cameraID)
+}
+
+// DeleteCamera はカメラ削除エンドポイントの実装
+func (h *SenriganHandler) DeleteCamera(c *gin.Context, cameraID string) {
+	if err := h.cameraManager.RemoveVideoSource(c.Request.Context(), cameraID); err != nil {
+		cameraErrorResponse(c, err, "カメラの削除に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// StartCamera はカメラ開始エンドポイントの実装
+func (h *SenriganHandler) StartCamera(c *gin.Context, cameraID string) {
+	if err := h.cameraManager.StartVideoSource(cameraID); err != nil {
+		cameraErrorResponse(c, err, "カメラの開始に失敗しました")
+		return
+	}
+
+	h.GetCamera(c, cameraID)
+}
+
+// StopCamera はカメラ停止エンドポイントの実装
+func (h *SenriganHandler) StopCamera(c *gin.Context, cameraID string) {
+	if err := h.cameraManager.StopVideoSource(c.Request.Context(), cameraID); err != nil {
+		cameraErrorResponse(c, err, "カメラの停止に失敗しました")
+		return
+	}
+
+	h.GetCamera(c, cameraID)
+}
+
+// GetCameraStats はカメラ統計取得エンドポイントの実装
+func (h *SenriganHandler) GetCameraStats(c *gin.Context, cameraID string) {
+	source, exists := h.cameraManager.GetVideoSource(cameraID)
+	if !exists {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "camera_not_found",
+			Message: "指定されたカメラが見つかりません",
+		})
+		return
+	}
+
+	health, _ := h.cameraManager.GetSourceHealth(cameraID)
+	c.JSON(http.StatusOK, convertCameraStats(cameraID, source.GetStats(), health, time.Now()))
+}
+
+// GetCameraSnapshot は最新フレームをJPEG画像で返すエンドポイントの実装
+func (h *SenriganHandler) GetCameraSnapshot(c *gin.Context, cameraID string, params generated.GetCameraSnapshotParams) {
+	source, exists := h.cameraManager.GetVideoSource(cameraID)
+	if !exists {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "camera_not_found",
+			Message: "指定されたカメラが見つかりません",
+		})
+		return
+	}
+
+	var width, height, quality int
+	if params.Width != nil {
+		if *params.Width < 1 || *params.Width > maxSnapshotWidth {
+			c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Error:   "invalid_parameter",
+				Message: "width は1から7680の範囲で指定してください",
+			})
+			return
+		}
+		width = *params.Width
+	}
+	if params.Height != nil {
+		if *params.Height < 1 || *params.Height > maxSnapshotHeight {
+			c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Error:   "invalid_parameter",
+				Message: "height は1から4320の範囲で指定してください",
+			})
+			return
+		}
+		height = *params.Height
+	}
+	if params.Quality != nil {
+		if *params.Quality < 1 || *params.Quality > 100 {
+			c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Error:   "invalid_parameter",
+				Message: "quality は1から100の範囲で指定してください",
+			})
+			return
+		}
+		quality = *params.Quality
+	}
+
+	if source.GetStatus() != camera.StatusActive {
+		c.JSON(http.StatusServiceUnavailable, generated.ErrorResponse{
+			Error:   "camera_not_active",
+			Message: "カメラがアクティブではありません",
+		})
+		return
+	}
+
+	// ストリーミング中の最新フレームを優先し、まだ無い場合は1フレームをキャプチャする
+	frame, ok := source.LatestFrame()
+	if !ok {
+		data, err := source.CaptureFrameForTimelapse(c.Request.Context())
+		if err != nil {
+			errMsg := err.Error()
+			c.JSON(http.StatusServiceUnavailable, generated.ErrorResponse{
+				Error:   "frame_unavailable",
+				Message: "フレームを取得できませんでした",
+				Details: &errMsg,
+			})
+			return
+		}
+		frame = camera.Frame{Data: data, Timestamp: time.Now()}
+	}
+
+	// 同じフレームでも加工パラメータが異なれば別の表現として扱う
+	etag := fmt.Sprintf(`"%x-%x-%dx%dq%d"`, frame.Timestamp.UnixNano(), frame.Sequence, width, height, quality)
+	lastModified := frame.Timestamp.UTC().Truncate(time.Second)
+
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-cache")
+
+	if isNotModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	data, err := imaging.ResizeJPEG(frame.Data, width, height, quality)
+	if err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "スナップショットの加工に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/jpeg", data)
+}
+
 // GetCameraStream はMJPEGストリーミングエンドポイントの実装
 func (h *SenriganHandler) GetCameraStream(c *gin.Context, cameraID string) {
 	// VideoSourceの存在確認
@@ -113,10 +420,48 @@ func (h *SenriganHandler) GetCameraStream(c *gin.Context, cameraID string) {
 	h.streamMJPEG(c, cameraID)
 }
 
-// GetCameraWebSocket はWebSocketストリーミングエンドポイントの実装（未実装）
+// TriggerCameraRecording はイベント録画トリガーエンドポイントの実装
+func (h *SenriganHandler) TriggerCameraRecording(c *gin.Context, cameraID string) {
+	if _, found := h.cameraManager.GetVideoSource(cameraID); !found {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "camera_not_found",
+			Message: "指定されたカメラが見つかりません",
+		})
+		return
+	}
+
+	result, err := h.recorderManager.Trigger(cameraID, recorder.TriggerManual)
+	if errors.Is(err, recorder.ErrTriggerDisabled) {
+		c.JSON(http.StatusConflict, generated.ErrorResponse{
+			Error:   "clip_recording_disabled",
+			Message: "このカメラのイベント録画は有効ではありません",
+		})
+		return
+	}
+	if err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "クリップ録画の開始に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, generated.TriggerResponse{
+		CameraId:  cameraID,
+		FileName:  result.Clip.FileName,
+		StartTime: result.Clip.StartTime,
+		Until:     result.Until,
+		Extended:  result.Extended,
+		Triggers:  result.Clip.Triggers,
+	})
+}
+
+// GetCameraWebSocket はWebSocketストリーミングエンドポイントの実装
 func (h *SenriganHandler) GetCameraWebSocket(c *gin.Context, cameraID string) {
 	// VideoSourceの存在確認
-	_, found := h.cameraManager.GetVideoSource(cameraID)
+	source, found := h.cameraManager.GetVideoSource(cameraID)
 	if !found {
 		errorResponse := generated.ErrorResponse{
 			Error:   "camera_not_found",
@@ -126,13 +471,17 @@ func (h *SenriganHandler) GetCameraWebSocket(c *gin.Context, cameraID string) {
 		return
 	}
 
-	// WebSocket機能は未実装
-	errorResponse := generated.ErrorResponse{
-		Error:   "not_implemented",
-		Message: "WebSocketストリーミング機能は未実装です",
-		Details: stringPtr("将来的に実装予定です"),
+	// VideoSourceがアクティブか確認
+	if source.GetStatus() != camera.StatusActive {
+		errorResponse := generated.ErrorResponse{
+			Error:   "camera_not_active",
+			Message: "カメラがアクティブではありません",
+		}
+		c.JSON(http.StatusServiceUnavailable, errorResponse)
+		return
 	}
-	c.JSON(http.StatusNotImplemented, errorResponse)
+
+	h.streamWebSocket(c, source)
 }
 
 // ヘルパー関数
@@ -151,11 +500,232 @@ func convertCameraStatus(status camera.Status) generated.CameraInfoStatus {
 	}
 }
 
+// cameraInfo はVideoSourceを監視状態を含むAPIのカメラ情報に変換する
+func (h *SenriganHandler) cameraInfo(source camera.VideoSource) generated.CameraInfo {
+	info := convertCameraInfo(source)
+	if health, exists := h.cameraManager.GetSourceHealth(info.Id); exists {
+		cameraHealth := convertCameraHealth(health)
+		info.Health = &cameraHealth
+	}
+	return info
+}
+
+// convertCameraHealth は映像ソースの監視状態をAPIのスキーマに変換する（未発生の項目は省略する）
+func convertCameraHealth(health camera.SourceHealth) generated.CameraHealth {
+	cameraHealth := generated.CameraHealth{RestartCount: health.RestartCount}
+	if health.LastError != "" {
+		cameraHealth.LastError = stringPtr(health.LastError)
+	}
+	if !health.LastErrorAt.IsZero() {
+		cameraHealth.LastErrorAt = &health.LastErrorAt
+	}
+	if !health.LastRestartAt.IsZero() {
+		cameraHealth.LastRestartAt = &health.LastRestartAt
+	}
+	if !health.NextRestartAt.IsZero() {
+		cameraHealth.NextRestartAt = &health.NextRestartAt
+	}
+	return cameraHealth
+}
+
+// convertCameraStats は映像ソースの配信統計をAPIのスキーマに変換する
+func convertCameraStats(cameraID string, stats camera.SourceStats, health camera.SourceHealth, now time.Time) generated.CameraStats {
+	cameraStats := generated.CameraStats{
+		CameraId:         cameraID,
+		Fps:              math.Round(stats.FrameRate*10) / 10,
+		AverageFrameSize: stats.AverageFrameSize,
+		Bitrate:          math.Round(stats.Bitrate),
+		FramesPublished:  int64(stats.FramesPublished),
+		FramesDropped:    int64(stats.FramesDropped),
+		RestartCount:     health.RestartCount,
+		Subscribers:      stats.Subscribers,
+	}
+	if !stats.LastFrameAt.IsZero() {
+		cameraStats.LastFrameAt = &stats.LastFrameAt
+	}
+	if !stats.StartedAt.IsZero() {
+		cameraStats.StartedAt = &stats.StartedAt
+		cameraStats.UptimeSeconds = int64(now.Sub(stats.StartedAt).Seconds())
+	}
+	if stats.CaptureCommand != "" {
+		cameraStats.CaptureCommand = stringPtr(stats.CaptureCommand)
+	}
+	return cameraStats
+}
+
+// convertCameraInfo はVideoSourceをAPIのカメラ情報に変換する
+func convertCameraInfo(source camera.VideoSource) generated.CameraInfo {
+	info := source.GetInfo()
+	settings := source.GetCurrentSettings()
+	capabilities := source.GetCapabilities()
+
+	// カメラ設定を生成されたスキーマに変換
+	cameraSettings := generated.CameraSettings{
+		Fps:    settings.FrameRate,
+		Width:  settings.Width,
+		Height: settings.Height,
+	}
+	if settings.Format != "" {
+		cameraSettings.Format = stringPtr(settings.Format)
+	}
+	if settings.Quality > 0 {
+		cameraSettings.Quality = intPtr(settings.Quality)
+	}
+	if len(settings.Properties) > 0 {
+		properties := settings.Properties
+		cameraSettings.Properties = &properties
+	}
+
+	cameraCapabilities := generated.CameraCapabilities{
+		Resolutions: make([]generated.Resolution, 0, len(capabilities.SupportedResolutions)),
+		FrameRates:  append([]int{}, capabilities.SupportedFrameRates...),
+		Formats:     append([]string{}, capabilities.SupportedFormats...),
+	}
+	for _, resolution := range capabilities.SupportedResolutions {
+		cameraCapabilities.Resolutions = append(cameraCapabilities.Resolutions, generated.Resolution{
+			Width:  resolution.Width,
+			Height: resolution.Height,
+		})
+	}
+
+	// カメラの状態を変換
+	status := convertCameraStatus(source.GetStatus())
+
+	return generated.CameraInfo{
+		Id:           info.ID,
+		Name:         info.Name,
+		Device:       info.Device,
+		Type:         stringPtr(string(info.Type)),
+		Settings:     cameraSettings,
+		Capabilities: &cameraCapabilities,
+		Status:       &status,
+	}
+}
+
+// convertSettingsUpdate は設定変更リクエストをVideoSettingsに変換する（未指定の項目はゼロ値）
+func convertSettingsUpdate(update generated.CameraSettingsUpdate) camera.VideoSettings {
+	var settings camera.VideoSettings
+	if update.Width != nil {
+		settings.Width = *update.Width
+	}
+	if update.Height != nil {
+		settings.Height = *update.Height
+	}
+	if update.Fps != nil {
+		settings.FrameRate = *update.Fps
+	}
+	if update.Format != nil {
+		settings.Format = *update.Format
+	}
+	if update.Quality != nil {
+		settings.Quality = *update.Quality
+	}
+	if update.Properties != nil {
+		settings.Properties = *update.Properties
+	}
+	return settings
+}
+
+// convertPrincipal は認証した利用者をAPIの形式に変換する
+func convertPrincipal(principal auth.Principal) generated.AuthUser {
+	user := generated.AuthUser{
+		Username: principal.Name,
+		Role:     generated.AuthUserRole(principal.Role),
+		Method:   generated.AuthUserMethod(principal.Method),
+	}
+	if !principal.ViewsAllCameras() {
+		cameras := append([]string(nil), principal.Cameras...)
+		user.Cameras = &cameras
+	}
+	if !principal.ExpiresAt.IsZero() {
+		expiresAt := principal.ExpiresAt
+		user.ExpiresAt = &expiresAt
+	}
+	return user
+}
+
+// cameraErrorResponse はカメラ管理のエラーをHTTPレスポンスに変換する
+func cameraErrorResponse(c *gin.Context, err error, message string) {
+	errMsg := err.Error()
+
+	switch {
+	case errors.Is(err, camera.ErrVideoSourceNotFound):
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "camera_not_found",
+			Message: "指定されたカメラが見つかりません",
+		})
+	case errors.Is(err, camera.ErrVideoSourceExists):
+		c.JSON(http.StatusConflict, generated.ErrorResponse{
+			Error:   "camera_already_exists",
+			Message: "同じIDまたはデバイスのカメラが既に存在します",
+			Details: &errMsg,
+		})
+	case errors.Is(err, camera.ErrInvalidSettings):
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "invalid_settings",
+			Message: "カメラが対応していない設定です",
+			Details: &errMsg,
+		})
+	case errors.Is(err, camera.ErrDeviceUnavailable), errors.Is(err, camera.ErrUnsupportedSourceType):
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "invalid_request",
+			Message: message,
+			Details: &errMsg,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: message,
+			Details: &errMsg,
+		})
+	}
+}
+
 // stringPtr は文字列のポインタを返すヘルパー関数
 func stringPtr(s string) *string {
 	return &s
 }
 
+// intPtr は整数のポインタを返すヘルパー関数
+func intPtr(i int) *int {
+	return &i
+}
+
+// int64Ptr は64bit整数のポインタを返すヘルパー関数
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+// boolPtr は真偽値のポインタを返すヘルパー関数
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// isNotModified は条件付きリクエストに対してフレームが更新されていないかを判定する
+// If-None-Match が指定されている場合は If-Modified-Since より優先する（RFC 9110）
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		if err == nil && !lastModified.After(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// mjpegSubscriberBufferSize はMJPEGクライアント毎のフレームバッファサイズ
+const mjpegSubscriberBufferSize = 3
+
 // streamMJPEG はMJPEGストリームを配信する
 func (h *SenriganHandler) streamMJPEG(c *gin.Context, cameraID string) {
 	// VideoSourceを取得
@@ -169,7 +739,6 @@ func (h *SenriganHandler) streamMJPEG(c *gin.Context, cameraID string) {
 	c.Header("Content-Type", "multipart/x-mixed-replace; boundary=frame")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
-	c.Header("Access-Control-Allow-Origin", "*")
 
 	// レスポンスライターを取得
 	writer := c.Writer
@@ -179,8 +748,11 @@ func (h *SenriganHandler) streamMJPEG(c *gin.Context, cameraID string) {
 		return
 	}
 
-	// フレームチャンネルを取得
-	frameChan := source.GetFrameChannel()
+	// フレーム配信を購読（クライアント毎に独立したバッファを持つ）
+	subscription := source.Subscribe(mjpegSubscriberBufferSize, camera.DropOldest)
+	defer subscription.Unsubscribe()
+	defer h.metrics.StreamClientConnected(cameraID, metrics.ProtocolMJPEG)()
+	frameChan := subscription.Frames()
 
 	// クライアント切断を検知するためのコンテキスト
 	clientGone := c.Request.Context().Done()
@@ -209,7 +781,7 @@ func (h *SenriganHandler) streamMJPEG(c *gin.Context, cameraID string) {
 				return
 			}
 
-			_, err = writer.Write(frame)
+			_, err = writer.Write(frame.Data)
 			if err != nil {
 				return
 			}
@@ -226,8 +798,13 @@ func (h *SenriganHandler) streamMJPEG(c *gin.Context, cameraID string) {
 }
 
 // GetTimelapseVideos はタイムラプス動画一覧取得エンドポイントの実装
-func (h *SenriganHandler) GetTimelapseVideos(c *gin.Context) {
-	videos, err := h.timelapseManager.GetTimelapseVideos()
+func (h *SenriganHandler) GetTimelapseVideos(c *gin.Context, params generated.GetTimelapseVideosParams) {
+	filter := timelapse.VideoFilter{}
+	if params.Source != nil {
+		filter.SourceID = *params.Source
+	}
+
+	videos, err := h.timelapseManager.GetTimelapseVideos(filter)
 	if err != nil {
 		errMsg := err.Error()
 		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
@@ -240,34 +817,382 @@ func (h *SenriganHandler) GetTimelapseVideos(c *gin.Context) {
 
 	// timelapse.Videoからgenerated.Videoに変換
 	// 事前にスライスの容量を確保（prealloc）
+	videos = visibleVideos(c, videos)
 	response := make([]generated.Video, 0, len(videos))
 	for _, video := range videos {
-		generatedVideo := generated.Video{
-			Date:        video.Date,
-			FilePath:    video.FilePath,
-			FileSize:    video.FileSize,
-			Status:      generated.VideoStatus(video.Status),
-			SourceCount: &video.SourceCount,
+		response = append(response, convertVideo(video))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SeekTimelapseVideo はタイムラプス動画の再生位置取得エンドポイントの実装
+func (h *SenriganHandler) SeekTimelapseVideo(c *gin.Context, params generated.SeekTimelapseVideoParams) {
+	sourceID, ok := authorizeTimelapseSource(c, params.Source)
+	if !ok {
+		return
+	}
+
+	result, err := h.timelapseManager.SeekVideo(sourceID, params.At)
+	switch {
+	case errors.Is(err, timelapse.ErrVideoNotFound):
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "video_not_found",
+			Message: "指定された時刻のタイムラプス動画が見つかりません",
+		})
+		return
+	case errors.Is(err, timelapse.ErrVideoIndexNotFound):
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "video_index_not_found",
+			Message: "動画に撮影時刻の記録がありません",
+		})
+		return
+	case err != nil:
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "タイムラプス動画の再生位置の取得に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	offset := result.Offset.Seconds()
+	c.JSON(http.StatusOK, generated.VideoSeekResponse{
+		Video:          convertVideo(result.Video),
+		Offset:         offset,
+		FrameTimestamp: result.FrameTimestamp,
+		Url:            timelapseVideoPath + filepath.Base(result.Video.FilePath) + "#t=" + strconv.FormatFloat(offset, 'f', 3, 64),
+	})
+}
+
+// convertVideo はタイムラプス動画をAPIのスキーマに変換する（撮影時刻の記録がない項目は省略する）
+func convertVideo(video timelapse.Video) generated.Video {
+	generatedVideo := generated.Video{
+		Date:        video.Date,
+		FilePath:    video.FilePath,
+		FileSize:    video.FileSize,
+		Status:      generated.VideoStatus(video.Status),
+		SourceCount: &video.SourceCount,
+	}
+
+	if video.Duration > 0 {
+		duration := video.Duration.String()
+		generatedVideo.Duration = &duration
+	}
+	if video.FrameCount > 0 {
+		generatedVideo.FrameCount = &video.FrameCount
+	}
+	if !video.StartTime.IsZero() {
+		generatedVideo.StartTime = &video.StartTime
+	}
+	if !video.EndTime.IsZero() {
+		generatedVideo.EndTime = &video.EndTime
+	}
+	if video.SourceID != "" {
+		generatedVideo.SourceId = &video.SourceID
+	}
+	return generatedVideo
+}
+
+// GetTimelapseFrames はタイムラプスのフレーム一覧取得エンドポイントの実装
+func (h *SenriganHandler) GetTimelapseFrames(c *gin.Context, params generated.GetTimelapseFramesParams) {
+	sourceID, ok := authorizeTimelapseSource(c, params.Source)
+	if !ok {
+		return
+	}
+
+	// 期間の省略時は to を現在、from を to の日の0時とする
+	filter := timelapse.FrameFilter{SourceID: sourceID, To: time.Now()}
+	if params.To != nil {
+		filter.To = *params.To
+	}
+	filter.From = time.Date(filter.To.Year(), filter.To.Month(), filter.To.Day(), 0, 0, 0, 0, filter.To.Location())
+	if params.From != nil {
+		filter.From = *params.From
+	}
+	if filter.To.Before(filter.From) {
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "invalid_parameter",
+			Message: "to は from 以降の時刻を指定してください",
+		})
+		return
+	}
+
+	limit := defaultFrameLimit
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxFrameLimit {
+			c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Error:   "invalid_parameter",
+				Message: "limit は1から10000の範囲で指定してください",
+			})
+			return
 		}
+		limit = *params.Limit
+	}
+
+	frames, err := h.timelapseManager.GetFrames(filter)
+	if err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "タイムラプスのフレーム取得に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
 
-		if video.Duration > 0 {
-			duration := video.Duration.String()
-			generatedVideo.Duration = &duration
+	// 長い期間でもシークバー全体を表示できるよう、期間全体から均等に間引く
+	sampled := timelapse.SampleFrames(frames, limit)
+	response := generated.FramesResponse{
+		Frames: make([]generated.TimelapseFrame, 0, len(sampled)),
+		Total:  len(frames),
+	}
+	for _, frame := range sampled {
+		item := generated.TimelapseFrame{
+			Timestamp:    frame.Timestamp,
+			Url:          timelapseFrameURL(frame, 0),
+			ThumbnailUrl: timelapseFrameURL(frame, frameThumbnailWidth),
 		}
-		if video.FrameCount > 0 {
-			generatedVideo.FrameCount = &video.FrameCount
+		if frame.SourceID != "" {
+			item.SourceId = &frame.SourceID
 		}
-		if !video.StartTime.IsZero() {
-			generatedVideo.StartTime = &video.StartTime
+		response.Frames = append(response.Frames, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTimelapseFrame はタイムラプスのフレーム取得エンドポイントの実装
+func (h *SenriganHandler) GetTimelapseFrame(c *gin.Context, timestamp time.Time, params generated.GetTimelapseFrameParams) {
+	sourceID, ok := authorizeTimelapseSource(c, params.Source)
+	if !ok {
+		return
+	}
+
+	var width, height int
+	if params.Width != nil {
+		if *params.Width < 1 || *params.Width > maxSnapshotWidth {
+			c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Error:   "invalid_parameter",
+				Message: "width は1から7680の範囲で指定してください",
+			})
+			return
 		}
-		if !video.EndTime.IsZero() {
-			generatedVideo.EndTime = &video.EndTime
+		width = *params.Width
+	}
+	if params.Height != nil {
+		if *params.Height < 1 || *params.Height > maxSnapshotHeight {
+			c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Error:   "invalid_parameter",
+				Message: "height は1から4320の範囲で指定してください",
+			})
+			return
 		}
+		height = *params.Height
+	}
+
+	frame, data, err := h.timelapseManager.GetFrame(sourceID, timestamp)
+	if errors.Is(err, timelapse.ErrFrameNotFound) {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "frame_not_found",
+			Message: "指定された時刻付近のフレームが見つかりません",
+		})
+		return
+	}
+	if err == nil && (width > 0 || height > 0) {
+		data, err = imaging.ResizeJPEG(data, width, height, 0)
+	}
+	if err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "タイムラプスのフレーム取得に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
 
-		response = append(response, generatedVideo)
+	// 保存済みのフレームは変更されないため、撮影時刻を指定した場合はキャッシュできる
+	// それ以外は後から撮影したフレームの方が近くなる場合がある
+	if frame.Timestamp.Equal(timestamp) {
+		c.Header("Cache-Control", "private, max-age=86400")
+	} else {
+		c.Header("Cache-Control", "no-cache")
 	}
+	c.Header("X-Frame-Timestamp", frame.Timestamp.UTC().Format(frameTimestampLayout))
+	c.Data(http.StatusOK, "image/jpeg", data)
+}
 
-	c.JSON(http.StatusOK, response)
+// authorizeTimelapseSource は利用者がタイムラプスのフレーム・動画の映像ソースを閲覧できるか確認する
+// 映像ソースの指定がない場合は結合したフレーム・動画とし、全てのカメラを閲覧できる利用者のみ許可する
+func authorizeTimelapseSource(c *gin.Context, source *string) (string, bool) {
+	principal, _ := principalFromContext(c)
+	if source == nil || *source == "" {
+		if !principal.ViewsAllCameras() {
+			c.JSON(http.StatusForbidden, generated.ErrorResponse{
+				Error:   "forbidden",
+				Message: "結合したタイムラプスは全てのカメラを閲覧できる利用者のみ利用できます",
+			})
+			return "", false
+		}
+		return "", true
+	}
+
+	// 閲覧できないカメラは存在しないものとして扱う
+	if !principal.CanViewCamera(*source) {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "camera_not_found",
+			Message: "指定されたカメラが見つかりません",
+		})
+		return "", false
+	}
+	return *source, true
+}
+
+// timelapseFrameURL はタイムラプスのフレーム画像のURLを返す（width が0の場合は元のサイズ）
+func timelapseFrameURL(frame timelapse.StoredFrame, width int) string {
+	query := url.Values{}
+	if frame.SourceID != "" {
+		query.Set("source", frame.SourceID)
+	}
+	if width > 0 {
+		query.Set("width", strconv.Itoa(width))
+	}
+
+	u := "/api/timelapse/frames/" + frame.Timestamp.UTC().Format(frameTimestampLayout)
+	if encoded := query.Encode(); encoded != "" {
+		u += "?" + encoded
+	}
+	return u
+}
+
+// GetMosaic はカメラのモザイク画像取得エンドポイントの実装
+func (h *SenriganHandler) GetMosaic(c *gin.Context, params generated.GetMosaicParams) {
+	config := h.timelapseManager.GetConfig()
+
+	// レイアウトの指定がない場合はタイムラプスで使用中のレイアウトで結合する
+	layoutName := config.Layout
+	if params.Layout != nil {
+		layoutName = *params.Layout
+	}
+	layout, err := config.LayoutByName(layoutName)
+	if err != nil {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "layout_not_found",
+			Message: "指定されたレイアウトが見つかりません",
+		})
+		return
+	}
+
+	composer := timelapse.NewFrameComposer(config)
+	composer.SetLayout(layout)
+
+	// 閲覧できるカメラのみを結合する
+	frame, err := composer.ComposeFrames(c.Request.Context(), visibleSources(c, h.cameraManager.GetVideoSources()))
+	if err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusServiceUnavailable, generated.ErrorResponse{
+			Error:   "no_frames",
+			Message: "フレームを取得できるカメラがありません",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, "image/jpeg", frame.ComposedData)
+}
+
+// GetTimelapseLayouts はタイムラプスのレイアウト一覧取得エンドポイントの実装
+func (h *SenriganHandler) GetTimelapseLayouts(c *gin.Context) {
+	c.JSON(http.StatusOK, convertLayouts(h.timelapseManager.GetConfig()))
+}
+
+// SetTimelapseLayout はタイムラプスのレイアウト変更エンドポイントの実装
+func (h *SenriganHandler) SetTimelapseLayout(c *gin.Context) {
+	var request generated.SetTimelapseLayoutJSONRequestBody
+	if err := c.ShouldBindJSON(&request); err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "リクエストボディが不正です",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	if err := h.timelapseManager.SetLayout(request.Name); err != nil {
+		if errors.Is(err, timelapse.ErrLayoutNotFound) {
+			c.JSON(http.StatusNotFound, generated.ErrorResponse{
+				Error:   "layout_not_found",
+				Message: "指定されたレイアウトが見つかりません",
+			})
+			return
+		}
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_error",
+			Message: "レイアウトの変更に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, convertLayouts(h.timelapseManager.GetConfig()))
+}
+
+// convertLayouts は選択できるレイアウトと使用中のレイアウトをAPIのスキーマに変換する
+func convertLayouts(config timelapse.Config) generated.MosaicLayoutsResponse {
+	active := config.Layout
+	if active == "" {
+		active = timelapse.LayoutAuto
+	}
+
+	response := generated.MosaicLayoutsResponse{Active: active, Layouts: []generated.MosaicLayout{}}
+	for _, name := range config.LayoutNames() {
+		layout, err := config.LayoutByName(name)
+		if err != nil {
+			continue
+		}
+
+		layoutType := layout.Type
+		if layoutType == "" {
+			layoutType = timelapse.LayoutAuto
+		}
+		item := generated.MosaicLayout{Name: name, Type: generated.MosaicLayoutType(layoutType)}
+		if layout.Cols > 0 {
+			item.Cols = &layout.Cols
+		}
+		if layout.Rows > 0 {
+			item.Rows = &layout.Rows
+		}
+		if len(layout.Cells) > 0 {
+			cells := make([]generated.MosaicLayoutCell, len(layout.Cells))
+			for i, cell := range layout.Cells {
+				colSpan, rowSpan := max(cell.ColSpan, 1), max(cell.RowSpan, 1)
+				cells[i] = generated.MosaicLayoutCell{
+					Source:  cell.Source,
+					Col:     cell.Col,
+					Row:     cell.Row,
+					ColSpan: &colSpan,
+					RowSpan: &rowSpan,
+				}
+			}
+			item.Cells = &cells
+		}
+		if layout.Main != "" {
+			item.Main = &layout.Main
+		}
+		if layout.Size > 0 {
+			item.Size = &layout.Size
+		}
+		if layout.Position != "" {
+			item.Position = &layout.Position
+		}
+		response.Layouts = append(response.Layouts, item)
+	}
+	return response
 }
 
 // GetTimelapseConfig はタイムラプス設定取得エンドポイントの実装
@@ -275,11 +1200,12 @@ func (h *SenriganHandler) GetTimelapseConfig(c *gin.Context) {
 	config := h.timelapseManager.GetConfig()
 
 	response := generated.Config{
-		Enabled:        &config.Enabled,
-		OutputFormat:   &config.OutputFormat,
-		Quality:        &config.Quality,
-		RetentionDays:  &config.RetentionDays,
-		MaxFrameBuffer: &config.MaxFrameBuffer,
+		Enabled:           &config.Enabled,
+		OutputFormat:      &config.OutputFormat,
+		Quality:           &config.Quality,
+		RetentionDays:     &config.RetentionDays,
+		MaxFrameBuffer:    &config.MaxFrameBuffer,
+		MaxFrameStorageMb: &config.MaxFrameStorage,
 	}
 
 	if config.CaptureInterval > 0 {
@@ -290,6 +1216,22 @@ func (h *SenriganHandler) GetTimelapseConfig(c *gin.Context) {
 		updateInterval := config.UpdateInterval.String()
 		response.UpdateInterval = &updateInterval
 	}
+	if config.Output != "" {
+		output := generated.ConfigOutput(config.Output)
+		response.Output = &output
+	}
+	response.Overlay = convertOverlayConfig(config.Overlay)
+	if config.Layout != "" {
+		response.Layout = &config.Layout
+	}
+	if config.Scale != "" {
+		scale := generated.ConfigScale(config.Scale)
+		response.Scale = &scale
+	}
+	if config.Resampling != "" {
+		resampling := generated.ConfigResampling(config.Resampling)
+		response.Resampling = &resampling
+	}
 	if config.Resolution.Width > 0 && config.Resolution.Height > 0 {
 		response.Resolution = &generated.Resolution{
 			Width:  config.Resolution.Width,
@@ -300,9 +1242,37 @@ func (h *SenriganHandler) GetTimelapseConfig(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// convertOverlayConfig はフレームに描画する文字の設定をAPIのスキーマに変換する（未指定の項目は省略する）
+func convertOverlayConfig(overlay timelapse.OverlayConfig) *generated.OverlayConfig {
+	response := &generated.OverlayConfig{
+		Enabled:    overlay.Enabled,
+		Timestamp:  &overlay.Timestamp,
+		CameraName: &overlay.CameraName,
+		FontSize:   &overlay.FontSize,
+		Background: &overlay.Background,
+	}
+	if overlay.Text != "" {
+		response.Text = &overlay.Text
+	}
+	if overlay.Position != "" {
+		position := generated.OverlayConfigPosition(overlay.Position)
+		response.Position = &position
+	}
+	if overlay.FontFile != "" {
+		response.FontFile = &overlay.FontFile
+	}
+	return response
+}
+
 // GetTimelapseStatus はタイムラプスシステム状態取得エンドポイントの実装
 func (h *SenriganHandler) GetTimelapseStatus(c *gin.Context) {
+	principal, _ := principalFromContext(c)
+	restricted := !principal.ViewsAllCameras()
+
 	status, err := h.timelapseManager.GetTimelapseStatus()
+	if err == nil && restricted {
+		err = h.restrictTimelapseStatus(c, &status)
+	}
 	if err != nil {
 		errMsg := err.Error()
 		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
@@ -314,19 +1284,138 @@ func (h *SenriganHandler) GetTimelapseStatus(c *gin.Context) {
 	}
 
 	response := generated.StatusResponse{
-		Enabled:         &status.Enabled,
-		ActiveSources:   &status.ActiveSources,
-		TotalVideos:     &status.TotalVideos,
-		StorageUsed:     &status.StorageUsed,
-		FrameBufferSize: &status.FrameBufferSize,
+		Enabled:       &status.Enabled,
+		ActiveSources: &status.ActiveSources,
+		TotalVideos:   &status.TotalVideos,
+		StorageUsed:   &status.StorageUsed,
 	}
 
 	if status.CurrentVideo != "" {
 		response.CurrentVideo = &status.CurrentVideo
 	}
+	if !restricted {
+		response.FrameBufferSize = &status.FrameBufferSize
+	}
 	if !status.LastUpdate.IsZero() {
 		response.LastUpdate = &status.LastUpdate
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// restrictTimelapseStatus はカメラが制限された利用者向けに、状態を閲覧できる映像ソースに限った値にする
+// 結合動画の状態（録画中の動画・フレームバッファ・更新時刻）は全てのカメラを含むため返さない
+func (h *SenriganHandler) restrictTimelapseStatus(c *gin.Context, status *timelapse.StatusInfo) error {
+	videos, err := h.timelapseManager.GetTimelapseVideos(timelapse.VideoFilter{})
+	if err != nil {
+		return err
+	}
+
+	status.TotalVideos = 0
+	status.StorageUsed = 0
+	for _, video := range visibleVideos(c, videos) {
+		status.TotalVideos++
+		status.StorageUsed += video.FileSize
+	}
+
+	status.ActiveSources = 0
+	for _, source := range visibleSources(c, h.cameraManager.GetVideoSources()) {
+		if source.GetStatus() == camera.StatusActive {
+			status.ActiveSources++
+		}
+	}
+
+	status.CurrentVideo = ""
+	status.FrameBufferSize = 0
+	status.LastUpdate = time.Time{}
+	return nil
+}
+
+// GetEvents は動体検知イベント一覧取得エンドポイントの実装
+func (h *SenriganHandler) GetEvents(c *gin.Context, params generated.GetEventsParams) {
+	principal, _ := principalFromContext(c)
+	filter := motion.Filter{Limit: defaultEventLimit, Visible: principal.CanViewCamera}
+	if params.CameraId != nil {
+		filter.SourceID = *params.CameraId
+	}
+	if params.From != nil {
+		filter.From = *params.From
+	}
+	if params.To != nil {
+		filter.To = *params.To
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxEventLimit {
+			c.JSON(http.StatusBadRequest, generated.ErrorResponse{
+				Error:   "invalid_parameter",
+				Message: "limit は1から1000の範囲で指定してください",
+			})
+			return
+		}
+		filter.Limit = *params.Limit
+	}
+
+	events, err := h.motionManager.ListEvents(filter)
+	if err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "動体検知イベント取得に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	response := generated.EventsResponse{
+		Events: make([]generated.MotionEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, generated.MotionEvent{
+			Id:          event.ID,
+			CameraId:    event.SourceID,
+			CameraName:  event.SourceName,
+			StartTime:   event.StartTime,
+			EndTime:     event.EndTime,
+			PeakScore:   event.PeakScore,
+			InProgress:  event.InProgress,
+			SnapshotUrl: "/api/events/" + event.ID + "/snapshot",
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetEventSnapshot は動体検知イベントのスナップショット取得エンドポイントの実装
+func (h *SenriganHandler) GetEventSnapshot(c *gin.Context, eventID string) {
+	// 閲覧できないカメラのイベントは存在しないものとして扱う
+	principal, _ := principalFromContext(c)
+	event, err := h.motionManager.GetEvent(eventID)
+	if err == nil && !principal.CanViewCamera(event.SourceID) {
+		err = motion.ErrEventNotFound
+	}
+
+	var data []byte
+	if err == nil {
+		data, err = h.motionManager.GetEventSnapshot(eventID)
+	}
+	if errors.Is(err, motion.ErrEventNotFound) {
+		c.JSON(http.StatusNotFound, generated.ErrorResponse{
+			Error:   "event_not_found",
+			Message: "指定されたイベントが見つかりません",
+		})
+		return
+	}
+	if err != nil {
+		errMsg := err.Error()
+		c.JSON(http.StatusInternalServerError, generated.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "スナップショット取得に失敗しました",
+			Details: &errMsg,
+		})
+		return
+	}
+
+	// イベント終了後もスナップショットは更新されうるため、キャッシュは短くする
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, "image/jpeg", data)
+}
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"senrigan/internal/auth"
+	"senrigan/internal/camera"
+	"senrigan/internal/config"
+	"senrigan/internal/generated"
+	"senrigan/internal/timelapse"
+
+	"github.com/gin-gonic/gin"
+)
+
+// principalKey は認証した利用者を gin.Context に保存するキー
+const principalKey = "senrigan.principal"
+
+// timelapseVideoPath はタイムラプス動画ファイルを配信するパス
+const timelapseVideoPath = "/api/timelapse/video/"
+
+// publicPaths は認証なしでアクセスできるAPIのパス
+var publicPaths = map[string]bool{
+	"/health":          true,
+	"/api/auth/login":  true,
+	"/api/auth/logout": true,
+}
+
+// routeRoles は viewer より上の権限が必要なルート（"メソッド ルートのパス"）
+// 記載のないルートは viewer 以上で利用できる
+var routeRoles = map[string]auth.Role{
+	"POST /api/cameras":                   auth.RoleAdmin,
+	"DELETE /api/cameras/:cameraId":       auth.RoleAdmin,
+	"PATCH /api/cameras/:cameraId":        auth.RoleOperator,
+	"POST /api/cameras/:cameraId/start":   auth.RoleOperator,
+	"POST /api/cameras/:cameraId/stop":    auth.RoleOperator,
+	"POST /api/cameras/:cameraId/trigger": auth.RoleOperator,
+	"PUT /api/timelapse/layout":           auth.RoleOperator,
+}
+
+// requiresAuth は認証が必要なパスか判定する
+// フロントエンドの静的ファイル（SPA）はログイン画面を表示するため認証しない
+func requiresAuth(path string) bool {
+	if publicPaths[path] {
+		return false
+	}
+	return strings.HasPrefix(path, "/api/") || path == "/metrics"
+}
+
+// authMiddleware はリクエストの利用者を認証し、ルートに必要な権限を確認する
+func authMiddleware(authManager auth.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !requiresAuth(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		principal, err := authManager.Authenticate(c.Request)
+		if err != nil {
+			message := "ログインが必要です"
+			if !errors.Is(err, auth.ErrNoCredentials) {
+				message = err.Error()
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, generated.ErrorResponse{
+				Error:   "unauthorized",
+				Message: message,
+			})
+			return
+		}
+
+		required, ok := routeRoles[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			required = auth.RoleViewer
+		}
+		if !principal.Role.Allows(required) {
+			c.AbortWithStatusJSON(http.StatusForbidden, generated.ErrorResponse{
+				Error:   "forbidden",
+				Message: "この操作には " + string(required) + " 以上の権限が必要です",
+			})
+			return
+		}
+
+		// 閲覧できないカメラは存在しないものとして扱う
+		if cameraID := c.Param("cameraId"); cameraID != "" && !principal.CanViewCamera(cameraID) {
+			c.AbortWithStatusJSON(http.StatusNotFound, generated.ErrorResponse{
+				Error:   "camera_not_found",
+				Message: "指定されたカメラが見つかりません",
+			})
+			return
+		}
+
+		// 結合したタイムラプス動画とメトリクスは全てのカメラを含むため、カメラが制限された利用者には公開しない
+		if !principal.ViewsAllCameras() && !canViewRestrictedPath(principal, c.Request.URL.Path) {
+			c.AbortWithStatusJSON(http.StatusForbidden, generated.ErrorResponse{
+				Error:   "forbidden",
+				Message: "全てのカメラを閲覧できる利用者のみ利用できます",
+			})
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// canViewRestrictedPath はカメラが制限された利用者がパスにアクセスできるか判定する
+// タイムラプス動画は閲覧できるカメラの映像ソース毎の動画のみアクセスできる
+func canViewRestrictedPath(principal auth.Principal, path string) bool {
+	if path == "/metrics" {
+		return false
+	}
+	name, found := strings.CutPrefix(path, timelapseVideoPath)
+	if !found {
+		return true
+	}
+	sourceID, _, ok := timelapse.ParseVideoFilename(name)
+	return ok && sourceID != "" && principal.CanViewCamera(sourceID)
+}
+
+// principalFromContext は authMiddleware が認証した利用者を返す
+func principalFromContext(c *gin.Context) (auth.Principal, bool) {
+	value, exists := c.Get(principalKey)
+	if !exists {
+		return auth.Principal{}, false
+	}
+	principal, ok := value.(auth.Principal)
+	return principal, ok
+}
+
+// visibleSources は利用者が閲覧できる映像ソースのみを返す
+func visibleSources(c *gin.Context, sources []camera.VideoSource) []camera.VideoSource {
+	principal, _ := principalFromContext(c)
+	if principal.ViewsAllCameras() {
+		return sources
+	}
+
+	visible := make([]camera.VideoSource, 0, len(sources))
+	for _, source := range sources {
+		if principal.CanViewCamera(source.GetInfo().ID) {
+			visible = append(visible, source)
+		}
+	}
+	return visible
+}
+
+// visibleVideos は利用者が閲覧できるタイムラプス動画のみを返す
+// 結合した動画は全てのカメラを含むため、カメラが制限された利用者には映像ソース毎の動画のみを返す
+func visibleVideos(c *gin.Context, videos []timelapse.Video) []timelapse.Video {
+	principal, _ := principalFromContext(c)
+	if principal.ViewsAllCameras() {
+		return videos
+	}
+
+	visible := make([]timelapse.Video, 0, len(videos))
+	for _, video := range videos {
+		if video.SourceID != "" && principal.CanViewCamera(video.SourceID) {
+			visible = append(visible, video)
+		}
+	}
+	return visible
+}
+
+// originPolicy は他のオリジンからのアクセス（CORS・WebSocket）の可否を判定する
+type originPolicy struct {
+	allowAll bool
+	allowed  map[string]bool
+}
+
+// newOriginPolicy は設定から originPolicy を作成する
+// 許可するオリジンが未指定の場合、認証が無効なら全てのオリジンを許可する（開発環境用）
+func newOriginPolicy(cfg *config.Config) *originPolicy {
+	policy := &originPolicy{
+		allowAll: len(cfg.Server.AllowedOrigins) == 0 && !cfg.Auth.Enabled,
+		allowed:  make(map[string]bool),
+	}
+	for _, origin := range cfg.Server.AllowedOrigins {
+		policy.allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+	return policy
+}
+
+// allowsOrigin は他のオリジンを許可するか判定する
+func (p *originPolicy) allowsOrigin(origin string) bool {
+	return p.allowAll || p.allowed[origin]
+}
+
+// allowsRequest はリクエストのオリジンを許可するか判定する（WebSocketのCheckOrigin用）
+// Originヘッダーがない場合と同一オリジンの場合は常に許可する
+func (p *originPolicy) allowsRequest(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return p.allowsOrigin(origin)
+}
+
+// corsMiddleware は許可したオリジンにCORSヘッダーを付与する
+// 全てのオリジンを許可する場合を除き、Cookieを送信できるようオリジンを明示して返す
+func corsMiddleware(policy *originPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin != "" && policy.allowsOrigin(origin) {
+			if policy.allowAll {
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Header("Access-Control-Allow-Origin", origin)
+				c.Header("Access-Control-Allow-Credentials", "true")
+				c.Header("Vary", "Origin")
+			}
+			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		}
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,327 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"senrigan/internal/auth"
+	"senrigan/internal/config"
+	"senrigan/internal/generated"
+	"senrigan/internal/timelapse"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// routeReached はミドルウェアを通過してルートに到達したリクエストに返すステータス
+const routeReached = http.StatusTeapot
+
+// testTokens はテスト用のAPIトークン（ロール・閲覧できるカメラ毎）
+type testTokens struct {
+	admin      string
+	operator   string
+	viewer     string
+	restricted string // cam1 のみ閲覧できる viewer
+}
+
+// newTestAuthConfig はAPIトークンを登録した認証有効の設定を作成する
+func newTestAuthConfig(t *testing.T) (*config.Config, testTokens) {
+	t.Helper()
+
+	var tokens testTokens
+	authConfig := auth.DefaultConfig()
+	authConfig.Enabled = true
+	for _, entry := range []struct {
+		token   *string
+		role    auth.Role
+		cameras []string
+	}{
+		{&tokens.admin, auth.RoleAdmin, nil},
+		{&tokens.operator, auth.RoleOperator, nil},
+		{&tokens.viewer, auth.RoleViewer, nil},
+		{&tokens.restricted, auth.RoleViewer, []string{"cam1"}},
+	} {
+		token, tokenHash, err := auth.GenerateToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		*entry.token = token
+		authConfig.Tokens = append(authConfig.Tokens, auth.Token{
+			Name:      string(entry.role) + strings.Join(entry.cameras, ","),
+			TokenHash: tokenHash,
+			Role:      entry.role,
+			Cameras:   entry.cameras,
+		})
+	}
+	if err := authConfig.Validate(); err != nil {
+		t.Fatalf("Expected valid auth config, got %v", err)
+	}
+
+	return &config.Config{Auth: authConfig}, tokens
+}
+
+// newTestRouter は本番と同じミドルウェアとルートを登録したルーターを作成する
+// ハンドラーの代わりに routeReached を返し、ミドルウェアを通過したかのみを確認する
+func newTestRouter(t *testing.T, cfg *config.Config) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(corsMiddleware(newOriginPolicy(cfg)))
+	router.Use(authMiddleware(auth.NewDefaultManager(cfg.Auth)))
+	router.Use(func(c *gin.Context) {
+		c.AbortWithStatus(routeReached)
+	})
+
+	generated.RegisterHandlers(router, &SenriganHandler{})
+	router.GET("/metrics", func(*gin.Context) {})
+	router.Static("/api/timelapse/video", t.TempDir())
+	return router
+}
+
+// serve はトークンを付けてリクエストを送り、レスポンスのステータスを返す
+func serve(router *gin.Engine, method, path, token string) int {
+	request := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func TestAuthMiddleware_Authentication(t *testing.T) {
+	cfg, tokens := newTestAuthConfig(t)
+	router := newTestRouter(t, cfg)
+
+	tests := []struct {
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		// 公開パスとフロントエンドは認証しない
+		{"GET", "/health", "", routeReached},
+		{"POST", "/api/auth/login", "", routeReached},
+		{"POST", "/api/auth/logout", "", routeReached},
+		{"GET", "/", "", routeReached},
+		{"GET", "/cameras/cam1", "", routeReached},
+
+		// APIとメトリクスは認証が必要
+		{"GET", "/api/cameras", "", http.StatusUnauthorized},
+		{"GET", "/api/auth/me", "", http.StatusUnauthorized},
+		{"GET", "/api/cameras/cam1/stream", "", http.StatusUnauthorized},
+		{"GET", "/api/timelapse/video/" + timelapse.VideoFilename("cam1", time.Now()), "", http.StatusUnauthorized},
+		{"GET", "/metrics", "", http.StatusUnauthorized},
+		{"GET", "/api/cameras", "invalid-token", http.StatusUnauthorized},
+		{"GET", "/api/cameras", tokens.viewer, routeReached},
+	}
+
+	for _, tt := range tests {
+		if got := serve(router, tt.method, tt.path, tt.token); got != tt.want {
+			t.Errorf("%s %s (token %q): status = %d, want %d", tt.method, tt.path, tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestAuthMiddleware_Roles(t *testing.T) {
+	cfg, tokens := newTestAuthConfig(t)
+	router := newTestRouter(t, cfg)
+
+	tests := []struct {
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		// viewer はカメラを閲覧できるが管理できない
+		{"GET", "/api/cameras", tokens.viewer, routeReached},
+		{"GET", "/api/cameras/cam1", tokens.viewer, routeReached},
+		{"GET", "/api/cameras/cam1/stream", tokens.viewer, routeReached},
+		{"POST", "/api/cameras", tokens.viewer, http.StatusForbidden},
+		{"DELETE", "/api/cameras/cam1", tokens.viewer, http.StatusForbidden},
+		{"PATCH", "/api/cameras/cam1", tokens.viewer, http.StatusForbidden},
+		{"POST", "/api/cameras/cam1/start", tokens.viewer, http.StatusForbidden},
+		{"POST", "/api/cameras/cam1/stop", tokens.viewer, http.StatusForbidden},
+		{"POST", "/api/cameras/cam1/trigger", tokens.viewer, http.StatusForbidden},
+		{"PUT", "/api/timelapse/layout", tokens.viewer, http.StatusForbidden},
+
+		// operator はカメラを操作できるが追加・削除できない
+		{"PATCH", "/api/cameras/cam1", tokens.operator, routeReached},
+		{"POST", "/api/cameras/cam1/start", tokens.operator, routeReached},
+		{"PUT", "/api/timelapse/layout", tokens.operator, routeReached},
+		{"POST", "/api/cameras", tokens.operator, http.StatusForbidden},
+		{"DELETE", "/api/cameras/cam1", tokens.operator, http.StatusForbidden},
+
+		// admin は全て操作できる
+		{"POST", "/api/cameras", tokens.admin, routeReached},
+		{"DELETE", "/api/cameras/cam1", tokens.admin, routeReached},
+	}
+
+	for _, tt := range tests {
+		if got := serve(router, tt.method, tt.path, tt.token); got != tt.want {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAuthMiddleware_RestrictedCameras(t *testing.T) {
+	cfg, tokens := newTestAuthConfig(t)
+	router := newTestRouter(t, cfg)
+	today := time.Now()
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		// 閲覧できるカメラ
+		{"/api/cameras/cam1", routeReached},
+		{"/api/cameras/cam1/stream", routeReached},
+		{"/api/cameras/cam1/snapshot", routeReached},
+		{"/api/cameras/cam1/ws", routeReached},
+		{"/api/timelapse/video/" + timelapse.VideoFilename("cam1", today), routeReached},
+
+		// 他のカメラは存在しないものとして扱う
+		{"/api/cameras/cam2", http.StatusNotFound},
+		{"/api/cameras/cam2/stream", http.StatusNotFound},
+		{"/api/cameras/cam2/snapshot", http.StatusNotFound},
+		{"/api/cameras/cam2/ws", http.StatusNotFound},
+		{"/api/cameras/cam2/stats", http.StatusNotFound},
+
+		// 他のカメラ・全てのカメラを含む動画とメトリクスは利用できない
+		{"/api/timelapse/video/" + timelapse.VideoFilename("cam2", today), http.StatusForbidden},
+		{"/api/timelapse/video/" + timelapse.VideoFilename("", today), http.StatusForbidden},
+		{"/api/timelapse/video/unknown.mp4", http.StatusForbidden},
+		{"/metrics", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		if got := serve(router, "GET", tt.path, tokens.restricted); got != tt.want {
+			t.Errorf("GET %s: status = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+
+	// 全てのカメラを閲覧できる利用者は他のカメラの動画も取得できる
+	if got := serve(router, "GET", "/api/timelapse/video/"+timelapse.VideoFilename("", today), tokens.viewer); got != routeReached {
+		t.Errorf("Expected viewer to reach the combined video, got %d", got)
+	}
+}
+
+func TestRouteTables(t *testing.T) {
+	cfg, _ := newTestAuthConfig(t)
+	router := newTestRouter(t, cfg)
+
+	routes := make(map[string]bool)
+	for _, route := range router.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	// 権限の表は登録されたルートを指している（パスの誤りで権限の確認が漏れない）
+	for route := range routeRoles {
+		if !routes[route] {
+			t.Errorf("routeRoles entry %q does not match any registered route", route)
+		}
+	}
+	for path := range publicPaths {
+		if !routes["GET "+path] && !routes["POST "+path] {
+			t.Errorf("publicPaths entry %q does not match any registered route", path)
+		}
+	}
+
+	// 変更を伴うルートは公開パスを除き viewer より上の権限が必要
+	for _, route := range router.Routes() {
+		if route.Method == "GET" || route.Method == "HEAD" || publicPaths[route.Path] {
+			continue
+		}
+		if _, ok := routeRoles[route.Method+" "+route.Path]; !ok {
+			t.Errorf("Route %s %s is not listed in routeRoles", route.Method, route.Path)
+		}
+	}
+}
+
+func TestOriginPolicy_AllowsRequest(t *testing.T) {
+	newRequest := func(host, origin string) *http.Request {
+		request := httptest.NewRequest("GET", "http://"+host+"/api/cameras/cam1/ws", nil)
+		if origin != "" {
+			request.Header.Set("Origin", origin)
+		}
+		return request
+	}
+
+	tests := []struct {
+		name        string
+		authEnabled bool
+		allowed     []string
+		origin      string
+		want        bool
+	}{
+		{"no origin", true, nil, "", true},
+		{"same origin", true, nil, "http://senrigan.local:8080", true},
+		{"cross origin with auth", true, nil, "http://evil.example", false},
+		{"allowed origin with auth", true, []string{"http://localhost:3000/"}, "http://localhost:3000", true},
+		{"other origin with allowed list", true, []string{"http://localhost:3000"}, "http://evil.example", false},
+		{"cross origin without auth", false, nil, "http://evil.example", true},
+		{"other origin with allowed list without auth", false, []string{"http://localhost:3000"}, "http://evil.example", false},
+	}
+
+	for _, tt := range tests {
+		cfg := &config.Config{
+			Server: config.ServerConfig{AllowedOrigins: tt.allowed},
+			Auth:   auth.Config{Enabled: tt.authEnabled},
+		}
+		policy := newOriginPolicy(cfg)
+		if got := policy.allowsRequest(newRequest("senrigan.local:8080", tt.origin)); got != tt.want {
+			t.Errorf("%s: allowsRequest = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	cfg, tokens := newTestAuthConfig(t)
+	cfg.Server.AllowedOrigins = []string{"http://localhost:3000"}
+	router := newTestRouter(t, cfg)
+
+	for _, tt := range []struct {
+		origin string
+		want   string
+	}{
+		{"http://localhost:3000", "http://localhost:3000"},
+		{"http://evil.example", ""},
+	} {
+		request := httptest.NewRequest("GET", "/api/cameras", nil)
+		request.Header.Set("Origin", tt.origin)
+		request.Header.Set("Authorization", "Bearer "+tokens.viewer)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != tt.want {
+			t.Errorf("Origin %s: Access-Control-Allow-Origin = %q, want %q", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestStreamWebSocket_RefusesCrossOrigin(t *testing.T) {
+	cfg, _ := newTestAuthConfig(t)
+	handler := &SenriganHandler{origins: newOriginPolicy(cfg)}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		handler.streamWebSocket(c, nil) // オリジンの確認で拒否されるため映像ソースは使われない
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	header := http.Header{"Origin": []string{"http://evil.example"}}
+	conn, response, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", header)
+	if err == nil {
+		_ = conn.Close()
+		t.Fatal("Expected cross-origin WebSocket upgrade to be refused")
+	}
+	if response == nil || response.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 response, got %v", response)
+	}
+}
@@ -2,18 +2,28 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
+	"senrigan/internal/auth"
 	"senrigan/internal/camera"
+	"senrigan/internal/certificate"
 	"senrigan/internal/config"
 	"senrigan/internal/generated"
+	"senrigan/internal/metrics"
+	"senrigan/internal/motion"
+	"senrigan/internal/recorder"
 	"senrigan/internal/timelapse"
 
 	"github.com/gin-gonic/gin"
@@ -23,9 +33,15 @@ import (
 type GinServer struct {
 	config           *config.Config
 	httpServer       *http.Server
+	redirectServer   *http.Server // HTTPからHTTPSへのリダイレクト用（無効の場合はnil）
 	router           *gin.Engine
 	cameraManager    camera.Manager
 	timelapseManager timelapse.Manager
+	recorderManager  recorder.Manager
+	motionManager    motion.Manager
+	metrics          *metrics.Registry
+	authManager      auth.Manager
+	origins          *originPolicy
 }
 
 // NewGin は新しいGinServerインスタンスを作成する
@@ -39,33 +55,67 @@ func NewGin(cfg *config.Config) *GinServer {
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 
-	// CORS設定（開発環境用に緩和）
-	router.Use(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	// CORS設定（許可するオリジンは設定ファイルで指定）
+	origins := newOriginPolicy(cfg)
+	router.Use(corsMiddleware(origins))
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-
-		c.Next()
-	})
+	// 認証と権限の確認（認証が無効の場合は全てのリクエストを許可）
+	// HTTPSで配信する場合はセッションCookieをHTTPSのみで送信する
+	authConfig := cfg.Auth
+	if cfg.Server.TLS.Enabled() {
+		authConfig.CookieSecure = true
+	}
+	authManager := auth.NewDefaultManager(authConfig)
+	router.Use(authMiddleware(authManager))
+	if !authManager.Enabled() {
+		log.Println("認証が無効です。ネットワーク上の誰でも全ての操作を行えます")
+	}
 
 	// カメラマネージャーを初期化
 	discovery := camera.NewLinuxDiscovery()
 	cameraManager := camera.NewDefaultCameraManager(discovery)
+	cameraManager.SetDefaultSettings(cfg.Camera.DefaultSettings())
+	cameraManager.SetDeviceConfigs(cfg.Camera.SourceConfigs())
 
 	// タイムラプスマネージャーを初期化
 	timelapseOutputDir := "./data/timelapse" // ローカルディレクトリに変更
 	timelapseManager := timelapse.NewDefaultManager(cameraManager, timelapseOutputDir, cfg.Timelapse)
 
+	// 連続録画マネージャーを初期化
+	recorderManager := recorder.NewDefaultManager(cameraManager, cfg.Recording, cfg.Camera.RecordingOptions())
+
+	// 動体検知マネージャーを初期化
+	motionManager := motion.NewDefaultManager(cameraManager, cfg.Motion, cfg.Camera.MotionOptions())
+
+	// 動きの継続中はイベント録画をトリガーし続ける（重なったトリガーは同じクリップを延長する）
+	motionManager.OnEvent(func(event motion.Event) {
+		if !event.InProgress {
+			return
+		}
+		if _, err := recorderManager.Trigger(event.SourceID, recorder.TriggerMotion); err != nil && !errors.Is(err, recorder.ErrTriggerDisabled) {
+			log.Printf("映像ソース %s の動体検知による録画に失敗: %v", event.SourceID, err)
+		}
+	})
+
+	// メトリクスは収集時に各マネージャーから状態を読み取る
+	metricsRegistry := metrics.NewRegistry(metrics.NewCollector(cameraManager, timelapseManager, timelapseOutputDir))
+
+	var redirectServer *http.Server
+	if cfg.Server.TLS.Enabled() && cfg.Server.TLS.RedirectPort != 0 {
+		redirectServer = newRedirectServer(cfg.RedirectAddress(), cfg.Server.Port)
+	}
+
 	return &GinServer{
 		config:           cfg,
+		redirectServer:   redirectServer,
 		router:           router,
 		cameraManager:    cameraManager,
 		timelapseManager: timelapseManager,
+		recorderManager:  recorderManager,
+		motionManager:    motionManager,
+		metrics:          metricsRegistry,
+		authManager:      authManager,
+		origins:          origins,
 		httpServer: &http.Server{
 			Addr:         cfg.ServerAddress(),
 			Handler:      router,
@@ -77,6 +127,13 @@ func NewGin(cfg *config.Config) *GinServer {
 
 // Start はサーバーを起動する
 func (s *GinServer) Start(ctx context.Context) error {
+	// HTTPSの証明書を準備（カメラ等を起動する前に設定の誤りを検出する）
+	if s.config.Server.TLS.Enabled() {
+		if err := s.configureTLS(); err != nil {
+			return fmt.Errorf("証明書の準備に失敗: %w", err)
+		}
+	}
+
 	// カメラマネージャーを開始
 	if err := s.cameraManager.Start(ctx); err != nil {
 		return fmt.Errorf("カメラマネージャーの起動に失敗: %w", err)
@@ -88,20 +145,49 @@ func (s *GinServer) Start(ctx context.Context) error {
 		// タイムラプスはオプション機能なので失敗してもサーバー起動を続行
 	}
 
+	// 連続録画マネージャーを開始
+	if err := s.recorderManager.Start(ctx); err != nil {
+		log.Printf("連続録画マネージャーの起動に失敗: %v", err)
+		// 録画はオプション機能なので失敗してもサーバー起動を続行
+	}
+
+	// 動体検知マネージャーを開始
+	if err := s.motionManager.Start(ctx); err != nil {
+		log.Printf("動体検知マネージャーの起動に失敗: %v", err)
+		// 動体検知はオプション機能なので失敗してもサーバー起動を続行
+	}
+
 	// ルートを設定
 	s.setupRoutes()
 
 	// シャットダウン用のチャンネル
-	shutdownCh := make(chan error, 1)
+	shutdownCh := make(chan error, 2)
 
 	// サーバーを別ゴルーチンで起動
 	go func() {
-		log.Printf("Gin HTTPサーバーを起動しています: %s", s.config.ServerAddress())
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.httpServer.TLSConfig != nil {
+			log.Printf("Gin HTTPSサーバーを起動しています: %s", s.config.ServerAddress())
+			err = s.httpServer.ListenAndServeTLS("", "")
+		} else {
+			log.Printf("Gin HTTPサーバーを起動しています: %s", s.config.ServerAddress())
+			err = s.httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			shutdownCh <- fmt.Errorf("サーバーの起動に失敗: %w", err)
 		}
 	}()
 
+	// HTTPからHTTPSへのリダイレクト用サーバーを起動
+	if s.redirectServer != nil {
+		go func() {
+			log.Printf("HTTPSへのリダイレクト用サーバーを起動しています: %s", s.redirectServer.Addr)
+			if err := s.redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				shutdownCh <- fmt.Errorf("リダイレクト用サーバーの起動に失敗: %w", err)
+			}
+		}()
+	}
+
 	// シグナルハンドリング
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -135,6 +221,22 @@ func (s *GinServer) Shutdown() error {
 		log.Println("タイムラプスマネージャーを停止しました")
 	}
 
+	// 動体検知マネージャーを停止（継続中のイベントを終了して保存する）
+	log.Println("動体検知マネージャーを停止中...")
+	if err := s.motionManager.Stop(ctx); err != nil {
+		log.Printf("動体検知マネージャーの停止に失敗: %v", err)
+	} else {
+		log.Println("動体検知マネージャーを停止しました")
+	}
+
+	// 連続録画マネージャーを停止（カメラ停止前に書き込み中のセグメントを確定する）
+	log.Println("連続録画マネージャーを停止中...")
+	if err := s.recorderManager.Stop(ctx); err != nil {
+		log.Printf("連続録画マネージャーの停止に失敗: %v", err)
+	} else {
+		log.Println("連続録画マネージャーを停止しました")
+	}
+
 	// カメラマネージャーを停止
 	log.Println("カメラマネージャーを停止中...")
 	if err := s.cameraManager.Stop(ctx); err != nil {
@@ -143,6 +245,13 @@ func (s *GinServer) Shutdown() error {
 		log.Println("カメラマネージャーを停止しました")
 	}
 
+	// リダイレクト用サーバーをシャットダウン
+	if s.redirectServer != nil {
+		if err := s.redirectServer.Shutdown(ctx); err != nil {
+			log.Printf("リダイレクト用サーバーの停止に失敗: %v", err)
+		}
+	}
+
 	// HTTPサーバーをシャットダウン
 	log.Println("HTTPサーバーを停止中...")
 	if err := s.httpServer.Shutdown(ctx); err != nil {
@@ -153,6 +262,58 @@ func (s *GinServer) Shutdown() error {
 	return nil
 }
 
+// configureTLS は設定に応じて証明書を読み込み（自己署名の場合は生成し）、HTTPサーバーに設定する
+func (s *GinServer) configureTLS() error {
+	tlsConfig := s.config.Server.TLS
+	certFile, keyFile := tlsConfig.Files()
+
+	var cert tls.Certificate
+	var err error
+	switch tlsConfig.Mode {
+	case config.TLSModeFile:
+		cert, err = certificate.Load(certFile, keyFile)
+	case config.TLSModeSelfSigned:
+		hosts := append(certificate.DetectHosts(), tlsConfig.Hosts...)
+		cert, err = certificate.LoadOrCreateSelfSigned(certFile, keyFile, hosts)
+		if err == nil {
+			log.Printf("自己署名証明書を使用します（SHA-256 フィンガープリント: %s）", certificate.Fingerprint(cert))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	s.httpServer.TLSConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	return nil
+}
+
+// newRedirectServer はHTTPのリクエストを同じホストのHTTPSにリダイレクトするサーバーを作成する
+func newRedirectServer(addr string, httpsPort int) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host := r.Host
+			if h, _, err := net.SplitHostPort(r.Host); err == nil {
+				host = h
+			}
+			host = strings.Trim(host, "[]")
+			if httpsPort != 443 {
+				host = net.JoinHostPort(host, strconv.Itoa(httpsPort))
+			} else if strings.Contains(host, ":") {
+				host = "[" + host + "]" // IPv6アドレス
+			}
+
+			target := url.URL{Scheme: "https", Host: host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+			// 設定でHTTPSを無効に戻せるよう、ブラウザにキャッシュされる恒久的なリダイレクトは使わない
+			http.Redirect(w, r, target.String(), http.StatusTemporaryRedirect)
+		}),
+	}
+}
+
 // setupRoutes はHTTPルートを設定する
 func (s *GinServer) setupRoutes() {
 	// ServerInterfaceを実装したハンドラーを作成
@@ -160,11 +321,19 @@ func (s *GinServer) setupRoutes() {
 		config:           s.config,
 		cameraManager:    s.cameraManager,
 		timelapseManager: s.timelapseManager,
+		motionManager:    s.motionManager,
+		recorderManager:  s.recorderManager,
+		metrics:          s.metrics,
+		authManager:      s.authManager,
+		origins:          s.origins,
 	}
 
 	// 生成されたルートを登録（OpenAPI仕様に基づく）
 	generated.RegisterHandlers(s.router, handler)
 
+	// Prometheusのメトリクスを公開
+	s.router.GET("/metrics", gin.WrapH(s.metrics.Handler()))
+
 	// フロントエンドの静的ファイルを配信（embed）
 	s.router.StaticFS("/assets", GetAssetsFS())
 	s.router.GET("/favicon.ico", func(c *gin.Context) {
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"senrigan/internal/camera"
+	"senrigan/internal/generated"
+	"senrigan/internal/imaging"
+	"senrigan/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsSubscriberBufferSize はWebSocketクライアント毎のフレームバッファサイズ
+	wsSubscriberBufferSize = 2
+
+	// wsWriteTimeout は1メッセージの書き込みタイムアウト
+	wsWriteTimeout = 10 * time.Second
+
+	// wsPingInterval はサーバーからpingを送信する間隔
+	wsPingInterval = 30 * time.Second
+
+	// wsPongTimeout はクライアントからの応答がない場合に切断するまでの時間
+	wsPongTimeout = 60 * time.Second
+
+	// wsMaxControlMessageSize は制御メッセージの最大サイズ
+	wsMaxControlMessageSize = 4096
+)
+
+// wsUpgrader はHTTP接続をWebSocketにアップグレードする
+// オリジンの確認（CheckOrigin）は接続ごとにCORSと同じ設定で行う
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 64 * 1024,
+}
+
+// wsSession は1つのWebSocket接続の配信状態
+type wsSession struct {
+	conn     *websocket.Conn
+	source   camera.VideoSource
+	cameraID string
+
+	// クライアントが制御する配信設定
+	mu        sync.Mutex
+	paused    bool
+	maxFPS    int
+	maxWidth  int
+	maxHeight int
+
+	// 書き込みは1つのゴルーチンからのみ行う必要があるため直列化する
+	writeMu sync.Mutex
+}
+
+// streamWebSocket はWebSocketでフレームを配信する
+func (h *SenriganHandler) streamWebSocket(c *gin.Context, source camera.VideoSource) {
+	upgrader := wsUpgrader
+	upgrader.CheckOrigin = h.origins.allowsRequest
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade がエラーレスポンスを書き込み済み
+		log.Printf("WebSocketへのアップグレードに失敗: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	session := &wsSession{
+		conn:     conn,
+		source:   source,
+		cameraID: source.GetInfo().ID,
+	}
+	defer h.metrics.StreamClientConnected(session.cameraID, metrics.ProtocolWebSocket)()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	// 制御メッセージの受信（切断を検知したら配信も終了する）
+	go func() {
+		defer cancel()
+		session.readLoop()
+	}()
+
+	if err := session.writeJSON(session.settingsMessage(generated.StreamServerMessageTypeHello)); err != nil {
+		return
+	}
+	session.writeLoop(ctx)
+}
+
+// readLoop はクライアントからの制御メッセージを処理する
+func (s *wsSession) readLoop() {
+	s.conn.SetReadLimit(wsMaxControlMessageSize)
+	_ = s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		var message generated.StreamControlMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			_ = s.writeError("制御メッセージの解析に失敗しました")
+			continue
+		}
+
+		if err := s.handleControl(message); err != nil {
+			return
+		}
+	}
+}
+
+// handleControl は制御メッセージを配信設定に反映して応答する
+func (s *wsSession) handleControl(message generated.StreamControlMessage) error {
+	switch message.Type {
+	case generated.Pause, generated.Resume:
+		s.mu.Lock()
+		s.paused = message.Type == generated.Pause
+		s.mu.Unlock()
+
+	case generated.SetFps:
+		if message.Fps == nil || *message.Fps < 0 {
+			return s.writeError("fps には0以上の値を指定してください")
+		}
+		s.mu.Lock()
+		s.maxFPS = *message.Fps
+		s.mu.Unlock()
+
+	case generated.SetResolution:
+		width, height := 0, 0
+		if message.Width != nil {
+			width = *message.Width
+		}
+		if message.Height != nil {
+			height = *message.Height
+		}
+		if width < 0 || height < 0 {
+			return s.writeError("width / height には0以上の値を指定してください")
+		}
+		s.mu.Lock()
+		s.maxWidth, s.maxHeight = width, height
+		s.mu.Unlock()
+
+	case generated.Ping:
+		now := time.Now()
+		return s.writeJSON(generated.StreamServerMessage{
+			Type:      generated.StreamServerMessageTypePong,
+			Timestamp: &now,
+		})
+
+	default:
+		return s.writeError(fmt.Sprintf("不明な制御メッセージです: %s", message.Type))
+	}
+
+	return s.writeJSON(s.settingsMessage(generated.StreamServerMessageTypeAck))
+}
+
+// writeLoop は購読したフレームを配信設定に従って送信する
+func (s *wsSession) writeLoop(ctx context.Context) {
+	subscription := s.source.Subscribe(wsSubscriberBufferSize, camera.DropOldest)
+	defer subscription.Unsubscribe()
+
+	pingTicker := time.NewTicker(wsPingInterval)
+	defer pingTicker.Stop()
+
+	var lastSent time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-pingTicker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+
+		case frame, ok := <-subscription.Frames():
+			if !ok {
+				// ソースが停止した
+				_ = s.conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "source stopped"),
+					time.Now().Add(wsWriteTimeout))
+				return
+			}
+
+			s.mu.Lock()
+			paused, maxFPS, maxWidth, maxHeight := s.paused, s.maxFPS, s.maxWidth, s.maxHeight
+			s.mu.Unlock()
+
+			if paused {
+				continue
+			}
+			if maxFPS > 0 && frame.Timestamp.Sub(lastSent) < time.Second/time.Duration(maxFPS) {
+				continue
+			}
+
+			if err := s.writeFrame(frame, maxWidth, maxHeight); err != nil {
+				return
+			}
+			lastSent = frame.Timestamp
+		}
+	}
+}
+
+// writeFrame はフレームのメタデータとJPEG画像を続けて送信する
+func (s *wsSession) writeFrame(frame camera.Frame, maxWidth, maxHeight int) error {
+	data := frame.Data
+	if maxWidth > 0 || maxHeight > 0 {
+		resized, err := imaging.ResizeJPEG(frame.Data, maxWidth, maxHeight, 0)
+		if err != nil {
+			log.Printf("WebSocket配信用のフレーム縮小に失敗: %v", err)
+		} else {
+			data = resized
+		}
+	}
+
+	message := generated.StreamServerMessage{
+		Type:      generated.StreamServerMessageTypeFrame,
+		Sequence:  int64Ptr(int64(frame.Sequence)),
+		Timestamp: &frame.Timestamp,
+		Size:      intPtr(len(data)),
+	}
+	if width, height, err := imaging.JPEGSize(data); err == nil {
+		message.Width = &width
+		message.Height = &height
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := s.conn.WriteJSON(message); err != nil {
+		return err
+	}
+	return s.conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// settingsMessage は現在の配信設定を表すメッセージを作成する
+func (s *wsSession) settingsMessage(messageType generated.StreamServerMessageType) generated.StreamServerMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return generated.StreamServerMessage{
+		Type:     messageType,
+		CameraId: stringPtr(s.cameraID),
+		Paused:   boolPtr(s.paused),
+		Fps:      intPtr(s.maxFPS),
+		Width:    intPtr(s.maxWidth),
+		Height:   intPtr(s.maxHeight),
+	}
+}
+
+// writeError はエラーメッセージを送信する
+func (s *wsSession) writeError(message string) error {
+	return s.writeJSON(generated.StreamServerMessage{
+		Type:    generated.StreamServerMessageTypeError,
+		Message: &message,
+	})
+}
+
+// writeJSON はテキストメッセージを送信する
+func (s *wsSession) writeJSON(message generated.StreamServerMessage) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return s.conn.WriteJSON(message)
+}
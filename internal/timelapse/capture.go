@@ -2,10 +2,12 @@ package timelapse
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,17 +16,22 @@ import (
 
 // Capture は統合タイムラプスキャプチャを管理する
 type Capture struct {
-	frameBuffer  []CombinedFrame      // 結合フレーム保存
-	outputDir    string               // 動画出力先
-	currentVideo string               // 現在の動画ファイル
-	lastUpdate   time.Time            // 最後の動画更新時刻
-	config       Config               // 設定
-	videoSources []camera.VideoSource // 全ての映像ソース
+	frameStore     *FrameStore          // 撮影したフレームの保存先
+	pendingFrames  int                  // 動画に追加していないフレーム数
+	completedDates map[string]bool      // 全てのフレームを動画に追加済みの日付（updateMu で保護）
+	outputDir      string               // 動画出力先
+	currentVideo   string               // 現在の動画ファイル（結合した動画）
+	currentDate    time.Time            // 現在の動画の日付
+	lastUpdate     time.Time            // 最後の動画更新時刻
+	config         Config               // 設定
+	videoSources   []camera.VideoSource // 全ての映像ソース
 
 	// 制御用
-	stopCh chan struct{}
-	wg     sync.WaitGroup
-	mu     sync.RWMutex
+	stopCh   chan struct{}
+	updateCh chan struct{} // 更新間隔を待たずに動画の更新を要求する
+	wg       sync.WaitGroup
+	mu       sync.RWMutex
+	updateMu sync.Mutex // 動画の更新を直列化する
 
 	// フレーム結合・動画生成用
 	frameComposer  *FrameComposer
@@ -33,13 +40,21 @@ type Capture struct {
 
 // NewCapture は新しいCapture を作成する
 func NewCapture(outputDir string, config Config, videoSources []camera.VideoSource) *Capture {
+	frameComposer := NewFrameComposer(config)
+	if layout, err := config.LayoutByName(config.Layout); err != nil {
+		log.Printf("レイアウトの取得に失敗したため自動の格子を使用します: %v", err)
+	} else {
+		frameComposer.SetLayout(layout)
+	}
+
 	return &Capture{
-		frameBuffer:    make([]CombinedFrame, 0, config.MaxFrameBuffer),
+		completedDates: make(map[string]bool),
 		outputDir:      outputDir,
 		config:         config,
 		videoSources:   videoSources,
 		stopCh:         make(chan struct{}),
-		frameComposer:  NewFrameComposer(config.Resolution.Width, config.Resolution.Height, config.Quality),
+		updateCh:       make(chan struct{}, 1),
+		frameComposer:  frameComposer,
 		videoGenerator: NewVideoGenerator(),
 	}
 }
@@ -54,6 +69,13 @@ func (tc *Capture) Start(ctx context.Context) error {
 		return fmt.Errorf("出力ディレクトリの作成に失敗: %w", err)
 	}
 
+	// フレームの保存先を開く（前回までに動画に追加していないフレームは最初の更新で追加する）
+	frameStore, err := OpenFrameStore(FramesDir(tc.outputDir), tc.config.RetentionDays, tc.config.MaxFrameStorageBytes())
+	if err != nil {
+		return err
+	}
+	tc.frameStore = frameStore
+
 	// フレーム撮影を開始
 	tc.wg.Add(1)
 	go tc.captureFrames(ctx)
@@ -82,9 +104,9 @@ func (tc *Capture) Stop(ctx context.Context) error {
 
 	select {
 	case <-done:
-		// 正常にワーカーが終了した場合のみログを記録（最終動画更新はスキップ）
-		if len(tc.frameBuffer) > 0 {
-			log.Printf("シャットダウン時に %d フレームのバッファが残りました。", len(tc.frameBuffer))
+		// 正常にワーカーが終了した場合のみログを記録（フレームは保存済みのため最終動画更新はスキップ）
+		if tc.pendingFrames > 0 {
+			log.Printf("動画に追加していない %d フレームは次回起動時に追加します", tc.pendingFrames)
 		}
 	case <-time.After(3 * time.Second):
 		log.Printf("ワーカーゴルーチンの停止がタイムアウトしました。強制終了します。")
@@ -117,24 +139,37 @@ func (tc *Capture) captureFrames(ctx context.Context) {
 	}
 }
 
-// captureFrame は1つの結合フレームをキャプチャしてバッファに追加する
+// captureFrame は1つの結合フレームをキャプチャしてディスクに保存する
+// 結合した動画を出力しない場合は、各映像ソースのフレームのみを取得する
 func (tc *Capture) captureFrame(ctx context.Context) error {
-	// 全映像ソースから結合フレームを作成
-	combinedFrame, err := tc.frameComposer.ComposeFrames(ctx, tc.videoSources)
+	var combinedFrame CombinedFrame
+	var err error
+	if tc.GetConfig().CombinedEnabled() {
+		// 全映像ソースから結合フレームを作成
+		combinedFrame, err = tc.frameComposer.ComposeFrames(ctx, tc.videoSources)
+	} else {
+		combinedFrame, err = tc.frameComposer.CaptureFrames(ctx, tc.videoSources)
+	}
 	if err != nil {
 		return fmt.Errorf("フレーム結合に失敗: %w", err)
 	}
+	tc.frameComposer.DrawSourceOverlays(&combinedFrame, tc.videoSources)
 
-	tc.mu.Lock()
-	defer tc.mu.Unlock()
+	if err := tc.frameStore.Save(combinedFrame); err != nil {
+		return fmt.Errorf("フレームの保存に失敗: %w", err)
+	}
 
-	// フレームをバッファに追加
-	tc.frameBuffer = append(tc.frameBuffer, combinedFrame)
+	tc.mu.Lock()
+	tc.pendingFrames++
+	full := tc.config.MaxFrameBuffer > 0 && tc.pendingFrames >= tc.config.MaxFrameBuffer
+	tc.mu.Unlock()
 
-	// バッファサイズ制限をチェック
-	if len(tc.frameBuffer) > tc.config.MaxFrameBuffer {
-		// 古いフレームを削除（FIFO）
-		tc.frameBuffer = tc.frameBuffer[1:]
+	// 動画に追加していないフレームが上限に達した場合は更新間隔を待たずに動画を更新する
+	if full {
+		select {
+		case tc.updateCh <- struct{}{}:
+		default:
+		}
 	}
 
 	return nil
@@ -162,6 +197,10 @@ func (tc *Capture) videoUpdateScheduler(ctx context.Context) {
 			if err := tc.updateVideo(); err != nil {
 				log.Printf("動画更新エラー: %v", err)
 			}
+		case <-tc.updateCh:
+			if err := tc.updateVideo(); err != nil {
+				log.Printf("動画更新エラー: %v", err)
+			}
 		case <-midnightTimer.C:
 			// 日次ローテーション
 			if err := tc.rotateVideo(); err != nil {
@@ -174,57 +213,188 @@ func (tc *Capture) videoUpdateScheduler(ctx context.Context) {
 	}
 }
 
-// updateVideo は現在のフレームバッファから動画を更新する
+// updateVideo は保存済みのフレームのうち動画に追加していないものを動画に追加する
+// 前回の起動時に追加できなかった日付のフレームも追加する
 func (tc *Capture) updateVideo() error {
+	tc.updateMu.Lock()
+	defer tc.updateMu.Unlock()
+
+	tc.mu.Lock()
+	if tc.currentVideo == "" {
+		tc.setCurrentDate(time.Now())
+	}
+	config := tc.config
+	processed := tc.pendingFrames
+	tc.mu.Unlock()
+
+	dates, err := tc.frameStore.Dates()
+	if err != nil {
+		return err
+	}
+
+	// 前日のフレームは日付が変わった直後に保存される場合があるため、2日以上前の日付のみ完了とする
+	now := time.Now()
+	completedBefore := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, now.Location())
+
+	var errs []error
+	for _, date := range dates {
+		key := date.Format(time.DateOnly)
+		if tc.completedDates[key] {
+			continue
+		}
+		dateErrs := tc.updateVideosOf(date, config)
+		if len(dateErrs) == 0 && date.Before(completedBefore) {
+			tc.completedDates[key] = true
+		}
+		errs = append(errs, dateErrs...)
+	}
+
+	if err := tc.frameStore.Prune(now); err != nil {
+		errs = append(errs, err)
+	}
+
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
+	if len(errs) == 0 {
+		tc.pendingFrames = max(tc.pendingFrames-processed, 0)
+	}
+	tc.lastUpdate = time.Now()
 
-	if len(tc.frameBuffer) == 0 {
-		return nil // フレームがない場合はスキップ
+	return errors.Join(errs...)
+}
+
+// updateVideosOf は指定した日付の動画（結合した動画・映像ソース毎の動画）を更新する
+func (tc *Capture) updateVideosOf(date time.Time, config Config) []error {
+	var errs []error
+
+	if config.CombinedEnabled() {
+		if err := tc.extendVideo("", date, config); err != nil {
+			errs = append(errs, fmt.Errorf("動画の延長に失敗: %w", err))
+		}
 	}
 
-	// 現在の動画ファイル名を決定
-	if tc.currentVideo == "" {
-		tc.currentVideo = tc.generateVideoFilename(time.Now())
+	if config.PerSourceEnabled() {
+		sources, err := tc.frameStore.Sources(date)
+		if err != nil {
+			return append(errs, err)
+		}
+		// 一部の映像ソースで失敗しても他の映像ソースの動画は更新する
+		for _, sourceID := range sources {
+			if err := tc.extendVideo(sourceID, date, config); err != nil {
+				errs = append(errs, fmt.Errorf("映像ソース %s の動画の延長に失敗: %w", sourceID, err))
+			}
+		}
 	}
 
-	videoPath := filepath.Join(tc.outputDir, tc.currentVideo)
+	return errs
+}
 
-	// 動画を生成または延長
-	if err := tc.videoGenerator.ExtendVideo(videoPath, tc.frameBuffer, tc.config); err != nil {
-		return fmt.Errorf("動画の延長に失敗: %w", err)
+// extendVideo は動画に追加していない保存済みのフレームを動画に追加する
+// 動画ファイルがない場合は保存済みの全てのフレームから作成する（削除した動画を別の設定で作り直せる）
+func (tc *Capture) extendVideo(sourceID string, date time.Time, config Config) error {
+	videoPath := filepath.Join(tc.outputDir, VideoFilename(sourceID, date))
+
+	var after time.Time
+	_, err := os.Stat(videoPath)
+	newVideo := err != nil
+	if !newVideo {
+		after, err = tc.frameStore.Encoded(sourceID, date)
+		if err != nil {
+			return err
+		}
 	}
 
-	// フレームバッファをクリア
-	tc.frameBuffer = tc.frameBuffer[:0]
-	tc.lastUpdate = time.Now()
+	frames, err := tc.frameStore.Frames(sourceID, date, after)
+	if err != nil {
+		return err
+	}
+	if len(frames) == 0 {
+		return nil
+	}
 
-	return nil
+	if err := tc.videoGenerator.ExtendVideo(videoPath, frames, config); err != nil {
+		return err
+	}
+	// 動画は延長済みのため、撮影時刻の記録に失敗してもフレームを再び追加しない
+	if err := writeVideoIndex(videoPath, frames, newVideo); err != nil {
+		log.Printf("動画 %s の撮影時刻の記録に失敗: %v", filepath.Base(videoPath), err)
+	}
+	return tc.frameStore.SetEncoded(sourceID, date, frames[len(frames)-1].Timestamp)
 }
 
 // rotateVideo は日次ローテーションを実行する
 func (tc *Capture) rotateVideo() error {
-	tc.mu.Lock()
-	defer tc.mu.Unlock()
-
-	// 最終更新を実行
-	if len(tc.frameBuffer) > 0 {
-		if err := tc.updateVideo(); err != nil {
-			log.Printf("ローテーション前の最終更新に失敗: %v", err)
-		}
+	// 前日の残りのフレームを動画に追加
+	if err := tc.updateVideo(); err != nil {
+		log.Printf("ローテーション前の最終更新に失敗: %v", err)
 	}
 
 	// 新しい動画ファイル名を設定
-	tc.currentVideo = tc.generateVideoFilename(time.Now())
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.setCurrentDate(time.Now())
 
 	log.Printf("日次ローテーション実行: %s", tc.currentVideo)
 	return nil
 }
 
-// generateVideoFilename は動画ファイル名を生成する
-func (tc *Capture) generateVideoFilename(t time.Time) string {
-	dateStr := t.Format("2006-01-02")
-	return fmt.Sprintf("timelapse_%s.mp4", dateStr)
+// setCurrentDate は現在の動画の日付とファイル名を設定する
+func (tc *Capture) setCurrentDate(t time.Time) {
+	tc.currentDate = t
+	tc.currentVideo = VideoFilename("", t)
+}
+
+// videoFilePrefix はタイムラプス動画ファイル名の接頭辞
+const videoFilePrefix = "timelapse_"
+
+// VideoFilename は動画ファイル名を生成する
+// 結合した動画（sourceID が空文字）は timelapse_YYYY-MM-DD.mp4、
+// 映像ソース毎の動画は timelapse_<映像ソースID>_YYYY-MM-DD.mp4 とする
+func VideoFilename(sourceID string, date time.Time) string {
+	dateStr := date.Format(time.DateOnly)
+	if sourceID == "" {
+		return fmt.Sprintf("%s%s.mp4", videoFilePrefix, dateStr)
+	}
+	return fmt.Sprintf("%s%s_%s.mp4", videoFilePrefix, filenameID(sourceID), dateStr)
+}
+
+// ParseVideoFilename は動画ファイル名から映像ソースIDと日付を取得する
+// 結合した動画の映像ソースIDは空文字で、ファイル名に使えない文字は "_" に置き換えられている
+func ParseVideoFilename(name string) (sourceID string, date time.Time, ok bool) {
+	base, found := strings.CutPrefix(name, videoFilePrefix)
+	if !found {
+		return "", time.Time{}, false
+	}
+	base, found = strings.CutSuffix(base, ".mp4")
+	if !found || len(base) < len(time.DateOnly) {
+		return "", time.Time{}, false
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, base[len(base)-len(time.DateOnly):], time.Local)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	rest := base[:len(base)-len(time.DateOnly)]
+	if rest == "" {
+		return "", date, true
+	}
+	sourceID, found = strings.CutSuffix(rest, "_")
+	if !found || sourceID == "" || filenameID(sourceID) != sourceID {
+		return "", time.Time{}, false // "/" 等を含む名前は動画ファイルとして扱わない
+	}
+	return sourceID, date, true
+}
+
+// filenameID は映像ソースIDのファイル名に使えない文字を "_" に置き換える
+func filenameID(sourceID string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, sourceID)
 }
 
 // getNextMidnight は次の0時の時刻を取得する
@@ -235,12 +405,19 @@ func (tc *Capture) getNextMidnight() time.Time {
 }
 
 // GetVideos はこのキャプチャの動画一覧を取得する
-func (tc *Capture) GetVideos() ([]Video, error) {
+func (tc *Capture) GetVideos(filter VideoFilter) ([]Video, error) {
 	tc.mu.RLock()
 	defer tc.mu.RUnlock()
 
 	var videos []Video
 
+	// ファイル名の映像ソースIDを元の映像ソースIDに戻すための対応表
+	sourceIDs := make(map[string]string, len(tc.videoSources))
+	for _, source := range tc.videoSources {
+		id := source.GetInfo().ID
+		sourceIDs[filenameID(id)] = id
+	}
+
 	// 出力ディレクトリの動画ファイルを走査
 	entries, err := os.ReadDir(tc.outputDir)
 	if err != nil {
@@ -251,32 +428,80 @@ func (tc *Capture) GetVideos() ([]Video, error) {
 	}
 
 	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".mp4" {
-			videoPath := filepath.Join(tc.outputDir, entry.Name())
-			info, err := entry.Info()
-			if err != nil {
-				log.Printf("ファイル情報の取得に失敗: %v", err)
-				continue
-			}
+		if entry.IsDir() {
+			continue
+		}
+		// 生成途中の一時ファイル等、タイムラプス動画の名前でないファイルは含めない
+		sourceID, date, ok := ParseVideoFilename(entry.Name())
+		if !ok {
+			continue
+		}
+		if id, found := sourceIDs[sourceID]; found {
+			sourceID = id
+		}
+		if filter.SourceID != "" && filter.SourceID != sourceID {
+			continue
+		}
 
-			video := Video{
-				FilePath:    videoPath,
-				FileSize:    info.Size(),
-				Date:        info.ModTime(),
-				Status:      tc.determineVideoStatus(entry.Name()),
-				SourceCount: len(tc.videoSources),
-			}
+		info, err := entry.Info()
+		if err != nil {
+			log.Printf("ファイル情報の取得に失敗: %v", err)
+			continue
+		}
 
-			videos = append(videos, video)
+		video := Video{
+			FilePath:    filepath.Join(tc.outputDir, entry.Name()),
+			FileSize:    info.Size(),
+			Date:        info.ModTime(),
+			Status:      tc.determineVideoStatus(date),
+			SourceCount: len(tc.videoSources),
+			SourceID:    sourceID,
 		}
+		if sourceID != "" {
+			video.SourceCount = 1
+		}
+		if err := readVideoIndexInfo(&video); err != nil && !errors.Is(err, ErrVideoIndexNotFound) {
+			log.Printf("動画 %s の撮影時刻の取得に失敗: %v", entry.Name(), err)
+		}
+
+		videos = append(videos, video)
 	}
 
 	return videos, nil
 }
 
-// determineVideoStatus は動画ファイルのステータスを判定する
-func (tc *Capture) determineVideoStatus(filename string) Status {
-	if tc.currentVideo == filename {
+// readVideoIndexInfo は撮影時刻の記録から動画のフレーム数・長さ・撮影期間を設定する
+func readVideoIndexInfo(video *Video) error {
+	index, err := OpenVideoIndex(video.FilePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = index.Close()
+	}()
+
+	if index.FrameCount() == 0 {
+		return nil
+	}
+	start, err := index.Timestamp(0)
+	if err != nil {
+		return err
+	}
+	end, err := index.Timestamp(index.FrameCount() - 1)
+	if err != nil {
+		return err
+	}
+
+	video.FrameCount = index.FrameCount()
+	video.Duration = index.Duration()
+	video.StartTime = start
+	video.EndTime = end
+	return nil
+}
+
+// determineVideoStatus は動画の日付からステータスを判定する
+func (tc *Capture) determineVideoStatus(date time.Time) Status {
+	if tc.currentVideo != "" && date.Format(time.DateOnly) == tc.currentDate.Format(time.DateOnly) {
 		return StatusRecording
 	}
 	return StatusCompleted
@@ -291,6 +516,15 @@ func (tc *Capture) UpdateConfig(config Config) error {
 	return nil
 }
 
+// SetLayout は結合フレームのレイアウトを変更する（次に撮影するフレームから反映する）
+func (tc *Capture) SetLayout(name string, layout LayoutConfig) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	tc.config.Layout = name
+	tc.frameComposer.SetLayout(layout)
+}
+
 // GetConfig は現在の設定を取得する
 func (tc *Capture) GetConfig() Config {
 	tc.mu.RLock()
@@ -304,6 +538,7 @@ type CaptureStatus struct {
 	CurrentVideo    string
 	FrameBufferSize int
 	LastUpdate      time.Time
+	Encodes         EncodeStats
 }
 
 // GetStatus は現在の状態を取得する
@@ -313,7 +548,8 @@ func (tc *Capture) GetStatus() CaptureStatus {
 
 	return CaptureStatus{
 		CurrentVideo:    tc.currentVideo,
-		FrameBufferSize: len(tc.frameBuffer),
+		FrameBufferSize: tc.pendingFrames,
 		LastUpdate:      tc.lastUpdate,
+		Encodes:         tc.videoGenerator.Stats(),
 	}
 }
@@ -0,0 +1,87 @@
+package timelapse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVideoFilename(t *testing.T) {
+	date := time.Date(2024, 1, 2, 15, 4, 5, 0, time.Local)
+
+	tests := []struct {
+		sourceID string
+		want     string
+		parsedID string
+	}{
+		{"", "timelapse_2024-01-02.mp4", ""},
+		{"screen_0_0", "timelapse_screen_0_0_2024-01-02.mp4", "screen_0_0"},
+		{"usb-cam.1", "timelapse_usb-cam.1_2024-01-02.mp4", "usb-cam.1"},
+		{"玄関/cam", "timelapse____cam_2024-01-02.mp4", "___cam"},
+	}
+
+	for _, tt := range tests {
+		name := VideoFilename(tt.sourceID, date)
+		if name != tt.want {
+			t.Errorf("VideoFilename(%q) = %q, want %q", tt.sourceID, name, tt.want)
+			continue
+		}
+
+		sourceID, parsedDate, ok := ParseVideoFilename(name)
+		if !ok {
+			t.Errorf("Expected %q to be parsed", name)
+			continue
+		}
+		if sourceID != tt.parsedID {
+			t.Errorf("ParseVideoFilename(%q) source = %q, want %q", name, sourceID, tt.parsedID)
+		}
+		if parsedDate.Format(time.DateOnly) != "2024-01-02" {
+			t.Errorf("ParseVideoFilename(%q) date = %v", name, parsedDate)
+		}
+	}
+}
+
+func TestParseVideoFilename_Invalid(t *testing.T) {
+	names := []string{
+		"timelapse_2024-01-02.mp4.temp.mp4",
+		"timelapse_2024-01-02.mp4.new.mp4",
+		"timelapse_2024-13-02.mp4",
+		"timelapse__2024-01-02.mp4",
+		"timelapse_cam/../timelapse_2024-01-02.mp4",
+		"recording_2024-01-02.mp4",
+		"concat_list.txt",
+	}
+
+	for _, name := range names {
+		if _, _, ok := ParseVideoFilename(name); ok {
+			t.Errorf("Expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestConfig_Output(t *testing.T) {
+	tests := []struct {
+		output    string
+		combined  bool
+		perSource bool
+	}{
+		{"", true, false},
+		{OutputCombined, true, false},
+		{OutputPerSource, false, true},
+		{OutputBoth, true, true},
+	}
+
+	for _, tt := range tests {
+		config := Config{Output: tt.output}
+		if !IsOutput(tt.output) {
+			t.Errorf("Expected %q to be a valid output", tt.output)
+		}
+		if config.CombinedEnabled() != tt.combined || config.PerSourceEnabled() != tt.perSource {
+			t.Errorf("Output %q: combined=%v perSource=%v, want %v %v",
+				tt.output, config.CombinedEnabled(), config.PerSourceEnabled(), tt.combined, tt.perSource)
+		}
+	}
+
+	if IsOutput("separate") {
+		t.Error("Expected unknown output to be rejected")
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"image/draw"
 	"image/jpeg"
 	"log"
 	"sort"
+	"sync"
 	"time"
 
 	"senrigan/internal/camera"
+	"senrigan/internal/imaging"
 )
 
 // FrameComposer は複数の映像ソースのフレームを結合する
@@ -18,19 +21,107 @@ type FrameComposer struct {
 	outputWidth  int
 	outputHeight int
 	quality      int
+	overlay      *Overlay        // フレームに描画する文字（無効な場合はnil）
+	scale        string          // タイルとアスペクト比が異なる映像の拡大縮小の方法
+	scaler       *imaging.Scaler // タイルの大きさへの拡大縮小（映像ソースの解像度毎の重みを保持）
+
+	mu     sync.RWMutex
+	layout LayoutConfig // 結合フレームのレイアウト（ゼロ値は自動の格子）
 }
 
-// NewFrameComposer は新しいFrameComposerを作成する
-func NewFrameComposer(outputWidth, outputHeight, quality int) *FrameComposer {
-	return &FrameComposer{
-		outputWidth:  outputWidth,
-		outputHeight: outputHeight,
-		quality:      quality,
+// NewFrameComposer は設定の出力解像度・品質で新しいFrameComposerを作成する
+// 文字の描画が有効な場合は、結合フレームの各タイルにカメラ名・撮影時刻等を描画する
+func NewFrameComposer(config Config) *FrameComposer {
+	fc := &FrameComposer{
+		outputWidth:  config.Resolution.Width,
+		outputHeight: config.Resolution.Height,
+		quality:      config.Quality,
+		scale:        config.Scale,
+		scaler:       imaging.NewScaler(config.Resampling),
 	}
+	if config.Overlay.Enabled {
+		o, err := NewOverlay(config.Overlay)
+		if err != nil {
+			log.Printf("フレームへの文字の描画を無効にします: %v", err)
+		} else {
+			fc.overlay = o
+		}
+	}
+	return fc
+}
+
+// SetLayout は結合フレームのレイアウトを変更する（次に結合するフレームから反映する）
+func (fc *FrameComposer) SetLayout(layout LayoutConfig) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.layout = layout
+}
+
+// Layout は結合フレームのレイアウトを返す
+func (fc *FrameComposer) Layout() LayoutConfig {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	return fc.layout
 }
 
 // ComposeFrames は複数の映像ソースからフレームを取得して結合する
 func (fc *FrameComposer) ComposeFrames(ctx context.Context, videoSources []camera.VideoSource) (CombinedFrame, error) {
+	frame, sourceTypeMap, err := fc.captureFrames(ctx, videoSources)
+	if err != nil {
+		return CombinedFrame{}, err
+	}
+
+	// フレームを結合
+	composedData, err := fc.combineFrames(frame.SourceFrames, sourceTypeMap, videoSources)
+	if err != nil {
+		return CombinedFrame{}, fmt.Errorf("フレーム結合に失敗: %w", err)
+	}
+
+	frame.ComposedData = composedData
+	frame.Size = len(composedData)
+	return frame, nil
+}
+
+// CaptureFrames は複数の映像ソースからフレームを取得する（結合は行わない）
+// 映像ソース毎の動画のみを出力する場合に、結合処理を省略するために使う
+func (fc *FrameComposer) CaptureFrames(ctx context.Context, videoSources []camera.VideoSource) (CombinedFrame, error) {
+	frame, _, err := fc.captureFrames(ctx, videoSources)
+	return frame, err
+}
+
+// DrawSourceOverlays は映像ソース毎のフレームにカメラ名・撮影時刻等を描画する
+// 文字の描画が無効な場合は何もしない。描画に失敗したフレームは元のまま残す
+func (fc *FrameComposer) DrawSourceOverlays(frame *CombinedFrame, videoSources []camera.VideoSource) {
+	if fc.overlay == nil {
+		return
+	}
+
+	names := sourceNames(videoSources)
+	for sourceID, sourceFrame := range frame.SourceFrames {
+		label := OverlayLabel{SourceID: sourceID, Name: names[sourceID], Timestamp: sourceFrame.Timestamp}
+		data, err := fc.overlay.DrawJPEG(sourceFrame.Data, label, fc.quality*20)
+		if err != nil {
+			log.Printf("映像ソース %s のフレームへの文字の描画に失敗: %v", sourceID, err)
+			continue
+		}
+		sourceFrame.Data = data
+		sourceFrame.Size = len(data)
+		frame.SourceFrames[sourceID] = sourceFrame
+	}
+}
+
+// sourceNames は映像ソースIDとカメラ名の対応を返す
+func sourceNames(videoSources []camera.VideoSource) map[string]string {
+	names := make(map[string]string, len(videoSources))
+	for _, source := range videoSources {
+		info := source.GetInfo()
+		names[info.ID] = info.Name
+	}
+	return names
+}
+
+// captureFrames はアクティブな各映像ソースからフレームを取得する
+func (fc *FrameComposer) captureFrames(ctx context.Context, videoSources []camera.VideoSource) (CombinedFrame, map[string]camera.VideoSourceType, error) {
 	timestamp := time.Now()
 	sourceFrames := make(map[string]SourceFrame)
 	sourceTypeMap := make(map[string]camera.VideoSourceType) // タイプ情報を保持
@@ -61,90 +152,66 @@ func (fc *FrameComposer) ComposeFrames(ctx context.Context, videoSources []camer
 	}
 
 	if len(sourceFrames) == 0 {
-		return CombinedFrame{}, fmt.Errorf("有効なフレームが取得できませんでした")
-	}
-
-	// フレームを結合
-	composedData, err := fc.combineFrames(sourceFrames, sourceTypeMap, videoSources)
-	if err != nil {
-		return CombinedFrame{}, fmt.Errorf("フレーム結合に失敗: %w", err)
+		return CombinedFrame{}, nil, fmt.Errorf("有効なフレームが取得できませんでした")
 	}
 
 	return CombinedFrame{
 		Timestamp:    timestamp,
 		SourceFrames: sourceFrames,
-		ComposedData: composedData,
-		Size:         len(composedData),
-	}, nil
+	}, sourceTypeMap, nil
 }
 
-// combineFrames は複数のJPEGフレームを1つの画像に結合する
+// combineFrames は複数のJPEGフレームをレイアウトに従って1つの画像に結合する
+// タイルの位置は映像を取得できなかった映像ソースを含めて決めるため、映像ソースが停止しても他のタイルは動かない
 func (fc *FrameComposer) combineFrames(sourceFrames map[string]SourceFrame, _ map[string]camera.VideoSourceType, videoSources []camera.VideoSource) ([]byte, error) {
 	if len(sourceFrames) == 0 {
 		return nil, fmt.Errorf("結合するフレームがありません")
 	}
 
-	// 結合レイアウトを決定
-	layout := fc.calculateLayout(len(sourceFrames))
-
 	// 出力画像を作成
 	outputImg := image.NewRGBA(image.Rect(0, 0, fc.outputWidth, fc.outputHeight))
 
-	// カメラ名でソートしてカメラ位置を固定（シンプルな名前順）
-	type SourceInfo struct {
-		ID   string
-		Name string
-	}
-
-	sourceInfos := make([]SourceInfo, 0, len(sourceFrames))
-	for sourceID := range sourceFrames {
-		// sourceTypeMapから名前を取得するためにVideoSourceを探す
-		name := sourceID // デフォルトはID
-		for _, source := range videoSources {
-			if source.GetInfo().ID == sourceID {
-				name = source.GetInfo().Name
-				break
-			}
-		}
-		sourceInfos = append(sourceInfos, SourceInfo{ID: sourceID, Name: name})
-	}
-
-	// 名前でソート、同じ名前の場合はIDでソート
-	sort.SliceStable(sourceInfos, func(i, j int) bool {
-		if sourceInfos[i].Name == sourceInfos[j].Name {
-			return sourceInfos[i].ID < sourceInfos[j].ID
-		}
-		return sourceInfos[i].Name < sourceInfos[j].Name
-	})
+	names := sourceNames(videoSources)
+	tiles := fc.Layout().Tiles(orderedSourceIDs(videoSources), fc.outputWidth, fc.outputHeight)
 
-	// ソートされた順序でIDリストを作成
-	sortedSourceIDs := make([]string, len(sourceInfos))
-	for i, info := range sourceInfos {
-		sortedSourceIDs[i] = info.ID
-	}
-
-	// 各フレームをソート順で配置
-	frameIndex := 0
-	for _, sourceID := range sortedSourceIDs {
-		sourceFrame := sourceFrames[sourceID]
+	// JPEGデータを画像にデコード（時間がかかるため映像ソース毎に並列で処理する）
+	images := make([]image.Image, len(tiles))
+	var wg sync.WaitGroup
+	for i, tile := range tiles {
+		sourceFrame := sourceFrames[tile.SourceID]
 		if len(sourceFrame.Data) == 0 {
 			continue
 		}
-
-		// JPEGデータを画像にデコード
-		img, err := jpeg.Decode(bytes.NewReader(sourceFrame.Data))
-		if err != nil {
-			log.Printf("JPEG デコードエラー (ソース %s): %v", sourceFrame.SourceID, err)
-			continue
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			img, err := jpeg.Decode(bytes.NewReader(sourceFrame.Data))
+			if err != nil {
+				log.Printf("JPEG デコードエラー (ソース %s): %v", sourceFrame.SourceID, err)
+				return
+			}
+			images[i] = img
+		}()
+	}
+	wg.Wait()
+
+	for i, tile := range tiles {
+		pos := tile.Position
+		rect := image.Rect(pos.X, pos.Y, pos.X+pos.Width, pos.Y+pos.Height)
+		label := OverlayLabel{SourceID: tile.SourceID, Name: names[tile.SourceID]}
+
+		if images[i] != nil {
+			fc.scaler.Draw(outputImg, rect, images[i], fc.scale)
+			label.Timestamp = sourceFrames[tile.SourceID].Timestamp
+		} else {
+			// 映像を取得できなかったタイルは黒で塗りつぶす（小窓が下の映像に紛れないように）
+			draw.Draw(outputImg, rect, image.Black, image.Point{}, draw.Src)
 		}
 
-		// 配置位置を計算
-		pos := fc.calculatePosition(frameIndex, layout)
-
-		// 画像を配置
-		fc.drawImageAt(outputImg, img, pos)
-
-		frameIndex++
+		// タイルにカメラ名・撮影時刻等を描画（映像を取得できなかったタイルはカメラ名のみ）
+		if _, known := names[tile.SourceID]; fc.overlay != nil && known {
+			fc.overlay.Draw(outputImg, rect, label)
+		}
 	}
 
 	// 結合画像をJPEGにエンコード
@@ -158,39 +225,25 @@ func (fc *FrameComposer) combineFrames(sourceFrames map[string]SourceFrame, _ ma
 	return buf.Bytes(), nil
 }
 
-// LayoutInfo はレイアウト情報
-type LayoutInfo struct {
-	Cols       int
-	Rows       int
-	CellWidth  int
-	CellHeight int
-}
-
-// calculateLayout はフレーム数に基づいてレイアウトを計算する
-func (fc *FrameComposer) calculateLayout(frameCount int) LayoutInfo {
-	var cols, rows int
-
-	switch frameCount {
-	case 1:
-		cols, rows = 1, 1
-	case 2:
-		cols, rows = 2, 1
-	case 3:
-		cols, rows = 2, 2 // 3つの場合も2x2で1つ空き
-	case 4:
-		cols, rows = 2, 2
-	default:
-		// 5つ以上の場合は適切な格子を計算
-		cols = int(float64(frameCount)*0.6) + 1 // 横を多めに
-		rows = (frameCount + cols - 1) / cols
+// orderedSourceIDs は映像ソースIDをカメラ名順（同じ名前の場合はID順）に返す
+// レイアウトで位置を指定していない映像ソースはこの順に配置する
+func orderedSourceIDs(videoSources []camera.VideoSource) []string {
+	infos := make([]camera.VideoSourceInfo, 0, len(videoSources))
+	for _, source := range videoSources {
+		infos = append(infos, source.GetInfo())
 	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].Name == infos[j].Name {
+			return infos[i].ID < infos[j].ID
+		}
+		return infos[i].Name < infos[j].Name
+	})
 
-	return LayoutInfo{
-		Cols:       cols,
-		Rows:       rows,
-		CellWidth:  fc.outputWidth / cols,
-		CellHeight: fc.outputHeight / rows,
+	ids := make([]string, len(infos))
+	for i, info := range infos {
+		ids[i] = info.ID
 	}
+	return ids
 }
 
 // Position は配置位置
@@ -198,37 +251,3 @@ type Position struct {
 	X, Y          int
 	Width, Height int
 }
-
-// calculatePosition は指定したインデックスの配置位置を計算する
-func (fc *FrameComposer) calculatePosition(index int, layout LayoutInfo) Position {
-	row := index / layout.Cols
-	col := index % layout.Cols
-
-	return Position{
-		X:      col * layout.CellWidth,
-		Y:      row * layout.CellHeight,
-		Width:  layout.CellWidth,
-		Height: layout.CellHeight,
-	}
-}
-
-// drawImageAt は指定した位置に画像を描画する（簡単なリサイズあり）
-func (fc *FrameComposer) drawImageAt(dst *image.RGBA, src image.Image, pos Position) {
-	srcBounds := src.Bounds()
-	srcWidth := srcBounds.Dx()
-	srcHeight := srcBounds.Dy()
-
-	// 簡単なニアレストネイバー法でリサイズしながら配置
-	for y := 0; y < pos.Height; y++ {
-		for x := 0; x < pos.Width; x++ {
-			// ソース画像の対応する座標を計算
-			srcX := x * srcWidth / pos.Width
-			srcY := y * srcHeight / pos.Height
-
-			if srcX < srcWidth && srcY < srcHeight {
-				color := src.At(srcBounds.Min.X+srcX, srcBounds.Min.Y+srcY)
-				dst.Set(pos.X+x, pos.Y+y, color)
-			}
-		}
-	}
-}
@@ -0,0 +1,99 @@
+package timelapse
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"testing"
+
+	"senrigan/internal/camera"
+	"senrigan/internal/imaging"
+)
+
+// stubSource は映像ソースの情報のみを返すテスト用の映像ソース
+type stubSource struct {
+	camera.VideoSource
+	info camera.VideoSourceInfo
+}
+
+func (s stubSource) GetInfo() camera.VideoSourceInfo {
+	return s.info
+}
+
+// solidJPEG は単色のJPEG画像を返す
+func solidJPEG(t *testing.T, c color.Color, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFrameComposer_MissingSource(t *testing.T) {
+	sources := []camera.VideoSource{
+		stubSource{info: camera.VideoSourceInfo{ID: "a", Name: "a"}},
+		stubSource{info: camera.VideoSourceInfo{ID: "b", Name: "b"}},
+		stubSource{info: camera.VideoSourceInfo{ID: "c", Name: "c"}},
+	}
+	fc := NewFrameComposer(Config{Resolution: Resolution{Width: 200, Height: 200}, Quality: 5, Scale: imaging.ScaleStretch})
+
+	// b の映像を取得できなくても c は左下のまま、b のタイルは黒で表示する
+	data, err := fc.combineFrames(map[string]SourceFrame{
+		"a": {SourceID: "a", Data: solidJPEG(t, color.White, 64, 48)},
+		"c": {SourceID: "c", Data: solidJPEG(t, color.White, 64, 48)},
+	}, nil, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		x, y   int
+		bright bool
+	}{{50, 50, true}, {150, 50, false}, {50, 150, true}, {150, 150, false}} {
+		r, _, _, _ := img.At(tt.x, tt.y).RGBA()
+		if (r > 0x8000) != tt.bright {
+			t.Errorf("Pixel at (%d, %d): r=%d, want bright=%v", tt.x, tt.y, r>>8, tt.bright)
+		}
+	}
+}
+
+func TestFrameComposer_Scale(t *testing.T) {
+	sources := []camera.VideoSource{stubSource{info: camera.VideoSourceInfo{ID: "screen", Name: "screen"}}}
+	frames := map[string]SourceFrame{"screen": {SourceID: "screen", Data: solidJPEG(t, color.White, 160, 90)}}
+
+	tests := []struct {
+		scale  string
+		bright bool // 上端が映像で埋まるか
+	}{
+		{imaging.ScaleFit, false},
+		{imaging.ScaleFill, true},
+		{imaging.ScaleStretch, true},
+	}
+	for _, tt := range tests {
+		// 16:9 の映像を正方形の出力に配置する
+		fc := NewFrameComposer(Config{Resolution: Resolution{Width: 120, Height: 120}, Quality: 5, Scale: tt.scale})
+		data, err := fc.combineFrames(frames, nil, sources)
+		if err != nil {
+			t.Fatal(err)
+		}
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		top, _, _, _ := img.At(60, 5).RGBA()
+		center, _, _, _ := img.At(60, 60).RGBA()
+		if (top > 0x8000) != tt.bright || center < 0x8000 {
+			t.Errorf("%s: top=%d center=%d, want top bright=%v", tt.scale, top>>8, center>>8, tt.bright)
+		}
+	}
+}
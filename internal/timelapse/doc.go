@@ -2,18 +2,39 @@
 //
 // 主な機能:
 // - 映像ソースから定期的にフレームを撮影
-// - メモリ上にフレームをバッファリング
+// - 撮影したフレームを日付毎のディレクトリに保存（停止・異常終了しても失われない）
 // - 定期的に動画を生成・延長
 // - 日毎の動画ファイル管理
+// - 全映像ソースを結合した動画と映像ソース毎の動画の出力
+// - 保存したフレームの一覧と指定時刻に最も近いフレームの検索（任意の時刻へのシーク用）
+// - 動画の各フレームの撮影時刻の記録と、指定時刻を撮影した動画の再生位置の検索
+// - フレームへの撮影時刻・カメラ名・任意の文字列の描画
+// - 名前付きのレイアウト（格子・ピクチャーインピクチャー・1+N）による結合フレームの配置
 //
 // 責務:
 // - TimelapseManager: 全体の管理とスケジューリング
 // - TimelapseCapture: 映像ソース毎のキャプチャ処理
-// - VideoGenerator: FFmpegを使った動画生成
+// - FrameStore: 撮影したフレームの保存・検索と動画に追加済みの位置の記録
+// - VideoIndex: 動画の各フレームの撮影時刻の記録（動画と同じディレクトリの .timestamps ファイル）
+// - Overlay: フレームへの文字の描画（内蔵フォントまたは指定したフォントファイル）
+// - LayoutConfig: 結合フレームの映像ソース毎のタイルの配置
+// - VideoGenerator: 保存したフレームからFFmpegを使った動画生成
 //
 // 仕様:
 // - 撮影間隔: デフォルト2秒毎
 // - 動画更新間隔: デフォルト1時間毎
 // - ファイル分割: 日毎に新しい動画ファイル作成
+// - 出力: combined（結合した動画）/ per_source（映像ソース毎、元の解像度）/ both
+// - フレームの保存先: <動画出力先>-frames/YYYY-MM-DD/（結合フレームと映像ソース毎のフレーム、動画と一緒に配信しないよう動画出力先の外に保存）
+// - フレームの検索: 時刻の範囲で一覧（日付をまたいで検索）、指定時刻に最も近いフレーム（その日にない場合は前後の日）
+// - フレームの保持: retention_days 日間（0は無期限）、合計サイズが max_frame_storage_mb を超えると当日・前日以外の古い日付から削除
+// - 動画の延長: 追加する部分を既存の動画の解像度で作成して再エンコードなしで結合（途中で解像度が変わったフレームは黒の余白を付けて揃える）
+// - 動画ファイルを削除すると、次回起動時に保存済みのフレームから作り直す
+// - ファイル名: 結合した動画は timelapse_YYYY-MM-DD.mp4、映像ソース毎の動画は timelapse_<映像ソースID>_YYYY-MM-DD.mp4
+// - 撮影時刻の記録: 保存したフレーム1枚を動画の1フレーム（30fps）とし、n行目に動画のn番目のフレームの撮影時刻（UTC、固定長）を記録
+// - 文字の描画: 結合フレームはタイル毎、映像ソース毎のフレームはフレーム全体に描画（内蔵フォントで描画できないカメラ名は映像ソースIDで代用）
+// - 拡大縮小: タイルとアスペクト比が異なる映像は fit（余白を黒で塗りつぶす、デフォルト）/ fill（切り取る）/ stretch、補間フィルタは bilinear（デフォルト）/ catmull_rom / nearest
+// - 結合: 映像ソースのJPEGのデコードは並列に処理する
+// - レイアウト: auto（映像ソース数に合わせた格子）/ grid（セルの指定・結合）/ pip / focus、映像ソースが停止しても他のタイルの位置は変わらない
 // - リアルタイム視聴: 作成途中の動画も再生可能
 package timelapse
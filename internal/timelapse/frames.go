@@ -0,0 +1,418 @@
+package timelapse
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// framesDirSuffix は撮影したフレームを保存するディレクトリの接尾辞
+	// 動画出力先は動画ファイルとして配信されるため、フレームは隣の <動画出力先>-frames に保存する
+	framesDirSuffix = "-frames"
+
+	// combinedFramesDirName は結合フレームを保存するディレクトリ名
+	combinedFramesDirName = "combined"
+
+	// sourceFramesDirName は映像ソース毎のフレームを保存するディレクトリ名
+	sourceFramesDirName = "sources"
+
+	// encodedFileName は動画に追加済みの最後のフレームの時刻を記録するファイル名
+	encodedFileName = ".encoded"
+
+	// frameFileLayout はフレームのファイル名（撮影時刻）の形式
+	frameFileLayout = "150405.000"
+)
+
+// ErrFrameNotFound は指定した時刻付近に保存したフレームが存在しない場合のエラー
+var ErrFrameNotFound = errors.New("フレームが見つかりません")
+
+// StoredFrame はディスクに保存したフレーム
+type StoredFrame struct {
+	SourceID  string    // 映像ソースID（結合フレームは空文字）
+	Timestamp time.Time // 撮影時刻
+	Path      string    // JPEGファイルのパス
+}
+
+// FrameStore は撮影したフレームを日付毎のディレクトリに保存する
+// 動画はこのフレームから生成するため、停止・異常終了してもフレームは失われない
+//
+// ディレクトリ構成:
+//
+//	<dir>/YYYY-MM-DD/combined/HHMMSS.mmm.jpg           結合フレーム
+//	<dir>/YYYY-MM-DD/sources/<映像ソースID>/HHMMSS.mmm.jpg 映像ソース毎のフレーム
+type FrameStore struct {
+	dir           string
+	retentionDays int
+	maxSize       int64 // 保存したフレームの合計サイズの上限（バイト、0は無制限）
+}
+
+// OpenFrameStore はフレームの保存先を作成して開く
+func OpenFrameStore(dir string, retentionDays int, maxSize int64) (*FrameStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("フレームディレクトリの作成に失敗: %w", err)
+	}
+	return NewFrameStore(dir, retentionDays, maxSize), nil
+}
+
+// NewFrameStore は保存先を作成せずに FrameStore を作成する（保存済みのフレームの参照用）
+func NewFrameStore(dir string, retentionDays int, maxSize int64) *FrameStore {
+	return &FrameStore{dir: dir, retentionDays: retentionDays, maxSize: maxSize}
+}
+
+// FramesDir は動画出力先に対するフレームの保存先を返す
+func FramesDir(outputDir string) string {
+	return filepath.Clean(outputDir) + framesDirSuffix
+}
+
+// Save は結合フレームと映像ソース毎のフレームを保存する
+func (s *FrameStore) Save(frame CombinedFrame) error {
+	if len(frame.ComposedData) > 0 {
+		if err := s.write("", frame.Timestamp, frame.ComposedData); err != nil {
+			return err
+		}
+	}
+	for sourceID, sourceFrame := range frame.SourceFrames {
+		if len(sourceFrame.Data) == 0 {
+			continue
+		}
+		if err := s.write(sourceID, sourceFrame.Timestamp, sourceFrame.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write はフレームを一時ファイル経由で書き込む（書き込み途中のファイルを動画に含めないため）
+func (s *FrameStore) write(sourceID string, timestamp time.Time, data []byte) error {
+	dir := s.framesDir(sourceID, timestamp)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("フレームディレクトリの作成に失敗: %w", err)
+	}
+
+	path := filepath.Join(dir, timestamp.Format(frameFileLayout)+".jpg")
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("フレームの書き込みに失敗: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("フレームの置き換えに失敗: %w", err)
+	}
+	return nil
+}
+
+// Dates はフレームを保存している日付を古い順に返す
+func (s *FrameStore) Dates() ([]time.Time, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("フレームディレクトリの読み取りに失敗: %w", err)
+	}
+
+	var dates []time.Time
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		date, err := time.ParseInLocation(time.DateOnly, entry.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates, nil
+}
+
+// Sources は指定した日付にフレームを保存している映像ソースID（ファイル名に使える文字に置き換えたもの）を返す
+func (s *FrameStore) Sources(date time.Time) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, date.Format(time.DateOnly), sourceFramesDirName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("フレームディレクトリの読み取りに失敗: %w", err)
+	}
+
+	var sources []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			sources = append(sources, entry.Name())
+		}
+	}
+	return sources, nil
+}
+
+// Frames は指定した日付・映像ソースの after より後に撮影したフレームを撮影順に返す
+// sourceID が空文字の場合は結合フレームを返す
+func (s *FrameStore) Frames(sourceID string, date time.Time, after time.Time) ([]StoredFrame, error) {
+	dir := s.framesDir(sourceID, date)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("フレームディレクトリの読み取りに失敗: %w", err)
+	}
+
+	dateStr := date.Format(time.DateOnly)
+	var frames []StoredFrame
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".jpg")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		timestamp, err := time.ParseInLocation(time.DateOnly+" "+frameFileLayout, dateStr+" "+name, time.Local)
+		if err != nil || !timestamp.After(after) {
+			continue
+		}
+		frames = append(frames, StoredFrame{
+			SourceID:  sourceID,
+			Timestamp: timestamp,
+			Path:      filepath.Join(dir, entry.Name()),
+		})
+	}
+
+	// ファイル名は撮影時刻のため名前順が撮影順になる
+	sort.Slice(frames, func(i, j int) bool { return frames[i].Timestamp.Before(frames[j].Timestamp) })
+	return frames, nil
+}
+
+// List は条件に一致するフレームを撮影順に返す
+// 保存先のディレクトリ構成（日付・映像ソース毎、ファイル名が撮影時刻）をフレームの索引として使う
+func (s *FrameStore) List(filter FrameFilter) ([]StoredFrame, error) {
+	dates, err := s.Dates()
+	if err != nil {
+		return nil, err
+	}
+
+	var frames []StoredFrame
+	for _, date := range dates {
+		if !filter.From.IsZero() && !date.AddDate(0, 0, 1).After(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && date.After(filter.To) {
+			break
+		}
+
+		dateFrames, err := s.Frames(filter.SourceID, date, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		for _, frame := range dateFrames {
+			if !filter.From.IsZero() && frame.Timestamp.Before(filter.From) {
+				continue
+			}
+			if !filter.To.IsZero() && frame.Timestamp.After(filter.To) {
+				break
+			}
+			frames = append(frames, frame)
+		}
+	}
+	return frames, nil
+}
+
+// Nearest は指定した時刻に最も近いフレームを返す
+// 同じ日付にフレームがない場合は前後の日付から探す
+func (s *FrameStore) Nearest(sourceID string, at time.Time) (StoredFrame, error) {
+	at = at.In(time.Local)
+
+	// 一覧で返した撮影時刻と一致する場合はディレクトリを走査しない
+	if at.Equal(at.Truncate(time.Millisecond)) {
+		path := filepath.Join(s.framesDir(sourceID, at), at.Format(frameFileLayout)+".jpg")
+		if _, err := os.Stat(path); err == nil {
+			return StoredFrame{SourceID: sourceID, Timestamp: at, Path: path}, nil
+		}
+	}
+
+	date := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.Local)
+	frames, err := s.Frames(sourceID, date, time.Time{})
+	if err != nil {
+		return StoredFrame{}, err
+	}
+	if len(frames) == 0 {
+		for _, adjacent := range []time.Time{date.AddDate(0, 0, -1), date.AddDate(0, 0, 1)} {
+			adjacentFrames, err := s.Frames(sourceID, adjacent, time.Time{})
+			if err != nil {
+				return StoredFrame{}, err
+			}
+			frames = append(frames, adjacentFrames...)
+		}
+	}
+	if len(frames) == 0 {
+		return StoredFrame{}, ErrFrameNotFound
+	}
+
+	nearest := frames[0]
+	for _, frame := range frames[1:] {
+		if absDuration(frame.Timestamp.Sub(at)) < absDuration(nearest.Timestamp.Sub(at)) {
+			nearest = frame
+		}
+	}
+	return nearest, nil
+}
+
+// absDuration は時間の絶対値を返す
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// SampleFrames はフレームを撮影順のまま最大 limit 件に間引く（最初と最後のフレームは必ず含める）
+// 長い期間のフレームをシークバーのサムネイルとして表示するために使う
+func SampleFrames(frames []StoredFrame, limit int) []StoredFrame {
+	if limit <= 0 || len(frames) <= limit {
+		return frames
+	}
+	if limit == 1 {
+		return frames[:1]
+	}
+
+	sampled := make([]StoredFrame, 0, limit)
+	for i := 0; i < limit; i++ {
+		sampled = append(sampled, frames[i*(len(frames)-1)/(limit-1)])
+	}
+	return sampled
+}
+
+// Encoded は動画に追加済みの最後のフレームの撮影時刻を返す（未追加の場合はゼロ値）
+func (s *FrameStore) Encoded(sourceID string, date time.Time) (time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(s.framesDir(sourceID, date), encodedFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("動画の追加状況の読み込みに失敗: %w", err)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("動画の追加状況の解析に失敗: %w", err)
+	}
+	return timestamp, nil
+}
+
+// SetEncoded は動画に追加済みの最後のフレームの撮影時刻を記録する
+// ゼロ値を指定した場合は記録を削除する（全てのフレームから動画を作り直す）
+func (s *FrameStore) SetEncoded(sourceID string, date time.Time, timestamp time.Time) error {
+	path := filepath.Join(s.framesDir(sourceID, date), encodedFileName)
+	if timestamp.IsZero() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("動画の追加状況の削除に失敗: %w", err)
+		}
+		return nil
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, []byte(timestamp.Format(time.RFC3339Nano)+"\n"), 0644); err != nil {
+		return fmt.Errorf("動画の追加状況の書き込みに失敗: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("動画の追加状況の置き換えに失敗: %w", err)
+	}
+	return nil
+}
+
+// Prune は保持期間を過ぎた日付のフレームを削除する
+// 合計サイズが上限を超える場合は、保持期間内でも古い日付から削除する
+// （動画に追加している途中の当日・前日のフレームは削除しない）
+func (s *FrameStore) Prune(now time.Time) error {
+	if s.retentionDays <= 0 && s.maxSize <= 0 {
+		return nil
+	}
+
+	dates, err := s.Dates()
+	if err != nil {
+		return err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if s.retentionDays > 0 {
+		cutoff := today.AddDate(0, 0, -s.retentionDays)
+		for len(dates) > 0 && dates[0].Before(cutoff) {
+			if err := os.RemoveAll(s.dateDir(dates[0])); err != nil {
+				return fmt.Errorf("保持期間を過ぎたフレームの削除に失敗: %w", err)
+			}
+			dates = dates[1:]
+		}
+	}
+	if s.maxSize <= 0 {
+		return nil
+	}
+
+	sizes := make([]int64, len(dates))
+	var total int64
+	for i, date := range dates {
+		if sizes[i], err = DirectorySize(s.dateDir(date)); err != nil {
+			return err
+		}
+		total += sizes[i]
+	}
+
+	yesterday := today.AddDate(0, 0, -1)
+	for i, date := range dates {
+		if total <= s.maxSize || !date.Before(yesterday) {
+			break
+		}
+		if err := os.RemoveAll(s.dateDir(date)); err != nil {
+			return fmt.Errorf("上限を超えたフレームの削除に失敗: %w", err)
+		}
+		total -= sizes[i]
+		log.Printf("保存したフレームの合計サイズが上限を超えたため %s のフレームを削除しました", date.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// DirectorySize はディレクトリ以下のファイルサイズの合計を返す（ディレクトリがない場合は0）
+// フレームの保存サイズの上限の確認と、メトリクスの使用量の計測で使用する
+func DirectorySize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil // 計測中に削除されたファイル
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("ディレクトリのサイズの計測に失敗: %w", err)
+	}
+	return total, nil
+}
+
+// dateDir は指定した日付のフレームを保存するディレクトリを返す
+func (s *FrameStore) dateDir(date time.Time) string {
+	return filepath.Join(s.dir, date.Format(time.DateOnly))
+}
+
+// framesDir はフレームを保存するディレクトリを返す
+func (s *FrameStore) framesDir(sourceID string, date time.Time) string {
+	dateDir := s.dateDir(date)
+	if sourceID == "" {
+		return filepath.Join(dateDir, combinedFramesDirName)
+	}
+	return filepath.Join(dateDir, sourceFramesDirName, filenameID(sourceID))
+}
@@ -0,0 +1,248 @@
+package timelapse
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFrameStore_SaveAndFrames(t *testing.T) {
+	store, err := OpenFrameStore(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local)
+	second := first.Add(2 * time.Second)
+	for _, timestamp := range []time.Time{second, first} {
+		frame := CombinedFrame{
+			Timestamp:    timestamp,
+			ComposedData: []byte("composed"),
+			SourceFrames: map[string]SourceFrame{
+				"cam/1": {SourceID: "cam/1", Timestamp: timestamp, Data: []byte("a")},
+				"empty": {SourceID: "empty", Timestamp: timestamp},
+			},
+		}
+		if err := store.Save(frame); err != nil {
+			t.Fatalf("Expected frame to be saved, got %v", err)
+		}
+	}
+
+	combined, err := store.Frames("", first, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(combined) != 2 || !combined[0].Timestamp.Equal(first) || !combined[1].Timestamp.Equal(second) {
+		t.Fatalf("Expected 2 combined frames in capture order, got %+v", combined)
+	}
+
+	sources, err := store.Sources(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 1 || sources[0] != "cam_1" {
+		t.Errorf("Expected only cam_1 to have frames, got %v", sources)
+	}
+
+	later, err := store.Frames("cam/1", first, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(later) != 1 || !later[0].Timestamp.Equal(second) {
+		t.Errorf("Expected only the frame after %v, got %+v", first, later)
+	}
+	data, err := os.ReadFile(later[0].Path)
+	if err != nil || string(data) != "a" {
+		t.Errorf("Expected stored JPEG data, got %q (%v)", data, err)
+	}
+}
+
+func TestFrameStore_Encoded(t *testing.T) {
+	store, err := OpenFrameStore(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+	if encoded, err := store.Encoded("", date); err != nil || !encoded.IsZero() {
+		t.Fatalf("Expected zero time before encoding, got %v (%v)", encoded, err)
+	}
+
+	timestamp := date.Add(10*time.Hour + 500*time.Millisecond)
+	if err := store.Save(CombinedFrame{Timestamp: timestamp, ComposedData: []byte("x")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetEncoded("", date, timestamp); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := store.Encoded("", date)
+	if err != nil || !encoded.Equal(timestamp) {
+		t.Errorf("Expected %v, got %v (%v)", timestamp, encoded, err)
+	}
+	if frames, _ := store.Frames("", date, encoded); len(frames) != 0 {
+		t.Errorf("Expected no frames after the encoded one, got %d", len(frames))
+	}
+
+	if err := store.SetEncoded("", date, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if encoded, _ := store.Encoded("", date); !encoded.IsZero() {
+		t.Errorf("Expected encoded time to be cleared, got %v", encoded)
+	}
+}
+
+func TestFrameStore_Prune(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenFrameStore(dir, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.Local)
+	for _, day := range []int{7, 8, 9, 10} {
+		timestamp := time.Date(2024, 1, day, 12, 0, 0, 0, time.Local)
+		if err := store.Save(CombinedFrame{Timestamp: timestamp, ComposedData: []byte("x")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Prune(now); err != nil {
+		t.Fatalf("Expected prune to succeed, got %v", err)
+	}
+
+	dates, err := store.Dates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 3 || dates[0].Day() != 8 {
+		t.Errorf("Expected frames older than 2 days to be removed, got %v", dates)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "README")); err != nil {
+		t.Errorf("Expected unrelated files to be kept: %v", err)
+	}
+}
+
+func TestFrameStore_PruneBySize(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenFrameStore(dir, 30, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1日100バイトのフレームを4日分保存する
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.Local)
+	for _, day := range []int{7, 8, 9, 10} {
+		timestamp := time.Date(2024, 1, day, 12, 0, 0, 0, time.Local)
+		if err := store.Save(CombinedFrame{Timestamp: timestamp, ComposedData: make([]byte, 100)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Prune(now); err != nil {
+		t.Fatalf("Expected prune to succeed, got %v", err)
+	}
+
+	// 上限に収まるまで古い日付から削除する
+	dates, err := store.Dates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 2 || dates[0].Day() != 9 {
+		t.Errorf("Expected frames of the oldest 2 days to be removed, got %v", dates)
+	}
+
+	// 当日・前日のフレームは上限を超えても削除しない
+	store.maxSize = 1
+	if err := store.Prune(now); err != nil {
+		t.Fatalf("Expected prune to succeed, got %v", err)
+	}
+	if dates, _ := store.Dates(); len(dates) != 2 {
+		t.Errorf("Expected frames of today and yesterday to be kept, got %v", dates)
+	}
+}
+
+func TestFrameStore_ListAndNearest(t *testing.T) {
+	store, err := OpenFrameStore(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 日付をまたいで10分毎に保存する
+	start := time.Date(2024, 1, 1, 23, 30, 0, 0, time.Local)
+	for i := 0; i < 6; i++ {
+		timestamp := start.Add(time.Duration(i) * 10 * time.Minute)
+		frame := CombinedFrame{SourceFrames: map[string]SourceFrame{
+			"cam": {SourceID: "cam", Timestamp: timestamp, Data: []byte{byte(i)}},
+		}}
+		if err := store.Save(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := store.List(FrameFilter{SourceID: "cam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 6 || !all[0].Timestamp.Equal(start) {
+		t.Fatalf("Expected 6 frames across dates, got %d", len(all))
+	}
+
+	ranged, err := store.List(FrameFilter{SourceID: "cam", From: start.Add(15 * time.Minute), To: start.Add(40 * time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranged) != 3 || !ranged[0].Timestamp.Equal(start.Add(20*time.Minute)) {
+		t.Errorf("Expected frames at 23:50, 00:00 and 00:10, got %+v", ranged)
+	}
+
+	if combined, _ := store.List(FrameFilter{}); len(combined) != 0 {
+		t.Errorf("Expected no combined frames, got %d", len(combined))
+	}
+
+	exact, err := store.Nearest("cam", start.Add(30*time.Minute).UTC())
+	if err != nil || !exact.Timestamp.Equal(start.Add(30*time.Minute)) {
+		t.Errorf("Expected exact frame, got %+v (%v)", exact, err)
+	}
+	nearest, err := store.Nearest("cam", start.Add(24*time.Minute))
+	if err != nil || !nearest.Timestamp.Equal(start.Add(20*time.Minute)) {
+		t.Errorf("Expected the 23:50 frame, got %+v (%v)", nearest, err)
+	}
+	nextDay, err := store.Nearest("cam", time.Date(2024, 1, 3, 1, 0, 0, 0, time.Local))
+	if err != nil || !nextDay.Timestamp.Equal(start.Add(50*time.Minute)) {
+		t.Errorf("Expected the last frame of the previous day, got %+v (%v)", nextDay, err)
+	}
+	if _, err := store.Nearest("cam", time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)); !errors.Is(err, ErrFrameNotFound) {
+		t.Errorf("Expected ErrFrameNotFound far from any frame, got %v", err)
+	}
+}
+
+func TestSampleFrames(t *testing.T) {
+	frames := make([]StoredFrame, 10)
+	for i := range frames {
+		frames[i].Timestamp = time.Unix(int64(i), 0)
+	}
+
+	if got := SampleFrames(frames, 20); len(got) != 10 {
+		t.Errorf("Expected all frames under the limit, got %d", len(got))
+	}
+
+	got := SampleFrames(frames, 4)
+	if len(got) != 4 {
+		t.Fatalf("Expected 4 frames, got %d", len(got))
+	}
+	want := []int64{0, 3, 6, 9}
+	for i, frame := range got {
+		if frame.Timestamp.Unix() != want[i] {
+			t.Errorf("Sample %d = %d, want %d", i, frame.Timestamp.Unix(), want[i])
+		}
+	}
+
+	if got := SampleFrames(frames, 1); len(got) != 1 || got[0].Timestamp.Unix() != 0 {
+		t.Errorf("Expected only the first frame, got %+v", got)
+	}
+}
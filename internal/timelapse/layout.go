@@ -0,0 +1,323 @@
+package timelapse
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ErrLayoutNotFound は指定した名前のレイアウトが存在しない場合のエラー
+var ErrLayoutNotFound = errors.New("レイアウトが見つかりません")
+
+// レイアウトの種類
+const (
+	LayoutAuto  = "auto"  // 映像ソース数に合わせた格子（名前順）
+	LayoutGrid  = "grid"  // 映像ソース毎のセルを指定した格子
+	LayoutPiP   = "pip"   // メインの映像ソースを全体に表示し、他の映像ソースを小窓で表示
+	LayoutFocus = "focus" // メインの映像ソースを大きく表示し、他の映像ソースを横または下に並べる（1+N）
+)
+
+// focus レイアウトの他の映像ソースを並べる位置
+const (
+	LayoutSideRight  = "right"
+	LayoutSideBottom = "bottom"
+)
+
+const (
+	// defaultPiPSize は pip レイアウトの小窓の幅（出力の幅に対する割合）
+	defaultPiPSize = 0.25
+
+	// defaultFocusSize は focus レイアウトの他の映像ソースを並べる帯の幅（出力に対する割合）
+	defaultFocusSize = 0.25
+
+	// pipMarginRatio は pip レイアウトの小窓と端の間隔（出力の幅に対する割合）
+	pipMarginRatio = 0.01
+)
+
+// LayoutConfig は結合フレームのレイアウト
+type LayoutConfig struct {
+	Type     string       `json:"type" yaml:"type"`         // 種類 ("auto", "grid", "pip", "focus")
+	Cols     int          `json:"cols" yaml:"cols"`         // grid: 列数
+	Rows     int          `json:"rows" yaml:"rows"`         // grid: 行数
+	Cells    []LayoutCell `json:"cells" yaml:"cells"`       // grid: 映像ソース毎のセル（指定のない映像ソースは空いたセルに名前順で配置）
+	Main     string       `json:"main" yaml:"main"`         // pip・focus: 大きく表示する映像ソースID（空文字は名前順で最初の映像ソース）
+	Size     float64      `json:"size" yaml:"size"`         // pip: 小窓の幅、focus: 他の映像ソースを並べる帯の幅（出力に対する割合、0はデフォルト）
+	Position string       `json:"position" yaml:"position"` // pip: 小窓を並べる角（"bottom_right" 等、空文字は右下）、focus: 帯の位置（"right", "bottom"、空文字は右）
+}
+
+// LayoutCell は grid レイアウトの映像ソースのセル
+type LayoutCell struct {
+	Source  string `json:"source" yaml:"source"`     // 映像ソースID
+	Col     int    `json:"col" yaml:"col"`           // 列（0から）
+	Row     int    `json:"row" yaml:"row"`           // 行（0から）
+	ColSpan int    `json:"col_span" yaml:"col_span"` // 横に結合するセル数（0は1）
+	RowSpan int    `json:"row_span" yaml:"row_span"` // 縦に結合するセル数（0は1）
+}
+
+// Tile は結合フレームに映像ソースを配置する位置
+type Tile struct {
+	SourceID string
+	Position Position
+}
+
+// LayoutByName は名前を指定してレイアウトを取得する（空文字と "auto" は自動の格子）
+func (c Config) LayoutByName(name string) (LayoutConfig, error) {
+	if name == "" || name == LayoutAuto {
+		return LayoutConfig{Type: LayoutAuto}, nil
+	}
+	layout, ok := c.Layouts[name]
+	if !ok {
+		return LayoutConfig{}, fmt.Errorf("%w: %s", ErrLayoutNotFound, name)
+	}
+	return layout, nil
+}
+
+// LayoutNames は選択できるレイアウトの名前を返す（"auto" と名前順の設定したレイアウト）
+func (c Config) LayoutNames() []string {
+	names := make([]string, 0, len(c.Layouts)+1)
+	names = append(names, LayoutAuto)
+	for name := range c.Layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// ValidateLayouts はレイアウトの設定と使用するレイアウトを検証する
+func ValidateLayouts(config Config) error {
+	for name, layout := range config.Layouts {
+		if name == "" || name == LayoutAuto {
+			return fmt.Errorf("レイアウト名 %q は使用できません", name)
+		}
+		if err := layout.Validate(); err != nil {
+			return fmt.Errorf("レイアウト %s: %w", name, err)
+		}
+	}
+	if _, err := config.LayoutByName(config.Layout); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate はレイアウトを検証する
+func (l LayoutConfig) Validate() error {
+	switch l.Type {
+	case "", LayoutAuto:
+		return nil
+	case LayoutGrid:
+		return l.validateGrid()
+	case LayoutPiP:
+		if !IsOverlayPosition(l.Position) {
+			return fmt.Errorf("無効な小窓の位置: %s", l.Position)
+		}
+	case LayoutFocus:
+		if l.Position != "" && l.Position != LayoutSideRight && l.Position != LayoutSideBottom {
+			return fmt.Errorf("無効な帯の位置: %s", l.Position)
+		}
+	default:
+		return fmt.Errorf("無効なレイアウトの種類: %s", l.Type)
+	}
+	if l.Size < 0 || l.Size >= 1 {
+		return fmt.Errorf("size は0以上1未満で指定してください")
+	}
+	return nil
+}
+
+// validateGrid は grid レイアウトのセルが格子に収まり、重ならないことを検証する
+func (l LayoutConfig) validateGrid() error {
+	if l.Cols <= 0 || l.Rows <= 0 {
+		return fmt.Errorf("列数と行数は1以上で指定してください")
+	}
+
+	occupied := make([]bool, l.Cols*l.Rows)
+	sources := make(map[string]bool, len(l.Cells))
+	for _, cell := range l.Cells {
+		if cell.Source == "" {
+			return fmt.Errorf("セルの映像ソースが指定されていません")
+		}
+		if sources[cell.Source] {
+			return fmt.Errorf("映像ソース %s のセルが重複しています", cell.Source)
+		}
+		sources[cell.Source] = true
+
+		colSpan, rowSpan := max(cell.ColSpan, 1), max(cell.RowSpan, 1)
+		if cell.Col < 0 || cell.Row < 0 || cell.Col+colSpan > l.Cols || cell.Row+rowSpan > l.Rows {
+			return fmt.Errorf("映像ソース %s のセルが格子の外にあります", cell.Source)
+		}
+		for row := cell.Row; row < cell.Row+rowSpan; row++ {
+			for col := cell.Col; col < cell.Col+colSpan; col++ {
+				if occupied[row*l.Cols+col] {
+					return fmt.Errorf("映像ソース %s のセルが他のセルと重なっています", cell.Source)
+				}
+				occupied[row*l.Cols+col] = true
+			}
+		}
+	}
+	return nil
+}
+
+// Tiles は映像ソースを配置する位置を描画順に返す
+// sourceIDs には映像の有無に関わらず全ての映像ソースを表示順（名前順）に指定する
+// 映像を取得できない映像ソースがあっても、他の映像ソースの位置は変わらない
+func (l LayoutConfig) Tiles(sourceIDs []string, width, height int) []Tile {
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	switch l.Type {
+	case LayoutGrid:
+		return l.gridTiles(sourceIDs, width, height)
+	case LayoutPiP:
+		return l.pipTiles(sourceIDs, width, height)
+	case LayoutFocus:
+		return l.focusTiles(sourceIDs, width, height)
+	default:
+		return autoTiles(sourceIDs, width, height)
+	}
+}
+
+// autoTiles は映像ソース数に合わせた正方形に近い格子に名前順で配置する
+func autoTiles(sourceIDs []string, width, height int) []Tile {
+	cols := int(math.Ceil(math.Sqrt(float64(len(sourceIDs)))))
+	rows := (len(sourceIDs) + cols - 1) / cols
+
+	tiles := make([]Tile, len(sourceIDs))
+	for i, sourceID := range sourceIDs {
+		tiles[i] = Tile{SourceID: sourceID, Position: cellPosition(i%cols, i/cols, 1, 1, cols, rows, width, height)}
+	}
+	return tiles
+}
+
+// gridTiles は指定したセルに映像ソースを配置し、指定のない映像ソースを空いたセルに名前順で配置する
+// 空いたセルが足りない映像ソースは表示しない
+func (l LayoutConfig) gridTiles(sourceIDs []string, width, height int) []Tile {
+	occupied := make([]bool, l.Cols*l.Rows)
+	placed := make(map[string]bool, len(l.Cells))
+	tiles := make([]Tile, 0, len(sourceIDs))
+
+	for _, cell := range l.Cells {
+		colSpan, rowSpan := max(cell.ColSpan, 1), max(cell.RowSpan, 1)
+		for row := cell.Row; row < cell.Row+rowSpan; row++ {
+			for col := cell.Col; col < cell.Col+colSpan; col++ {
+				occupied[row*l.Cols+col] = true
+			}
+		}
+		placed[cell.Source] = true
+		tiles = append(tiles, Tile{
+			SourceID: cell.Source,
+			Position: cellPosition(cell.Col, cell.Row, colSpan, rowSpan, l.Cols, l.Rows, width, height),
+		})
+	}
+
+	next := 0
+	for _, sourceID := range sourceIDs {
+		if placed[sourceID] {
+			continue
+		}
+		for next < len(occupied) && occupied[next] {
+			next++
+		}
+		if next == len(occupied) {
+			break
+		}
+		occupied[next] = true
+		tiles = append(tiles, Tile{
+			SourceID: sourceID,
+			Position: cellPosition(next%l.Cols, next/l.Cols, 1, 1, l.Cols, l.Rows, width, height),
+		})
+	}
+	return tiles
+}
+
+// pipTiles はメインの映像ソースを全体に配置し、他の映像ソースを角から小窓で並べる
+// 小窓が1行に収まらない場合は内側の行に折り返す
+func (l LayoutConfig) pipTiles(sourceIDs []string, width, height int) []Tile {
+	main, others := l.mainSource(sourceIDs)
+	tiles := []Tile{{SourceID: main, Position: Position{Width: width, Height: height}}}
+
+	size := l.Size
+	if size == 0 {
+		size = defaultPiPSize
+	}
+	pipWidth := max(int(float64(width)*size), 1)
+	pipHeight := max(pipWidth*height/width, 1)
+	margin := int(float64(width) * pipMarginRatio)
+	perRow := max((width-margin)/(pipWidth+margin), 1)
+
+	right := l.Position == "" || l.Position == OverlayBottomRight || l.Position == OverlayTopRight
+	bottom := l.Position == "" || l.Position == OverlayBottomLeft || l.Position == OverlayBottomRight
+	for i, sourceID := range others {
+		x := margin + (i%perRow)*(pipWidth+margin)
+		y := margin + (i/perRow)*(pipHeight+margin)
+		if right {
+			x = width - x - pipWidth
+		}
+		if bottom {
+			y = height - y - pipHeight
+		}
+		tiles = append(tiles, Tile{SourceID: sourceID, Position: Position{X: x, Y: y, Width: pipWidth, Height: pipHeight}})
+	}
+	return tiles
+}
+
+// focusTiles はメインの映像ソースを大きく配置し、他の映像ソースを右または下の帯に等分して並べる
+func (l LayoutConfig) focusTiles(sourceIDs []string, width, height int) []Tile {
+	main, others := l.mainSource(sourceIDs)
+	if len(others) == 0 {
+		return []Tile{{SourceID: main, Position: Position{Width: width, Height: height}}}
+	}
+
+	size := l.Size
+	if size == 0 {
+		size = defaultFocusSize
+	}
+
+	tiles := make([]Tile, 0, len(sourceIDs))
+	if l.Position == LayoutSideBottom {
+		stripHeight := int(float64(height) * size)
+		mainHeight := height - stripHeight
+		tiles = append(tiles, Tile{SourceID: main, Position: Position{Width: width, Height: mainHeight}})
+		for i, sourceID := range others {
+			position := cellPosition(i, 0, 1, 1, len(others), 1, width, stripHeight)
+			position.Y += mainHeight
+			tiles = append(tiles, Tile{SourceID: sourceID, Position: position})
+		}
+		return tiles
+	}
+
+	stripWidth := int(float64(width) * size)
+	mainWidth := width - stripWidth
+	tiles = append(tiles, Tile{SourceID: main, Position: Position{Width: mainWidth, Height: height}})
+	for i, sourceID := range others {
+		position := cellPosition(0, i, 1, 1, 1, len(others), stripWidth, height)
+		position.X += mainWidth
+		tiles = append(tiles, Tile{SourceID: sourceID, Position: position})
+	}
+	return tiles
+}
+
+// mainSource はメインの映像ソースと、それ以外の映像ソースを返す
+// メインの映像ソースが見つからない場合も、他の映像ソースの位置が変わらないようメインの位置は空ける
+func (l LayoutConfig) mainSource(sourceIDs []string) (string, []string) {
+	main := l.Main
+	if main == "" {
+		main = sourceIDs[0]
+	}
+
+	others := make([]string, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		if sourceID != main {
+			others = append(others, sourceID)
+		}
+	}
+	return main, others
+}
+
+// cellPosition は格子のセル（結合したセルを含む）の位置を返す
+// 割り切れない場合もセルの間に隙間ができないよう、境界を出力の大きさから計算する
+func cellPosition(col, row, colSpan, rowSpan, cols, rows, width, height int) Position {
+	x0, x1 := col*width/cols, (col+colSpan)*width/cols
+	y0, y1 := row*height/rows, (row+rowSpan)*height/rows
+	return Position{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
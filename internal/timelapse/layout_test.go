@@ -0,0 +1,164 @@
+package timelapse
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// tilePositions はタイルの位置を映像ソースID毎に返す
+func tilePositions(tiles []Tile) map[string]Position {
+	positions := make(map[string]Position, len(tiles))
+	for _, tile := range tiles {
+		positions[tile.SourceID] = tile.Position
+	}
+	return positions
+}
+
+func TestLayout_Auto(t *testing.T) {
+	tiles := LayoutConfig{}.Tiles([]string{"a", "b", "c", "d", "e"}, 600, 400)
+	if len(tiles) != 5 {
+		t.Fatalf("Expected 5 tiles, got %d", len(tiles))
+	}
+
+	// 5つの映像ソースは3列2行に名前順で配置する
+	positions := tilePositions(tiles)
+	if positions["a"] != (Position{X: 0, Y: 0, Width: 200, Height: 200}) {
+		t.Errorf("Unexpected position of a: %+v", positions["a"])
+	}
+	if positions["e"] != (Position{X: 200, Y: 200, Width: 200, Height: 200}) {
+		t.Errorf("Unexpected position of e: %+v", positions["e"])
+	}
+}
+
+func TestLayout_Grid(t *testing.T) {
+	layout := LayoutConfig{
+		Type: LayoutGrid,
+		Cols: 3,
+		Rows: 2,
+		Cells: []LayoutCell{
+			{Source: "entrance", Col: 0, Row: 0, ColSpan: 2, RowSpan: 2},
+			{Source: "garage", Col: 2, Row: 1},
+		},
+	}
+	if err := layout.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 指定のない映像ソースは空いたセルに配置し、入りきらない映像ソースは表示しない
+	positions := tilePositions(layout.Tiles([]string{"entrance", "garage", "lobby", "office"}, 300, 200))
+	want := map[string]Position{
+		"entrance": {X: 0, Y: 0, Width: 200, Height: 200},
+		"garage":   {X: 200, Y: 100, Width: 100, Height: 100},
+		"lobby":    {X: 200, Y: 0, Width: 100, Height: 100},
+	}
+	for sourceID, position := range want {
+		if positions[sourceID] != position {
+			t.Errorf("Position of %s = %+v, want %+v", sourceID, positions[sourceID], position)
+		}
+	}
+	if _, ok := positions["office"]; ok {
+		t.Error("Expected office not to be placed")
+	}
+}
+
+func TestLayout_PiP(t *testing.T) {
+	tests := []struct {
+		position string
+		want     Position
+	}{
+		{"", Position{X: 444, Y: 294, Width: 150, Height: 100}},
+		{OverlayTopLeft, Position{X: 6, Y: 6, Width: 150, Height: 100}},
+		{OverlayBottomLeft, Position{X: 6, Y: 294, Width: 150, Height: 100}},
+	}
+
+	for _, tt := range tests {
+		layout := LayoutConfig{Type: LayoutPiP, Main: "lobby", Position: tt.position}
+		tiles := layout.Tiles([]string{"entrance", "garage", "lobby"}, 600, 400)
+
+		// メインの映像ソースを全体に配置し、小窓を後に描画する
+		if tiles[0].SourceID != "lobby" || tiles[0].Position != (Position{Width: 600, Height: 400}) {
+			t.Errorf("%q: unexpected main tile %+v", tt.position, tiles[0])
+		}
+		if tiles[1].SourceID != "entrance" || tiles[1].Position != tt.want {
+			t.Errorf("%q: first window = %+v, want %+v", tt.position, tiles[1], tt.want)
+		}
+	}
+}
+
+func TestLayout_Focus(t *testing.T) {
+	sourceIDs := []string{"a", "b", "c"}
+
+	positions := tilePositions(LayoutConfig{Type: LayoutFocus, Main: "b"}.Tiles(sourceIDs, 800, 400))
+	if positions["b"] != (Position{Width: 600, Height: 400}) {
+		t.Errorf("Unexpected main position %+v", positions["b"])
+	}
+	if positions["a"] != (Position{X: 600, Y: 0, Width: 200, Height: 200}) || positions["c"] != (Position{X: 600, Y: 200, Width: 200, Height: 200}) {
+		t.Errorf("Unexpected strip positions a=%+v c=%+v", positions["a"], positions["c"])
+	}
+
+	positions = tilePositions(LayoutConfig{Type: LayoutFocus, Position: LayoutSideBottom, Size: 0.5}.Tiles(sourceIDs, 800, 400))
+	if positions["a"] != (Position{Width: 800, Height: 200}) {
+		t.Errorf("Unexpected main position %+v", positions["a"])
+	}
+	if positions["c"] != (Position{X: 400, Y: 200, Width: 400, Height: 200}) {
+		t.Errorf("Unexpected strip position %+v", positions["c"])
+	}
+}
+
+func TestLayout_MissingMain(t *testing.T) {
+	// メインの映像ソースがなくても他の映像ソースの位置は変わらない
+	layout := LayoutConfig{Type: LayoutFocus, Main: "lobby"}
+	with := tilePositions(layout.Tiles([]string{"entrance", "lobby"}, 800, 400))
+	without := tilePositions(layout.Tiles([]string{"entrance"}, 800, 400))
+	if with["entrance"] != without["entrance"] {
+		t.Errorf("Position changed without main: %+v -> %+v", with["entrance"], without["entrance"])
+	}
+}
+
+func TestLayout_Validate(t *testing.T) {
+	invalid := []LayoutConfig{
+		{Type: "mosaic"},
+		{Type: LayoutGrid},
+		{Type: LayoutGrid, Cols: 2, Rows: 2, Cells: []LayoutCell{{Source: "a", Col: 1, ColSpan: 2}}},
+		{Type: LayoutGrid, Cols: 2, Rows: 2, Cells: []LayoutCell{{Source: "a", RowSpan: 2}, {Source: "b", Row: 1}}},
+		{Type: LayoutGrid, Cols: 2, Rows: 2, Cells: []LayoutCell{{Source: "a"}, {Source: "a", Col: 1}}},
+		{Type: LayoutPiP, Position: "center"},
+		{Type: LayoutPiP, Size: 1},
+		{Type: LayoutFocus, Position: "left"},
+	}
+	for _, layout := range invalid {
+		if err := layout.Validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", layout)
+		}
+	}
+}
+
+func TestConfig_Layouts(t *testing.T) {
+	config := DefaultConfig()
+	config.Layouts = map[string]LayoutConfig{
+		"pip":   {Type: LayoutPiP},
+		"focus": {Type: LayoutFocus},
+	}
+
+	if names := config.LayoutNames(); !slices.Equal(names, []string{"auto", "focus", "pip"}) {
+		t.Errorf("LayoutNames = %q", names)
+	}
+	if err := ValidateLayouts(config); err != nil {
+		t.Errorf("Expected layouts to be valid: %v", err)
+	}
+
+	if _, err := config.LayoutByName("lobby"); !errors.Is(err, ErrLayoutNotFound) {
+		t.Errorf("Expected ErrLayoutNotFound, got %v", err)
+	}
+	config.Layout = "lobby"
+	if err := ValidateLayouts(config); err == nil {
+		t.Error("Expected unknown active layout to be rejected")
+	}
+
+	config.Layout = ""
+	config.Layouts = map[string]LayoutConfig{"auto": {Type: LayoutGrid, Cols: 1, Rows: 1}}
+	if err := ValidateLayouts(config); err == nil {
+		t.Error("Expected reserved layout name to be rejected")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -18,11 +19,15 @@ type Manager interface {
 	Stop(ctx context.Context) error
 
 	// データ取得
-	GetTimelapseVideos() ([]Video, error)
+	GetTimelapseVideos(filter VideoFilter) ([]Video, error)
+	SeekVideo(sourceID string, at time.Time) (SeekResult, error)
 	GetTimelapseStatus() (StatusInfo, error)
+	GetFrames(filter FrameFilter) ([]StoredFrame, error)
+	GetFrame(sourceID string, at time.Time) (StoredFrame, []byte, error)
 
-	// 設定取得
+	// 設定取得・変更
 	GetConfig() Config
+	SetLayout(name string) error
 }
 
 // StatusInfo はタイムラプスシステムの状態情報
@@ -34,12 +39,16 @@ type StatusInfo struct {
 	CurrentVideo    string    `json:"current_video"`
 	FrameBufferSize int       `json:"frame_buffer_size"`
 	LastUpdate      time.Time `json:"last_update"`
+
+	// Encodes は開始してからの動画エンコードの統計（メトリクス用）
+	Encodes EncodeStats `json:"-"`
 }
 
 // DefaultManager はTimelapseManagerのデフォルト実装
 type DefaultManager struct {
 	cameraManager camera.Manager
 	capture       *Capture
+	frameStore    *FrameStore // 保存済みのフレームの参照用（キャプチャの停止中も参照できる）
 	config        Config
 	outputDir     string
 	mu            sync.RWMutex
@@ -51,6 +60,7 @@ type DefaultManager struct {
 func NewDefaultManager(cameraManager camera.Manager, outputDir string, config Config) *DefaultManager {
 	return &DefaultManager{
 		cameraManager: cameraManager,
+		frameStore:    NewFrameStore(FramesDir(outputDir), config.RetentionDays, config.MaxFrameStorageBytes()),
 		outputDir:     outputDir,
 		config:        config,
 	}
@@ -95,7 +105,11 @@ func (m *DefaultManager) Start(ctx context.Context) error {
 		return fmt.Errorf("タイムラプスキャプチャの開始に失敗: %w", err)
 	}
 
-	log.Printf("タイムラプスマネージャーを開始しました (%d個の映像ソースを結合)", len(activeVideoSources))
+	if m.config.PerSourceEnabled() {
+		log.Printf("タイムラプスマネージャーを開始しました (%d個の映像ソース、出力: %s)", len(activeVideoSources), m.config.Output)
+	} else {
+		log.Printf("タイムラプスマネージャーを開始しました (%d個の映像ソースを結合)", len(activeVideoSources))
+	}
 	return nil
 }
 
@@ -120,8 +134,8 @@ func (m *DefaultManager) Stop(ctx context.Context) error {
 	return nil
 }
 
-// GetTimelapseVideos は結合タイムラプス動画一覧を取得する
-func (m *DefaultManager) GetTimelapseVideos() ([]Video, error) {
+// GetTimelapseVideos はタイムラプス動画（結合した動画・映像ソース毎の動画）の一覧を取得する
+func (m *DefaultManager) GetTimelapseVideos(filter VideoFilter) ([]Video, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -129,7 +143,60 @@ func (m *DefaultManager) GetTimelapseVideos() ([]Video, error) {
 		return []Video{}, nil
 	}
 
-	return m.capture.GetVideos()
+	return m.capture.GetVideos(filter)
+}
+
+// SeekVideo は指定した時刻を撮影したタイムラプス動画と再生位置を取得する
+// sourceID が空文字の場合は結合した動画、それ以外は映像ソース毎の動画を対象とする
+func (m *DefaultManager) SeekVideo(sourceID string, at time.Time) (SeekResult, error) {
+	videos, err := m.GetTimelapseVideos(VideoFilter{SourceID: sourceID})
+	if err != nil {
+		return SeekResult{}, err
+	}
+
+	name := VideoFilename(sourceID, at.Local())
+	for _, video := range videos {
+		if filepath.Base(video.FilePath) != name {
+			continue
+		}
+
+		index, err := OpenVideoIndex(video.FilePath)
+		if err != nil {
+			return SeekResult{}, err
+		}
+		defer func() {
+			_ = index.Close()
+		}()
+
+		n, timestamp, err := index.Seek(at)
+		if err != nil {
+			return SeekResult{}, err
+		}
+		return SeekResult{Video: video, Offset: FrameOffset(n), FrameTimestamp: timestamp}, nil
+	}
+	return SeekResult{}, ErrVideoNotFound
+}
+
+// GetFrames は保存済みのフレームを撮影順に取得する（タイムラプスの任意時点へのジャンプ用）
+func (m *DefaultManager) GetFrames(filter FrameFilter) ([]StoredFrame, error) {
+	return m.frameStore.List(filter)
+}
+
+// GetFrame は指定した時刻に最も近い保存済みのフレームとそのJPEGデータを取得する
+func (m *DefaultManager) GetFrame(sourceID string, at time.Time) (StoredFrame, []byte, error) {
+	frame, err := m.frameStore.Nearest(sourceID, at)
+	if err != nil {
+		return StoredFrame{}, nil, err
+	}
+
+	data, err := os.ReadFile(frame.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return StoredFrame{}, nil, ErrFrameNotFound // 保持期間を過ぎて削除された
+		}
+		return StoredFrame{}, nil, fmt.Errorf("フレームの読み込みに失敗: %w", err)
+	}
+	return frame, data, nil
 }
 
 // GetTimelapseStatus はタイムラプスシステムの状態を取得する
@@ -144,7 +211,7 @@ func (m *DefaultManager) GetTimelapseStatus() (StatusInfo, error) {
 	}
 
 	if m.capture != nil {
-		videos, err := m.capture.GetVideos()
+		videos, err := m.capture.GetVideos(VideoFilter{})
 		if err == nil {
 			status.TotalVideos = len(videos)
 
@@ -159,6 +226,7 @@ func (m *DefaultManager) GetTimelapseStatus() (StatusInfo, error) {
 		status.CurrentVideo = captureStatus.CurrentVideo
 		status.FrameBufferSize = captureStatus.FrameBufferSize
 		status.LastUpdate = captureStatus.LastUpdate
+		status.Encodes = captureStatus.Encodes
 	}
 
 	// アクティブソース数を取得
@@ -178,3 +246,25 @@ func (m *DefaultManager) GetConfig() Config {
 	defer m.mu.RUnlock()
 	return m.config
 }
+
+// SetLayout は結合フレームに使用するレイアウトを変更する
+// 変更は実行中のみ有効で、設定ファイルには保存しない
+func (m *DefaultManager) SetLayout(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	layout, err := m.config.LayoutByName(name)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = LayoutAuto
+	}
+	m.config.Layout = name
+	if m.capture != nil {
+		m.capture.SetLayout(name, layout)
+	}
+	log.Printf("タイムラプスのレイアウトを変更しました: %s", name)
+	return nil
+}
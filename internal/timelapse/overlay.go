@@ -0,0 +1,272 @@
+package timelapse
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// overlayTimeLayout は描画する撮影時刻の形式
+	overlayTimeLayout = "2006-01-02 15:04:05"
+
+	// minOverlayFontSize は文字の大きさを画像の高さに合わせる場合の最小値
+	minOverlayFontSize = 10
+
+	// overlayFontSizeRatio は文字の大きさを画像の高さに合わせる場合の比率（高さの1/30）
+	overlayFontSizeRatio = 30
+)
+
+var (
+	// overlayBackground は文字の背景の色（半透明の黒）
+	overlayBackground = image.NewUniform(color.RGBA{A: 160})
+
+	// overlayShadow は背景がない場合に文字を読みやすくする影の色
+	overlayShadow = image.NewUniform(color.Black)
+
+	// builtinFont は内蔵フォント（Go Mono Bold）
+	// ASCII 以外の多くの文字（日本語等）は含まないため、必要な場合は font_file を指定する
+	builtinFont = sync.OnceValues(func() (*opentype.Font, error) {
+		return opentype.Parse(gomonobold.TTF)
+	})
+
+	// fontFiles は読み込んだフォントファイル（パス毎）
+	fontFiles sync.Map
+)
+
+// OverlayLabel はフレームに描画する内容
+type OverlayLabel struct {
+	SourceID  string    // 映像ソースID（カメラ名をフォントで描画できない場合に代わりに描画する）
+	Name      string    // カメラ名
+	Timestamp time.Time // 撮影時刻
+}
+
+// Overlay はフレームに撮影時刻・カメラ名・任意の文字列を描画する
+type Overlay struct {
+	config OverlayConfig
+	font   *opentype.Font
+
+	mu    sync.Mutex        // font.Face は並行して使えないため描画を直列化する
+	faces map[int]font.Face // 文字の大きさ毎のフォント
+	buf   sfnt.Buffer
+}
+
+// NewOverlay は設定のフォントを読み込んで Overlay を作成する
+func NewOverlay(config OverlayConfig) (*Overlay, error) {
+	f, err := loadFont(config.FontFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Overlay{config: config, font: f, faces: make(map[int]font.Face)}, nil
+}
+
+// ValidateOverlay は文字の描画設定を検証する
+func ValidateOverlay(config OverlayConfig) error {
+	if !IsOverlayPosition(config.Position) {
+		return fmt.Errorf("無効な描画位置: %s", config.Position)
+	}
+	if config.FontSize < 0 {
+		return fmt.Errorf("文字の大きさに負の値は指定できません")
+	}
+	if config.FontFile != "" {
+		if _, err := loadFont(config.FontFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadFont はフォントファイルを読み込む（空文字は内蔵フォント）
+// フォントコレクション（.ttc）は最初のフォントを使用する
+func loadFont(path string) (*opentype.Font, error) {
+	if path == "" {
+		f, err := builtinFont()
+		if err != nil {
+			return nil, fmt.Errorf("内蔵フォントの読み込みに失敗: %w", err)
+		}
+		return f, nil
+	}
+	if f, ok := fontFiles.Load(path); ok {
+		return f.(*opentype.Font), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("フォントファイル %s の読み込みに失敗: %w", path, err)
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		collection, collectionErr := opentype.ParseCollection(data)
+		if collectionErr != nil || collection.NumFonts() == 0 {
+			return nil, fmt.Errorf("フォントファイル %s の解析に失敗: %w", path, err)
+		}
+		if f, err = collection.Font(0); err != nil {
+			return nil, fmt.Errorf("フォントファイル %s の解析に失敗: %w", path, err)
+		}
+	}
+
+	fontFiles.Store(path, f)
+	return f, nil
+}
+
+// Draw は画像の指定した範囲（結合フレームのタイル等）に文字を描画する
+// 文字は範囲の外にはみ出さない
+func (o *Overlay) Draw(dst *image.RGBA, rect image.Rectangle, label OverlayLabel) {
+	rect = rect.Intersect(dst.Bounds())
+	if rect.Empty() {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	lines := o.lines(label)
+	if len(lines) == 0 {
+		return
+	}
+
+	size := o.config.FontSize
+	if size == 0 {
+		size = max(rect.Dy()/overlayFontSizeRatio, minOverlayFontSize)
+	}
+	face, err := o.face(size)
+	if err != nil {
+		return // 設定の検証で読み込めることを確認済みのため発生しない
+	}
+
+	metrics := face.Metrics()
+	lineHeight := metrics.Height.Ceil()
+	padding := max(size/4, 2)
+
+	widths := make([]int, len(lines))
+	boxWidth := 0
+	for i, line := range lines {
+		widths[i] = font.MeasureString(face, line).Ceil()
+		boxWidth = max(boxWidth, widths[i])
+	}
+	boxWidth += padding * 2
+	boxHeight := lineHeight*len(lines) + padding*2
+
+	// 描画位置に合わせて文字の四角形を配置する（右側は右揃え）
+	right := o.config.Position == OverlayTopRight || o.config.Position == OverlayBottomRight
+	bottom := o.config.Position == OverlayBottomLeft || o.config.Position == OverlayBottomRight
+	box := image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+boxWidth, rect.Min.Y+boxHeight)
+	if right {
+		box = box.Add(image.Pt(rect.Dx()-boxWidth, 0))
+	}
+	if bottom {
+		box = box.Add(image.Pt(0, rect.Dy()-boxHeight))
+	}
+
+	canvas := dst.SubImage(rect).(*image.RGBA)
+	if o.config.Background {
+		draw.Draw(canvas, box.Intersect(rect), overlayBackground, image.Point{}, draw.Over)
+	}
+
+	drawer := font.Drawer{Dst: canvas, Face: face}
+	for i, line := range lines {
+		x := box.Min.X + padding
+		if right {
+			x = box.Max.X - padding - widths[i]
+		}
+		y := box.Min.Y + padding + lineHeight*i + metrics.Ascent.Ceil()
+
+		if !o.config.Background {
+			drawer.Src = overlayShadow
+			drawer.Dot = fixed.P(x+1, y+1)
+			drawer.DrawString(line)
+		}
+		drawer.Src = image.White
+		drawer.Dot = fixed.P(x, y)
+		drawer.DrawString(line)
+	}
+}
+
+// DrawJPEG はJPEGフレーム全体に文字を描画して再エンコードする
+func (o *Overlay) DrawJPEG(data []byte, label OverlayLabel, quality int) ([]byte, error) {
+	src, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("JPEGのデコードに失敗: %w", err)
+	}
+
+	bounds := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(img, img.Bounds(), src, bounds.Min, draw.Src)
+	o.Draw(img, img.Bounds(), label)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("JPEGのエンコードに失敗: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// lines は描画する文字列を行毎に返す（カメラ名、撮影時刻、任意の文字列の順）
+func (o *Overlay) lines(label OverlayLabel) []string {
+	var lines []string
+	if o.config.CameraName {
+		name, ok := o.printable(label.Name)
+		if !ok || name == "" {
+			// フォントで描画できないカメラ名（内蔵フォントの日本語等）は映像ソースIDで代用する
+			if id, idOK := o.printable(label.SourceID); idOK && id != "" {
+				name = id
+			}
+		}
+		if name != "" {
+			lines = append(lines, name)
+		}
+	}
+	if o.config.Timestamp && !label.Timestamp.IsZero() {
+		lines = append(lines, label.Timestamp.Format(overlayTimeLayout))
+	}
+	if o.config.Text != "" {
+		for _, line := range strings.Split(o.config.Text, "\n") {
+			text, _ := o.printable(line)
+			lines = append(lines, text)
+		}
+	}
+	return lines
+}
+
+// printable はフォントにない文字を "?" に置き換えた文字列と、全ての文字を描画できるかを返す
+func (o *Overlay) printable(text string) (string, bool) {
+	ok := true
+	replaced := strings.Map(func(r rune) rune {
+		if index, err := o.font.GlyphIndex(&o.buf, r); err != nil || index == 0 {
+			ok = false
+			return '?'
+		}
+		return r
+	}, text)
+	return replaced, ok
+}
+
+// face は指定した大きさのフォントを返す
+func (o *Overlay) face(size int) (font.Face, error) {
+	if face, ok := o.faces[size]; ok {
+		return face, nil
+	}
+	face, err := opentype.NewFace(o.font, &opentype.FaceOptions{
+		Size:    float64(size),
+		DPI:     72, // Size をピクセル数として扱う
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("フォントの作成に失敗: %w", err)
+	}
+	o.faces[size] = face
+	return face, nil
+}
@@ -0,0 +1,120 @@
+package timelapse
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"slices"
+	"testing"
+	"time"
+)
+
+// brightPixels は範囲内の明るい（文字を描画した）ピクセル数を返す
+func brightPixels(img *image.RGBA, rect image.Rectangle) int {
+	count := 0
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if img.RGBAAt(x, y).R > 200 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func newBlackImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	return img
+}
+
+func TestOverlay_Position(t *testing.T) {
+	label := OverlayLabel{SourceID: "cam", Name: "Office", Timestamp: time.Date(2024, 1, 2, 14, 32, 0, 0, time.Local)}
+
+	tests := []struct {
+		position string
+		corner   image.Rectangle
+	}{
+		{OverlayTopLeft, image.Rect(0, 0, 320, 120)},
+		{OverlayTopRight, image.Rect(320, 0, 640, 120)},
+		{OverlayBottomLeft, image.Rect(0, 360, 320, 480)},
+		{OverlayBottomRight, image.Rect(320, 360, 640, 480)},
+	}
+
+	for _, tt := range tests {
+		overlay, err := NewOverlay(OverlayConfig{Enabled: true, Timestamp: true, CameraName: true, Position: tt.position, FontSize: 16})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		img := newBlackImage(640, 480)
+		overlay.Draw(img, img.Bounds(), label)
+
+		inCorner := brightPixels(img, tt.corner)
+		if inCorner == 0 {
+			t.Errorf("%s: expected text in %v", tt.position, tt.corner)
+		}
+		if total := brightPixels(img, img.Bounds()); total != inCorner {
+			t.Errorf("%s: expected all text in %v, %d of %d pixels outside", tt.position, tt.corner, total-inCorner, total)
+		}
+	}
+}
+
+func TestOverlay_ClipsToTile(t *testing.T) {
+	overlay, err := NewOverlay(OverlayConfig{Enabled: true, CameraName: true, Text: "a long custom text that does not fit", Position: OverlayTopRight, FontSize: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 右半分のタイルに描画した文字は左半分にはみ出さない
+	img := newBlackImage(400, 200)
+	tile := image.Rect(200, 0, 400, 200)
+	overlay.Draw(img, tile, OverlayLabel{SourceID: "cam", Name: "Lobby"})
+
+	if brightPixels(img, tile) == 0 {
+		t.Error("Expected text in the tile")
+	}
+	if n := brightPixels(img, image.Rect(0, 0, 200, 200)); n != 0 {
+		t.Errorf("Expected no text outside the tile, got %d pixels", n)
+	}
+}
+
+func TestOverlay_Lines(t *testing.T) {
+	overlay, err := NewOverlay(OverlayConfig{Enabled: true, Timestamp: true, CameraName: true, Text: "Site A\n第2倉庫"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timestamp := time.Date(2024, 1, 2, 14, 32, 5, 0, time.Local)
+	got := overlay.lines(OverlayLabel{SourceID: "entrance", Name: "玄関", Timestamp: timestamp})
+	want := []string{"entrance", "2024-01-02 14:32:05", "Site A", "?2??"}
+	if !slices.Equal(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+
+	// 描画する項目を無効にした場合は描画しない
+	overlay, err = NewOverlay(OverlayConfig{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := overlay.lines(OverlayLabel{SourceID: "cam", Name: "cam", Timestamp: timestamp}); len(lines) != 0 {
+		t.Errorf("Expected no lines, got %q", lines)
+	}
+}
+
+func TestValidateOverlay(t *testing.T) {
+	if err := ValidateOverlay(DefaultConfig().Overlay); err != nil {
+		t.Errorf("Expected default overlay to be valid: %v", err)
+	}
+
+	invalid := []OverlayConfig{
+		{Position: "center"},
+		{FontSize: -1},
+		{FontFile: "/nonexistent/font.ttf"},
+	}
+	for _, config := range invalid {
+		if err := ValidateOverlay(config); err == nil {
+			t.Errorf("Expected %+v to be rejected", config)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package timelapse
 
 import (
 	"time"
+
+	"senrigan/internal/imaging"
 )
 
 // SourceFrame は単一映像ソースのフレームデータ
@@ -22,20 +24,88 @@ type CombinedFrame struct {
 
 // Config はタイムラプス設定
 type Config struct {
-	Enabled         bool          `json:"enabled"`          // 有効/無効
-	CaptureInterval time.Duration `json:"capture_interval"` // 撮影間隔 (デフォルト: 2秒)
-	UpdateInterval  time.Duration `json:"update_interval"`  // 動画更新間隔 (デフォルト: 1時間)
-	OutputFormat    string        `json:"output_format"`    // 出力フォーマット ("mp4")
-	Quality         int           `json:"quality"`          // 動画品質 (1-5)
-	Resolution      Resolution    `json:"resolution"`       // 出力解像度
-	MaxFrameBuffer  int           `json:"max_frame_buffer"` // 最大バッファサイズ
-	RetentionDays   int           `json:"retention_days"`   // 保持期間（日数）
+	Enabled         bool                    `json:"enabled" yaml:"enabled"`                           // 有効/無効
+	CaptureInterval time.Duration           `json:"capture_interval" yaml:"capture_interval"`         // 撮影間隔 (デフォルト: 2秒)
+	UpdateInterval  time.Duration           `json:"update_interval" yaml:"update_interval"`           // 動画更新間隔 (デフォルト: 1時間)
+	OutputFormat    string                  `json:"output_format" yaml:"output_format"`               // 出力フォーマット ("mp4")
+	Quality         int                     `json:"quality" yaml:"quality"`                           // 動画品質 (1-5)
+	Resolution      Resolution              `json:"resolution" yaml:"resolution"`                     // 出力解像度
+	MaxFrameBuffer  int                     `json:"max_frame_buffer" yaml:"max_frame_buffer"`         // 動画に追加していないフレームの上限（超えると更新間隔を待たずに更新）
+	RetentionDays   int                     `json:"retention_days" yaml:"retention_days"`             // 保存したフレームの保持期間（日数、0は無期限）
+	MaxFrameStorage int                     `json:"max_frame_storage_mb" yaml:"max_frame_storage_mb"` // 保存したフレームの合計サイズの上限（MB、0は無制限、超えると古い日付から削除）
+	Output          string                  `json:"output" yaml:"output"`                             // 出力する動画 ("combined", "per_source", "both")
+	Overlay         OverlayConfig           `json:"overlay" yaml:"overlay"`                           // フレームに描画する文字
+	Layout          string                  `json:"layout" yaml:"layout"`                             // 結合フレームに使用するレイアウト名（空文字・"auto" は自動の格子）
+	Layouts         map[string]LayoutConfig `json:"layouts" yaml:"layouts"`                           // 名前付きのレイアウト
+	Scale           string                  `json:"scale" yaml:"scale"`                               // タイルとアスペクト比が異なる映像の拡大縮小 ("fit", "fill", "stretch")
+	Resampling      string                  `json:"resampling" yaml:"resampling"`                     // 拡大縮小の補間フィルタ ("nearest", "bilinear", "catmull_rom")
+}
+
+// OverlayConfig はフレームに描画する文字（撮影時刻・カメラ名・任意の文字列）の設定
+// 結合フレームではタイル毎、映像ソース毎のフレームではフレーム全体に描画する
+type OverlayConfig struct {
+	Enabled    bool   `json:"enabled" yaml:"enabled"`         // 有効/無効
+	Timestamp  bool   `json:"timestamp" yaml:"timestamp"`     // 撮影時刻を描画する
+	CameraName bool   `json:"camera_name" yaml:"camera_name"` // カメラ名を描画する
+	Text       string `json:"text" yaml:"text"`               // 任意の文字列（空文字は描画しない）
+	Position   string `json:"position" yaml:"position"`       // 描画位置 ("top_left", "top_right", "bottom_left", "bottom_right")
+	FontSize   int    `json:"font_size" yaml:"font_size"`     // 文字の大きさ（ピクセル、0は画像の高さに合わせる）
+	Background bool   `json:"background" yaml:"background"`   // 文字の背景に半透明の四角形を描画する
+	FontFile   string `json:"font_file" yaml:"font_file"`     // TrueType/OpenTypeフォントファイル（空文字は内蔵フォント）
+}
+
+// 文字の描画位置
+const (
+	OverlayTopLeft     = "top_left"
+	OverlayTopRight    = "top_right"
+	OverlayBottomLeft  = "bottom_left"
+	OverlayBottomRight = "bottom_right"
+)
+
+// IsOverlayPosition は文字の描画位置がサポートされているか判定する（空文字は左上）
+func IsOverlayPosition(position string) bool {
+	switch position {
+	case "", OverlayTopLeft, OverlayTopRight, OverlayBottomLeft, OverlayBottomRight:
+		return true
+	}
+	return false
+}
+
+// 出力する動画の種類
+const (
+	OutputCombined  = "combined"   // 全ての映像ソースを結合した動画のみ
+	OutputPerSource = "per_source" // 映像ソース毎の動画（元の解像度）のみ
+	OutputBoth      = "both"       // 結合した動画と映像ソース毎の動画の両方
+)
+
+// IsOutput は出力する動画の種類がサポートされているか判定する（空文字は結合した動画）
+func IsOutput(output string) bool {
+	switch output {
+	case "", OutputCombined, OutputPerSource, OutputBoth:
+		return true
+	}
+	return false
+}
+
+// CombinedEnabled は全ての映像ソースを結合した動画を出力するか判定する
+func (c Config) CombinedEnabled() bool {
+	return c.Output == "" || c.Output == OutputCombined || c.Output == OutputBoth
+}
+
+// PerSourceEnabled は映像ソース毎の動画を出力するか判定する
+func (c Config) PerSourceEnabled() bool {
+	return c.Output == OutputPerSource || c.Output == OutputBoth
+}
+
+// MaxFrameStorageBytes は保存したフレームの合計サイズの上限をバイト数で返す（0は無制限）
+func (c Config) MaxFrameStorageBytes() int64 {
+	return int64(c.MaxFrameStorage) * 1024 * 1024
 }
 
 // Resolution は解像度設定
 type Resolution struct {
-	Width  int `json:"width"`
-	Height int `json:"height"`
+	Width  int `json:"width" yaml:"width"`
+	Height int `json:"height" yaml:"height"`
 }
 
 // Video はタイムラプス動画情報
@@ -49,6 +119,26 @@ type Video struct {
 	EndTime     time.Time     `json:"end_time"`     // 録画終了時刻
 	Status      Status        `json:"status"`       // ステータス
 	SourceCount int           `json:"source_count"` // 結合された映像ソース数
+	SourceID    string        `json:"source_id"`    // 映像ソースID（結合した動画は空文字）
+}
+
+// SeekResult は指定した時刻を撮影したタイムラプス動画の再生位置
+type SeekResult struct {
+	Video          Video         // 動画
+	Offset         time.Duration // 再生位置
+	FrameTimestamp time.Time     // 再生位置のフレームの撮影時刻（指定した時刻に最も近いフレーム）
+}
+
+// FrameFilter は保存したフレーム一覧の絞り込み条件
+type FrameFilter struct {
+	SourceID string    // 映像ソースID（空文字は結合フレーム）
+	From     time.Time // この時刻以降に撮影したフレーム
+	To       time.Time // この時刻以前に撮影したフレーム
+}
+
+// VideoFilter はタイムラプス動画一覧の絞り込み条件
+type VideoFilter struct {
+	SourceID string // 指定した映像ソースの動画のみ（空文字は全ての動画）
 }
 
 // Status はタイムラプスのステータス
@@ -74,7 +164,19 @@ func DefaultConfig() Config {
 			Width:  1920,
 			Height: 1080,
 		},
-		MaxFrameBuffer: 60, // 1分間分（2秒間隔）
-		RetentionDays:  30,
+		MaxFrameBuffer:  60, // 1分間分（2秒間隔）
+		RetentionDays:   30,
+		MaxFrameStorage: 10 * 1024, // 10GB
+		Output:          OutputCombined,
+		Layout:          LayoutAuto,
+		Scale:           imaging.ScaleFit,
+		Resampling:      imaging.ResampleBilinear,
+		// 有効にした場合は撮影時刻とカメラ名を左上に描画する
+		Overlay: OverlayConfig{
+			Timestamp:  true,
+			CameraName: true,
+			Position:   OverlayTopLeft,
+			Background: true,
+		},
 	}
 }
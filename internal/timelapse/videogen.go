@@ -3,16 +3,35 @@ package timelapse
 import (
 	"context"
 	"fmt"
+	"image"
+	"image/jpeg"
+	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// EncodeStats は動画エンコード（ffmpegによる生成・延長）の統計
+type EncodeStats struct {
+	Count         int           // 成功したエンコードの回数
+	Failures      int           // 失敗したエンコードの回数
+	TotalDuration time.Duration // 成功したエンコードの合計時間
+	LastDuration  time.Duration // 最後に成功したエンコードの時間
+}
+
+// outputFrameRate は動画のフレームレート（保存したフレーム1枚が動画の1フレームになる）
+const outputFrameRate = 30
+
 // VideoGenerator は動画生成を担当する
 type VideoGenerator struct {
 	tempDir string // 一時ファイル用ディレクトリ
+
+	statsMu sync.Mutex
+	stats   EncodeStats
 }
 
 // NewVideoGenerator は新しいVideoGeneratorを作成する
@@ -22,13 +41,13 @@ func NewVideoGenerator() *VideoGenerator {
 	}
 }
 
-// ExtendVideo は既存の動画にフレームを追加して延長する
-func (vg *VideoGenerator) ExtendVideo(videoPath string, frames []CombinedFrame, config Config) error {
+// ExtendVideo は既存の動画にディスクに保存したフレームを追加して延長する
+func (vg *VideoGenerator) ExtendVideo(videoPath string, frames []StoredFrame, config Config) error {
 	if len(frames) == 0 {
 		return nil // フレームがない場合は何もしない
 	}
 
-	// 一時ディレクトリを作成
+	// 画像リスト用の一時ディレクトリを作成
 	sessionDir := filepath.Join(vg.tempDir, fmt.Sprintf("session_%d", time.Now().UnixNano()))
 	if err := os.MkdirAll(sessionDir, 0755); err != nil {
 		return fmt.Errorf("一時ディレクトリの作成に失敗: %w", err)
@@ -37,52 +56,63 @@ func (vg *VideoGenerator) ExtendVideo(videoPath string, frames []CombinedFrame,
 		_ = os.RemoveAll(sessionDir) // cleanup中のエラーは無視
 	}()
 
-	// フレームを一時画像ファイルとして保存
-	imageFiles, err := vg.saveFramesAsImages(sessionDir, frames)
-	if err != nil {
-		return fmt.Errorf("フレーム画像の保存に失敗: %w", err)
+	// 保存済みのフレームをそのまま入力に使う（FFmpegが画像リストの場所に依存しないよう絶対パスに変換）
+	imageFiles := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		path, err := filepath.Abs(frame.Path)
+		if err != nil {
+			return fmt.Errorf("フレームの絶対パス化に失敗: %w", err)
+		}
+		imageFiles = append(imageFiles, path)
 	}
 
 	// 動画を生成または延長
+	started := time.Now()
 	if _, err := os.Stat(videoPath); os.IsNotExist(err) {
-		// 新規動画作成
-		return vg.createNewVideo(videoPath, imageFiles, config)
+		// 新規動画作成（最初のフレームの解像度にする）
+		size, err := frameSize(imageFiles[0])
+		if err == nil {
+			err = vg.createNewVideo(videoPath, sessionDir, imageFiles, config, size)
+		}
+		vg.recordEncode(time.Since(started), err)
+		return err
 	}
 	// 既存動画に追加
-	return vg.appendToVideo(videoPath, imageFiles, config)
+	err := vg.appendToVideo(videoPath, sessionDir, imageFiles, config)
+	vg.recordEncode(time.Since(started), err)
+	return err
 }
 
-// saveFramesAsImages はフレームを一時画像ファイルとして保存する
-func (vg *VideoGenerator) saveFramesAsImages(sessionDir string, frames []CombinedFrame) ([]string, error) {
-	// 事前にスライスの容量を確保（prealloc）
-	imageFiles := make([]string, 0, len(frames))
-
-	for i, frame := range frames {
-		if len(frame.ComposedData) == 0 {
-			continue // 空のフレームはスキップ
-		}
-
-		filename := fmt.Sprintf("frame_%06d.jpg", i)
-		filepath := filepath.Join(sessionDir, filename)
-
-		if err := os.WriteFile(filepath, frame.ComposedData, 0644); err != nil {
-			return nil, fmt.Errorf("フレーム画像の保存に失敗 (%s): %w", filename, err)
-		}
+// recordEncode はエンコードの結果を統計に記録する
+func (vg *VideoGenerator) recordEncode(duration time.Duration, err error) {
+	vg.statsMu.Lock()
+	defer vg.statsMu.Unlock()
 
-		imageFiles = append(imageFiles, filepath)
+	if err != nil {
+		vg.stats.Failures++
+		return
 	}
+	vg.stats.Count++
+	vg.stats.TotalDuration += duration
+	vg.stats.LastDuration = duration
+}
 
-	return imageFiles, nil
+// Stats はエンコードの統計を返す
+func (vg *VideoGenerator) Stats() EncodeStats {
+	vg.statsMu.Lock()
+	defer vg.statsMu.Unlock()
+	return vg.stats
 }
 
 // createNewVideo は新しい動画ファイルを作成する
-func (vg *VideoGenerator) createNewVideo(videoPath string, imageFiles []string, config Config) error {
+// 全てのフレームを size の解像度に揃える（アスペクト比が異なるフレームは黒で余白を付ける）
+func (vg *VideoGenerator) createNewVideo(videoPath, sessionDir string, imageFiles []string, config Config, size image.Point) error {
 	if len(imageFiles) == 0 {
 		return fmt.Errorf("画像ファイルがありません")
 	}
 
 	// 画像ファイルリストを作成
-	listFile := filepath.Join(filepath.Dir(imageFiles[0]), "images.txt")
+	listFile := filepath.Join(sessionDir, "images.txt")
 	if err := vg.createImageList(listFile, imageFiles); err != nil {
 		return fmt.Errorf("画像リストの作成に失敗: %w", err)
 	}
@@ -92,7 +122,10 @@ func (vg *VideoGenerator) createNewVideo(videoPath string, imageFiles []string,
 		"-f", "concat",
 		"-safe", "0",
 		"-i", listFile,
-		"-r", "30", // 30fpsで出力
+		"-r", strconv.Itoa(outputFrameRate),
+		// 撮影時刻の記録と対応させるため、n番目の画像を動画のn番目のフレームにする（フレームの重複・欠落をさせない）
+		// 途中で解像度が変わっても（カメラの設定変更・レイアウトの変更等）エンコードできるよう解像度を揃える
+		"-vf", videoFilter(size),
 		"-c:v", "libx264",
 		"-preset", "fast",
 		"-crf", vg.qualityToCRF(config.Quality),
@@ -110,18 +143,28 @@ func (vg *VideoGenerator) createNewVideo(videoPath string, imageFiles []string,
 }
 
 // appendToVideo は既存の動画にフレームを追加する
-func (vg *VideoGenerator) appendToVideo(videoPath string, imageFiles []string, config Config) error {
+func (vg *VideoGenerator) appendToVideo(videoPath, sessionDir string, imageFiles []string, config Config) error {
 	if len(imageFiles) == 0 {
 		return fmt.Errorf("追加する画像ファイルがありません")
 	}
 
+	// 再エンコードなしで結合できるよう、追加する部分を既存の動画と同じ解像度で作成する
+	size, err := probeVideoSize(videoPath)
+	if err != nil {
+		return err
+	}
+	if frame, err := frameSize(imageFiles[0]); err == nil && frame != size {
+		log.Printf("動画 %s と解像度が異なるフレーム（%dx%d）を %dx%d に合わせて追加します",
+			filepath.Base(videoPath), frame.X, frame.Y, size.X, size.Y)
+	}
+
 	// 追加用の一時動画を作成（絶対パスに変換）
 	absVideoPath, err := filepath.Abs(videoPath)
 	if err != nil {
 		return fmt.Errorf("ビデオパスの絶対パス化に失敗: %w", err)
 	}
 	tempVideoPath := absVideoPath + ".temp.mp4"
-	if err := vg.createNewVideo(tempVideoPath, imageFiles, config); err != nil {
+	if err := vg.createNewVideo(tempVideoPath, sessionDir, imageFiles, config, size); err != nil {
 		return fmt.Errorf("一時動画の作成に失敗: %w", err)
 	}
 	defer func() {
@@ -162,20 +205,65 @@ func (vg *VideoGenerator) appendToVideo(videoPath string, imageFiles []string, c
 	return nil
 }
 
+// videoFilter は各フレームを size の解像度に揃えるFFmpegのフィルターを返す
+func videoFilter(size image.Point) string {
+	return fmt.Sprintf("setpts=N/(%d*TB),scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2,setsar=1",
+		outputFrameRate, size.X, size.Y, size.X, size.Y)
+}
+
+// frameSize はJPEGフレームの解像度を動画の解像度として返す
+// yuv420pのため幅・高さを偶数にする
+func frameSize(path string) (image.Point, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return image.Point{}, fmt.Errorf("フレームの読み込みに失敗: %w", err)
+	}
+	defer func() {
+		_ = file.Close() // 読み込みのみのため無視
+	}()
+
+	config, err := jpeg.DecodeConfig(file)
+	if err != nil {
+		return image.Point{}, fmt.Errorf("フレームの解像度の取得に失敗: %w", err)
+	}
+	return evenSize(config.Width, config.Height), nil
+}
+
+// evenSize は幅・高さを2以上の偶数に切り捨てる
+func evenSize(width, height int) image.Point {
+	return image.Pt(max(width/2*2, 2), max(height/2*2, 2))
+}
+
+// probeVideoSize は既存の動画の解像度を取得する
+func probeVideoSize(videoPath string) (image.Point, error) {
+	cmd := exec.Command("ffprobe",
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height",
+		"-of", "csv=p=0:s=x",
+		videoPath,
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		return image.Point{}, fmt.Errorf("動画の解像度の取得に失敗: %w", err)
+	}
+
+	var width, height int
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+		return image.Point{}, fmt.Errorf("動画の解像度を解析できません: %q", strings.TrimSpace(string(output)))
+	}
+	return image.Pt(width, height), nil
+}
+
 // createImageList は画像ファイルリストを作成する
+// 各画像の表示時間は createNewVideo のフィルターで1フレーム分に揃えるため指定しない
 func (vg *VideoGenerator) createImageList(listFile string, imageFiles []string) error {
-	var content string
+	var content strings.Builder
 	for _, imageFile := range imageFiles {
-		// 各フレームを0.033秒（30fpsの逆数）表示
-		content += fmt.Sprintf("file '%s'\nduration 0.033\n", imageFile)
-	}
-
-	// 最後のフレームは追加の表示時間なし
-	if len(imageFiles) > 0 {
-		content += fmt.Sprintf("file '%s'\n", imageFiles[len(imageFiles)-1])
+		fmt.Fprintf(&content, "file '%s'\n", imageFile)
 	}
 
-	return os.WriteFile(listFile, []byte(content), 0644)
+	return os.WriteFile(listFile, []byte(content.String()), 0644)
 }
 
 // qualityToCRF は品質設定をFFmpegのCRF値に変換する
@@ -0,0 +1,40 @@
+package timelapse
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFrameSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frame.jpg")
+	if err := os.WriteFile(path, solidJPEG(t, color.Gray{Y: 128}, 641, 359), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// yuv420pのため幅・高さは偶数に切り捨てる
+	size, err := frameSize(path)
+	if err != nil {
+		t.Fatalf("frameSize failed: %v", err)
+	}
+	if size != image.Pt(640, 358) {
+		t.Errorf("Expected 640x358, got %v", size)
+	}
+
+	if _, err := frameSize(filepath.Join(t.TempDir(), "missing.jpg")); err == nil {
+		t.Error("Expected error for missing frame")
+	}
+}
+
+func TestVideoFilter(t *testing.T) {
+	// 解像度が異なるフレームも既存の動画と同じ解像度に揃える
+	filter := videoFilter(image.Pt(1280, 720))
+	for _, want := range []string{"scale=1280:720:force_original_aspect_ratio=decrease", "pad=1280:720:"} {
+		if !strings.Contains(filter, want) {
+			t.Errorf("Expected %q in filter %q", want, filter)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package timelapse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// videoIndexExt は動画の各フレームの撮影時刻を記録するファイルの拡張子
+	videoIndexExt = ".timestamps"
+
+	// videoIndexLayout は撮影時刻の形式（UTCの固定長のため、ファイル全体を読まずに任意の行を参照できる）
+	videoIndexLayout = "2006-01-02T15:04:05.000Z"
+
+	// videoIndexLineSize は撮影時刻ファイルの1行の長さ（改行を含む）
+	videoIndexLineSize = len(videoIndexLayout) + 1
+)
+
+var (
+	// ErrVideoNotFound は指定した時刻のタイムラプス動画が存在しない場合のエラー
+	ErrVideoNotFound = errors.New("タイムラプス動画が見つかりません")
+
+	// ErrVideoIndexNotFound は動画に撮影時刻の記録がない場合のエラー（記録を開始する前に作成された動画）
+	ErrVideoIndexNotFound = errors.New("動画の撮影時刻の記録がありません")
+)
+
+// VideoIndex は動画の各フレームの撮影時刻を記録したファイル
+// n行目が動画のn番目のフレーム（再生位置 FrameOffset(n)）の撮影時刻で、動画と同じディレクトリに
+// timelapse_YYYY-MM-DD.timestamps のように保存する
+type VideoIndex struct {
+	file  *os.File
+	count int
+}
+
+// videoIndexPath は動画ファイルに対応する撮影時刻ファイルのパスを返す
+func videoIndexPath(videoPath string) string {
+	return strings.TrimSuffix(videoPath, ".mp4") + videoIndexExt
+}
+
+// FrameOffset は動画のn番目のフレームの再生位置を返す
+func FrameOffset(n int) time.Duration {
+	return time.Duration(n) * time.Second / outputFrameRate
+}
+
+// OpenVideoIndex は動画ファイルの撮影時刻の記録を開く
+func OpenVideoIndex(videoPath string) (*VideoIndex, error) {
+	file, err := os.Open(videoIndexPath(videoPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrVideoIndexNotFound
+		}
+		return nil, fmt.Errorf("撮影時刻の記録を開けません: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("撮影時刻の記録の情報取得に失敗: %w", err)
+	}
+
+	// 書き込み途中の行は含めない
+	return &VideoIndex{file: file, count: int(info.Size()) / videoIndexLineSize}, nil
+}
+
+// Close は撮影時刻の記録を閉じる
+func (vi *VideoIndex) Close() error {
+	return vi.file.Close()
+}
+
+// FrameCount は記録した動画のフレーム数を返す
+func (vi *VideoIndex) FrameCount() int {
+	return vi.count
+}
+
+// Duration は記録したフレームの動画の長さを返す
+func (vi *VideoIndex) Duration() time.Duration {
+	return FrameOffset(vi.count)
+}
+
+// Timestamp は動画のn番目のフレームの撮影時刻を返す
+func (vi *VideoIndex) Timestamp(n int) (time.Time, error) {
+	if n < 0 || n >= vi.count {
+		return time.Time{}, fmt.Errorf("フレーム番号 %d は範囲外です（フレーム数: %d）", n, vi.count)
+	}
+
+	line := make([]byte, videoIndexLineSize)
+	if _, err := vi.file.ReadAt(line, int64(n*videoIndexLineSize)); err != nil && !errors.Is(err, io.EOF) {
+		return time.Time{}, fmt.Errorf("撮影時刻の記録の読み込みに失敗: %w", err)
+	}
+	timestamp, err := time.Parse(videoIndexLayout, string(line[:len(videoIndexLayout)]))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("撮影時刻の記録の解析に失敗: %w", err)
+	}
+	return timestamp.Local(), nil
+}
+
+// Seek は指定した時刻に最も近い撮影時刻のフレームの番号と撮影時刻を返す
+// 撮影時刻は昇順のため二分探索する
+func (vi *VideoIndex) Seek(at time.Time) (int, time.Time, error) {
+	if vi.count == 0 {
+		return 0, time.Time{}, ErrVideoIndexNotFound
+	}
+
+	var searchErr error
+	n := sort.Search(vi.count, func(i int) bool {
+		timestamp, err := vi.Timestamp(i)
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return !timestamp.Before(at)
+	})
+	if searchErr != nil {
+		return 0, time.Time{}, searchErr
+	}
+
+	// 指定した時刻の直後のフレームと直前のフレームのうち近い方
+	if n == vi.count {
+		n--
+	}
+	timestamp, err := vi.Timestamp(n)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if n > 0 {
+		previous, err := vi.Timestamp(n - 1)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		if absDuration(at.Sub(previous)) <= absDuration(timestamp.Sub(at)) {
+			return n - 1, previous, nil
+		}
+	}
+	return n, timestamp, nil
+}
+
+// writeVideoIndex は動画に追加したフレームの撮影時刻を記録する
+// newVideo の場合は記録を作り直し、それ以外は既存の記録に追加する
+// 記録がない既存の動画（記録を開始する前に作成された動画）には、対応が取れないため記録しない
+func writeVideoIndex(videoPath string, frames []StoredFrame, newVideo bool) error {
+	path := videoIndexPath(videoPath)
+
+	var content strings.Builder
+	content.Grow(len(frames) * videoIndexLineSize)
+	for _, frame := range frames {
+		content.WriteString(frame.Timestamp.UTC().Format(videoIndexLayout))
+		content.WriteByte('\n')
+	}
+
+	if newVideo {
+		// 参照中の記録を壊さないよう一時ファイル経由で置き換える
+		tempPath := path + ".tmp"
+		if err := os.WriteFile(tempPath, []byte(content.String()), 0644); err != nil {
+			return fmt.Errorf("撮影時刻の記録の書き込みに失敗: %w", err)
+		}
+		if err := os.Rename(tempPath, path); err != nil {
+			return fmt.Errorf("撮影時刻の記録の置き換えに失敗: %w", err)
+		}
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("撮影時刻の記録を開けません: %w", err)
+	}
+	if _, err := file.WriteString(content.String()); err != nil {
+		_ = file.Close()
+		// 動画のフレームと対応しない記録は誤った時刻を示すため削除する
+		_ = os.Remove(path)
+		return fmt.Errorf("撮影時刻の記録の追加に失敗: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,102 @@
+package timelapse
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestVideoIndex_WriteAndSeek(t *testing.T) {
+	videoPath := filepath.Join(t.TempDir(), VideoFilename("", time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)))
+	start := time.Date(2024, 1, 2, 14, 0, 0, 0, time.Local)
+
+	frames := func(from, count int) []StoredFrame {
+		result := make([]StoredFrame, count)
+		for i := range result {
+			result[i].Timestamp = start.Add(time.Duration(from+i) * 10 * time.Second)
+		}
+		return result
+	}
+
+	// 新しい動画の記録を作成し、延長したフレームを追加する
+	if err := writeVideoIndex(videoPath, frames(0, 3), true); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeVideoIndex(videoPath, frames(3, 2), false); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := OpenVideoIndex(videoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+
+	if index.FrameCount() != 5 {
+		t.Fatalf("Expected 5 frames, got %d", index.FrameCount())
+	}
+	if index.Duration() != 5*time.Second/outputFrameRate {
+		t.Errorf("Unexpected duration %v", index.Duration())
+	}
+	last, err := index.Timestamp(4)
+	if err != nil || !last.Equal(start.Add(40*time.Second)) {
+		t.Errorf("Expected last frame at 14:00:40, got %v (%v)", last, err)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want int
+	}{
+		{start.Add(-time.Hour), 0},
+		{start.Add(20 * time.Second), 2},
+		{start.Add(24 * time.Second), 2},
+		{start.Add(26 * time.Second), 3},
+		{start.Add(time.Hour), 4},
+	}
+	for _, tt := range tests {
+		n, timestamp, err := index.Seek(tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != tt.want || !timestamp.Equal(start.Add(time.Duration(tt.want)*10*time.Second)) {
+			t.Errorf("Seek(%v) = %d (%v), want %d", tt.at.Format(time.TimeOnly), n, timestamp, tt.want)
+		}
+	}
+
+	// 動画を作り直した場合は記録も作り直す
+	if err := writeVideoIndex(videoPath, frames(0, 1), true); err != nil {
+		t.Fatal(err)
+	}
+	recreated, err := OpenVideoIndex(videoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recreated.Close()
+	if recreated.FrameCount() != 1 {
+		t.Errorf("Expected recreated index to have 1 frame, got %d", recreated.FrameCount())
+	}
+}
+
+func TestVideoIndex_Missing(t *testing.T) {
+	videoPath := filepath.Join(t.TempDir(), "timelapse_2024-01-02.mp4")
+
+	// 記録のない既存の動画には追加しない
+	if err := writeVideoIndex(videoPath, []StoredFrame{{Timestamp: time.Now()}}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(videoIndexPath(videoPath)); !os.IsNotExist(err) {
+		t.Errorf("Expected no index for a video without one, got %v", err)
+	}
+
+	if _, err := OpenVideoIndex(videoPath); !errors.Is(err, ErrVideoIndexNotFound) {
+		t.Errorf("Expected ErrVideoIndexNotFound, got %v", err)
+	}
+}
+
+func TestFrameOffset(t *testing.T) {
+	if got := FrameOffset(outputFrameRate * 90); got != 90*time.Second {
+		t.Errorf("FrameOffset = %v, want 1m30s", got)
+	}
+}
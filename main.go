@@ -2,16 +2,43 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
+	"senrigan/internal/auth"
 	"senrigan/internal/config"
 	"senrigan/internal/server"
 )
 
 func main() {
+	configPath := flag.String("config", "", "設定ファイルのパス (.yaml/.yml/.toml)")
+	hashPassword := flag.Bool("hash-password", false, "標準入力のパスワードをbcryptでハッシュ化して表示（auth.users の password_hash 用）")
+	generateToken := flag.Bool("generate-token", false, "APIトークンとそのハッシュ（auth.tokens の token_hash 用）を生成して表示")
+	flag.Parse()
+
+	// 認証設定の作成を補助するコマンド
+	if *hashPassword {
+		if err := printPasswordHash(); err != nil {
+			log.Fatalf("パスワードのハッシュ化に失敗しました: %v", err)
+		}
+		return
+	}
+	if *generateToken {
+		token, hash, err := auth.GenerateToken()
+		if err != nil {
+			log.Fatalf("APIトークンの生成に失敗しました: %v", err)
+		}
+		fmt.Printf("token: %s\ntoken_hash: %s\n", token, hash)
+		return
+	}
+
 	// 設定を読み込む
-	cfg, err := config.Load()
+	cfg, err := config.LoadFile(*configPath)
 	if err != nil {
 		log.Fatalf("設定の読み込みに失敗しました: %v", err)
 	}
@@ -27,3 +54,19 @@ func main() {
 		log.Fatalf("サーバーの起動に失敗しました: %v", err)
 	}
 }
+
+// printPasswordHash は標準入力の1行目をパスワードとしてハッシュを表示する
+func printPasswordHash() error {
+	fmt.Fprint(os.Stderr, "パスワード: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("パスワードの読み込みに失敗: %w", err)
+	}
+
+	hash, err := auth.HashPassword(strings.TrimRight(line, "\r\n"))
+	if err != nil {
+		return err
+	}
+	fmt.Println(hash)
+	return nil
+}